@@ -0,0 +1,86 @@
+package provider
+
+import (
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/yunarta/golang-quality-of-life-pack/collections"
+	"strconv"
+	"strings"
+)
+
+const accessModeAuthoritative = "authoritative"
+const accessModeAdditive = "additive"
+
+// AccessModeSchema is the mode attribute of the resources granting linked repositories access to each other.
+var AccessModeSchema = schema.StringAttribute{
+	Optional: true,
+	Computed: true,
+	Default:  stringdefault.StaticString(accessModeAdditive),
+	Validators: []validator.String{
+		stringvalidator.OneOf(accessModeAuthoritative, accessModeAdditive),
+	},
+	MarkdownDescription: "Either `authoritative` or `additive`, default value is `additive`.\n\n" +
+		"  - `authoritative` makes the list exactly match the configuration, entries added outside of this resource are reported as drift and removed on the next apply.\n" +
+		"  - `additive` only adds the configured entries and only removes the entries previously added by this resource, so several workspaces can grant access to the same repository.",
+}
+
+// accessMode returns the mode in state, states written before the mode existed are additive.
+func accessMode(mode types.String) types.String {
+	if mode.IsNull() || mode.IsUnknown() {
+		return types.StringValue(accessModeAdditive)
+	}
+
+	return mode
+}
+
+// accessDelta returns the entries to add and remove so the existing entries match the planned ones.
+// In additive mode only the owned entries, the ones in state, may be removed.
+func accessDelta(mode types.String, existing []string, owned []string, planned []string) (adding []string, removing []string) {
+	for _, entry := range planned {
+		if !collections.Contains(existing, entry) {
+			adding = append(adding, entry)
+		}
+	}
+
+	removable := existing
+	if accessMode(mode).ValueString() == accessModeAdditive {
+		removable = owned
+	}
+
+	for _, entry := range removable {
+		if collections.Contains(existing, entry) && !collections.Contains(planned, entry) {
+			removing = append(removing, entry)
+		}
+	}
+
+	return adding, removing
+}
+
+// parseAccessImportId reads the import id of the resources granting linked repositories access to each other, the
+// numeric id of the linked repository optionally followed by the mode, <id>:<mode>. The mode defaults to additive.
+func parseAccessImportId(id string) (int, types.String, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	value, mode, found := strings.Cut(id, ":")
+	if !found {
+		mode = accessModeAdditive
+	}
+
+	repositoryId, err := strconv.Atoi(value)
+	if err != nil {
+		diags.AddError(errorProvidedRepositoryMustBeNumber, fmt.Sprintf("Expected <repository id> or <repository id>:<mode>, got '%s'", id))
+		return 0, types.StringNull(), diags
+	}
+
+	if mode != accessModeAdditive && mode != accessModeAuthoritative {
+		diags.AddError("Invalid import id", fmt.Sprintf("Expected the mode to be %s or %s, got '%s'", accessModeAuthoritative, accessModeAdditive, mode))
+		return 0, types.StringNull(), diags
+	}
+
+	return repositoryId, types.StringValue(mode), diags
+}
@@ -8,7 +8,9 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
 	"github.com/yunarta/golang-quality-of-life-pack/collections"
@@ -19,6 +21,8 @@ import (
 
 import (
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 )
 
 type Assignment struct {
@@ -37,36 +41,116 @@ type AssignmentOrder struct {
 
 type Assignments []Assignment
 
+// AssignmentOptions decides which users and groups the assignments of a resource manage.
+type AssignmentOptions struct {
+	// Authoritative manages every user and group with permissions, revoking the ones not declared in the assignments.
+	Authoritative bool
+	// IgnorePrincipals are the users and groups the authoritative mode never reports nor revokes.
+	IgnorePrincipals []string
+	// MergeStrategy decides the permissions of a user or group listed in several assignment blocks.
+	MergeStrategy string
+	// StrictPrincipals fails instead of warning when a user or group of the assignments does not exist.
+	StrictPrincipals bool
+	// Target describes the resource the assignments are declared on, such as project "PROJ".
+	Target string
+}
+
+func NewAssignmentOptions(ctx context.Context, authoritative types.Bool, ignorePrincipals types.List, mergeStrategy types.String, strictPrincipals types.Bool) (AssignmentOptions, diag.Diagnostics) {
+	options := AssignmentOptions{
+		Authoritative:    authoritative.ValueBool(),
+		IgnorePrincipals: make([]string, 0),
+		MergeStrategy:    assignmentMergeStrategy(mergeStrategy).ValueString(),
+		StrictPrincipals: strictPrincipals.ValueBool(),
+	}
+
+	if ignorePrincipals.IsNull() || ignorePrincipals.IsUnknown() {
+		return options, nil
+	}
+
+	diags := ignorePrincipals.ElementsAs(ctx, &options.IgnorePrincipals, true)
+	return options, diags
+}
+
+// manages reports whether the user or group is declared in the assignments, or holds permissions managed by the
+// authoritative mode.
+func (options AssignmentOptions) manages(name string, permissions []string, declared map[string][]string) bool {
+	if declares(declared, name) {
+		return true
+	}
+
+	return options.Authoritative && len(permissions) > 0 && !options.ignores(name)
+}
+
+func (options AssignmentOptions) ignores(name string) bool {
+	for _, principal := range options.IgnorePrincipals {
+		if strings.EqualFold(principal, name) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func declares(declared map[string][]string, name string) bool {
+	for key := range declared {
+		if strings.EqualFold(key, name) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// assignmentAuthoritative returns the authoritative flag, states written before the flag existed are not authoritative.
+func assignmentAuthoritative(authoritative types.Bool) types.Bool {
+	if authoritative.IsNull() {
+		return types.BoolValue(false)
+	}
+
+	return authoritative
+}
+
 type FindUserPermissionsFunc func(user string) (*bamboo.UserPermission, error)
 type UpdateUserPermissionsFunc func(user string, requestedPermissions []string) error
 type FindGroupPermissionsFunc func(group string) (*bamboo.GroupPermission, error)
 type UpdateGroupPermissionsFunc func(group string, requestedPermissions []string) error
 
-func (assignments Assignments) CreateAssignmentOrder(ctx context.Context) (*AssignmentOrder, diag.Diagnostics) {
+// CreateAssignmentOrder resolves the permissions of every user and group, applying the blocks from the lowest to the
+// highest priority. With the override strategy the highest priority block listing a user or group decides its
+// permissions, with the union strategy it gets the permissions of every block listing it.
+func (assignments Assignments) CreateAssignmentOrder(ctx context.Context, strategy string) (*AssignmentOrder, diag.Diagnostics) {
 	var priorities []int64
-	var makeAssignments = map[int64]Assignment{}
+	// the validator rejects blocks sharing a priority, but not a priority only known at apply time, nor a state
+	// written before the validator existed, so every block of a priority is kept
+	var makeAssignments = map[int64][]Assignment{}
 	for _, assignment := range assignments {
 		priorities = append(priorities, assignment.Priority)
-		makeAssignments[assignment.Priority] = assignment
+		makeAssignments[assignment.Priority] = append(makeAssignments[assignment.Priority], assignment)
 	}
 	slices.SortFunc(priorities, func(a, b int64) int {
 		return utils.Int64Comparator(a, b)
 	})
+	priorities = slices.Compact(priorities)
 
 	var usersAssignments = map[string][]string{}
 	var groupsAssignments = map[string][]string{}
 	var userNames = make([]string, 0)
 	var groupNames = make([]string, 0)
 	for _, priority := range priorities {
-		assignment := makeAssignments[priority]
-		for _, user := range assignment.Users {
-			usersAssignments[user] = assignment.Permissions
-			userNames = append(userNames, user)
-		}
+		for _, assignment := range makeAssignments[priority] {
+			for _, user := range assignment.Users {
+				if _, found := usersAssignments[user]; !found {
+					userNames = append(userNames, user)
+				}
+				usersAssignments[user] = mergePermissions(strategy, usersAssignments[user], assignment.Permissions)
+			}
 
-		for _, group := range assignment.Groups {
-			groupsAssignments[group] = assignment.Permissions
-			groupNames = append(groupNames, group)
+			for _, group := range assignment.Groups {
+				if _, found := groupsAssignments[group]; !found {
+					groupNames = append(groupNames, group)
+				}
+				groupsAssignments[group] = mergePermissions(strategy, groupsAssignments[group], assignment.Permissions)
+			}
 		}
 	}
 
@@ -78,9 +162,69 @@ func (assignments Assignments) CreateAssignmentOrder(ctx context.Context) (*Assi
 	}, nil
 }
 
+func mergePermissions(strategy string, current []string, permissions []string) []string {
+	if strategy != mergeStrategyUnion {
+		return permissions
+	}
+
+	merged := slices.Clone(current)
+	for _, permission := range permissions {
+		if !slices.Contains(merged, permission) {
+			merged = append(merged, permission)
+		}
+	}
+
+	return merged
+}
+
+// assignmentPriorityValidator rejects assignment blocks sharing a priority, the order between them would be undefined.
+type assignmentPriorityValidator struct{}
+
+func (v assignmentPriorityValidator) Description(ctx context.Context) string {
+	return "each assignment block must have a distinct priority"
+}
+
+func (v assignmentPriorityValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v assignmentPriorityValidator) ValidateList(ctx context.Context, request validator.ListRequest, response *validator.ListResponse) {
+	if request.ConfigValue.IsNull() || request.ConfigValue.IsUnknown() {
+		return
+	}
+
+	var seen = map[int64]int{}
+	for index, element := range request.ConfigValue.Elements() {
+		block, ok := element.(types.Object)
+		if !ok {
+			continue
+		}
+
+		priority, ok := block.Attributes()["priority"].(types.Int64)
+		if !ok || priority.IsNull() || priority.IsUnknown() {
+			continue
+		}
+
+		if first, found := seen[priority.ValueInt64()]; found {
+			response.Diagnostics.AddAttributeError(request.Path.AtListIndex(index).AtName("priority"),
+				errorDuplicateAssignmentPriority,
+				fmt.Sprintf("Assignment blocks %d and %d both have priority %d, each block must have a distinct priority. "+
+					"List the users and groups in a single block, or change the priority of one of the blocks.",
+					first, index, priority.ValueInt64()),
+			)
+			continue
+		}
+
+		seen[priority.ValueInt64()] = index
+	}
+}
+
 func AssignmentSchema(permissions ...string) schema.ListNestedBlock {
 	return schema.ListNestedBlock{
 		MarkdownDescription: "Assignment block",
+		Validators: []validator.List{
+			assignmentPriorityValidator{},
+		},
 		NestedObject: schema.NestedBlockObject{
 			Attributes: map[string]schema.Attribute{
 				"users": schema.ListAttribute{
@@ -110,6 +254,65 @@ func AssignmentSchema(permissions ...string) schema.ListNestedBlock {
 	}
 }
 
+var AssignmentVersionSchema = schema.StringAttribute{
+	Optional:            true,
+	DeprecationMessage:  "Permissions changed outside of Terraform are now detected and planned as an update, remove assignment_version from the configuration.",
+	MarkdownDescription: "Assignment version, used to force update the permission. Deprecated, permissions changed outside of Terraform are detected and planned as an update.",
+}
+
+const mergeStrategyOverride = "override"
+const mergeStrategyUnion = "union"
+
+var MergeStrategySchema = schema.StringAttribute{
+	Optional: true,
+	Computed: true,
+	Default:  stringdefault.StaticString(mergeStrategyOverride),
+	Validators: []validator.String{
+		stringvalidator.OneOf(mergeStrategyOverride, mergeStrategyUnion),
+	},
+	MarkdownDescription: "Either `override` or `union`, default value is `override`. Decides the permissions of a user or group listed in several assignment blocks.\n\n" +
+		"  - `override` gives the permissions of the highest priority block listing it.\n" +
+		"  - `union` gives the permissions of every block listing it.",
+}
+
+// assignmentMergeStrategy returns the merge strategy, states written before the strategy existed override.
+func assignmentMergeStrategy(strategy types.String) types.String {
+	if strategy.IsNull() || strategy.IsUnknown() {
+		return types.StringValue(mergeStrategyOverride)
+	}
+
+	return strategy
+}
+
+var StrictPrincipalsSchema = schema.BoolAttribute{
+	Optional:            true,
+	Computed:            true,
+	Default:             booldefault.StaticBool(false),
+	MarkdownDescription: "Default value is `false`. When `true`, a user or group in `assignments` that does not exist in Bamboo fails the apply instead of producing a warning.",
+}
+
+// assignmentStrictPrincipals returns the strict principals flag, states written before the flag existed are not strict.
+func assignmentStrictPrincipals(strictPrincipals types.Bool) types.Bool {
+	if strictPrincipals.IsNull() {
+		return types.BoolValue(false)
+	}
+
+	return strictPrincipals
+}
+
+var AuthoritativeSchema = schema.BoolAttribute{
+	Optional:            true,
+	Computed:            true,
+	Default:             booldefault.StaticBool(false),
+	MarkdownDescription: "Default value is `false`. When `true`, every user and group with permissions is reported in `computed_users` and `computed_groups`, and the ones not declared in `assignments` have their permissions revoked.",
+}
+
+var IgnorePrincipalsSchema = schema.ListAttribute{
+	Optional:            true,
+	ElementType:         types.StringType,
+	MarkdownDescription: "Users and groups, such as service accounts, that `authoritative` never reports nor revokes. Compared case-insensitively.",
+}
+
 var ComputedAssignmentSchema = schema.ListNestedAttribute{
 	Computed:            true,
 	MarkdownDescription: "Computed assignment.",
@@ -158,25 +361,96 @@ var computedAssignmentType = types.ObjectType{
 type AssignmentResult struct {
 	ComputedUsers  types.List
 	ComputedGroups types.List
+	Roles          *RolesModel
+}
+
+// resolvePrincipals looks up the users and groups of the assignments, returning the ones that exist in Bamboo, and the
+// system name of each group. A user or group that does not exist is reported as a warning, or as an error with strict
+// principals, while a failed lookup is always an error.
+func (options AssignmentOptions) resolvePrincipals(userService *bamboo.UserService,
+	assignmentOrder AssignmentOrder,
+	findUserPermission FindUserPermissionsFunc,
+	findGroupPermission FindGroupPermissionsFunc) (map[string]bool, map[string]string, diag.Diagnostics) {
+
+	var diags diag.Diagnostics
+
+	resolvedUsers := map[string]bool{}
+	for _, user := range assignmentOrder.UserNames {
+		if userService.LookupUser(user) {
+			resolvedUsers[user] = true
+			continue
+		}
+
+		found, err := findUserPermission(user)
+		if err != nil {
+			diags.AddError(errorFailedToLookUpPrincipal,
+				fmt.Sprintf("Failed to look up user %q declared in the assignments of %s: %s", user, options.Target, err.Error()))
+			continue
+		}
+
+		if found == nil {
+			diags.Append(options.unresolvedPrincipal("user", user))
+			continue
+		}
+
+		userService.ValidateUser(user)
+		resolvedUsers[user] = true
+	}
+
+	resolvedGroups := map[string]string{}
+	for _, group := range assignmentOrder.GroupNames {
+		if userService.LookupGroup(group) {
+			resolvedGroups[group] = group
+			continue
+		}
+
+		found, err := findGroupPermission(group)
+		if err != nil {
+			diags.AddError(errorFailedToLookUpPrincipal,
+				fmt.Sprintf("Failed to look up group %q declared in the assignments of %s: %s", group, options.Target, err.Error()))
+			continue
+		}
+
+		if found == nil {
+			diags.Append(options.unresolvedPrincipal("group", group))
+			continue
+		}
+
+		userService.ValidateGroup(group)
+		resolvedGroups[group] = found.Name
+	}
+
+	return resolvedUsers, resolvedGroups, diags
+}
+
+func (options AssignmentOptions) unresolvedPrincipal(kind string, name string) diag.Diagnostic {
+	detail := fmt.Sprintf("The %s %q declared in the assignments of %s does not exist in Bamboo, it was not granted any permission.", kind, name, options.Target)
+	if options.StrictPrincipals {
+		return diag.NewErrorDiagnostic(errorUnresolvedPrincipal, detail)
+	}
+
+	return diag.NewWarningDiagnostic(errorUnresolvedPrincipal, detail+" Set strict_principals to true to fail instead.")
 }
 
 func ApplyNewAssignmentSet(ctx context.Context, userService *bamboo.UserService,
 	assignmentOrder AssignmentOrder,
+	options AssignmentOptions,
 	findUserPermission FindUserPermissionsFunc,
 	findGroupPermission FindGroupPermissionsFunc,
 	updateUserPermissions UpdateUserPermissionsFunc,
 	updateGroupPermissions UpdateGroupPermissionsFunc) (*AssignmentResult, diag.Diagnostics) {
 
+	resolvedUsers, resolvedGroups, diags := options.resolvePrincipals(userService, assignmentOrder, findUserPermission, findGroupPermission)
+	if diags.HasError() {
+		return nil, diags
+	}
+
 	computedUsers := make([]ComputedAssignment, 0)
 	computedGroups := make([]ComputedAssignment, 0)
 
 	for user, requestedPermissions := range assignmentOrder.Users {
-		if !userService.LookupUser(user) {
-			found, _ := findUserPermission(user)
-			if found == nil {
-				continue
-			}
-			userService.ValidateUser(user)
+		if !resolvedUsers[user] {
+			continue
 		}
 
 		computedUsers = append(computedUsers, ComputedAssignment{
@@ -191,12 +465,8 @@ func ApplyNewAssignmentSet(ctx context.Context, userService *bamboo.UserService,
 	}
 
 	for group, requestedPermissions := range assignmentOrder.Groups {
-		if !userService.LookupGroup(group) {
-			found, _ := findGroupPermission(group)
-			if found == nil {
-				continue
-			}
-			userService.ValidateGroup(group)
+		if _, found := resolvedGroups[group]; !found {
+			continue
 		}
 
 		computedGroups = append(computedGroups, ComputedAssignment{
@@ -210,49 +480,51 @@ func ApplyNewAssignmentSet(ctx context.Context, userService *bamboo.UserService,
 		}
 	}
 
-	return createAssignmentResult(ctx, computedUsers, computedGroups)
+	result, resultDiags := createAssignmentResult(ctx, computedUsers, computedGroups)
+	diags.Append(resultDiags...)
+	return result, diags
 }
 
 func UpdateAssignment(ctx context.Context, userService *bamboo.UserService,
 	inStateAssignmentOrder AssignmentOrder,
 	plannedAssignmentOrder AssignmentOrder,
 	forceUpdate bool,
+	options AssignmentOptions,
 	findUserPermission FindUserPermissionsFunc,
 	findGroupPermission FindGroupPermissionsFunc,
 	updateUserPermission UpdateUserPermissionsFunc,
 	updateGroupPermission UpdateGroupPermissionsFunc) (*AssignmentResult, diag.Diagnostics) {
 
-	computedUsers, diags := updateUsers(inStateAssignmentOrder, plannedAssignmentOrder, userService, forceUpdate, findUserPermission, updateUserPermission)
-	if diags != nil {
+	resolvedUsers, resolvedGroups, diags := options.resolvePrincipals(userService, plannedAssignmentOrder, findUserPermission, findGroupPermission)
+	if diags.HasError() {
 		return nil, diags
 	}
 
-	computedGroups, diags := updateGroups(inStateAssignmentOrder, plannedAssignmentOrder, userService, forceUpdate, findGroupPermission, updateGroupPermission)
-	if diags != nil {
-		return nil, diags
+	computedUsers, updateDiags := updateUsers(inStateAssignmentOrder, plannedAssignmentOrder, resolvedUsers, forceUpdate, updateUserPermission)
+	if updateDiags != nil {
+		return nil, updateDiags
 	}
 
-	return createAssignmentResult(ctx, computedUsers, computedGroups)
+	computedGroups, updateDiags := updateGroups(inStateAssignmentOrder, plannedAssignmentOrder, resolvedGroups, userService, forceUpdate, findGroupPermission, updateGroupPermission)
+	if updateDiags != nil {
+		return nil, updateDiags
+	}
+
+	result, resultDiags := createAssignmentResult(ctx, computedUsers, computedGroups)
+	diags.Append(resultDiags...)
+	return result, diags
 }
 
 func updateUsers(inStateAssignmentOrder AssignmentOrder, plannedAssignmentOrder AssignmentOrder,
-	userService *bamboo.UserService, forceUpdate bool, findUserPermission FindUserPermissionsFunc, updateUserPermissions UpdateUserPermissionsFunc) ([]ComputedAssignment, diag.Diagnostics) {
+	resolvedUsers map[string]bool, forceUpdate bool, updateUserPermissions UpdateUserPermissionsFunc) ([]ComputedAssignment, diag.Diagnostics) {
 	_, removing := collections.Delta(inStateAssignmentOrder.UserNames, plannedAssignmentOrder.UserNames)
 
 	var computedUsers = make([]ComputedAssignment, 0)
 	for _, user := range plannedAssignmentOrder.UserNames {
-		if collections.Contains(removing, user) {
+		if collections.Contains(removing, user) || !resolvedUsers[user] {
 			continue
 		}
 
-		if !userService.LookupUser(user) {
-			found, _ := findUserPermission(user)
-			if found == nil {
-				continue
-			}
-			userService.ValidateUser(user)
-		}
-
 		requestedPermissions := plannedAssignmentOrder.Users[user]
 		inStatePermissions := inStateAssignmentOrder.Users[user]
 		computedUsers = append(computedUsers, ComputedAssignment{
@@ -278,26 +550,16 @@ func updateUsers(inStateAssignmentOrder AssignmentOrder, plannedAssignmentOrder
 }
 
 func updateGroups(inStateAssignmentOrder AssignmentOrder, plannedAssignmentOrder AssignmentOrder,
-	userService *bamboo.UserService, forceUpdate bool, findGroupPermission FindGroupPermissionsFunc, updateGroupPermissions UpdateGroupPermissionsFunc) ([]ComputedAssignment, diag.Diagnostics) {
+	resolvedGroups map[string]string, userService *bamboo.UserService, forceUpdate bool, findGroupPermission FindGroupPermissionsFunc, updateGroupPermissions UpdateGroupPermissionsFunc) ([]ComputedAssignment, diag.Diagnostics) {
 	_, removing := collections.Delta(inStateAssignmentOrder.GroupNames, plannedAssignmentOrder.GroupNames)
 
 	var computedGroups = make([]ComputedAssignment, 0)
 	for _, group := range plannedAssignmentOrder.GroupNames {
-		if collections.Contains(removing, group) {
+		systemName, resolved := resolvedGroups[group]
+		if collections.Contains(removing, group) || !resolved {
 			continue
 		}
 
-		systemName := group
-		if !userService.LookupGroup(group) {
-			found, _ := findGroupPermission(group)
-			if found == nil {
-				continue
-			}
-
-			systemName = found.Name
-			userService.ValidateGroup(group)
-		}
-
 		requestedPermissions := plannedAssignmentOrder.Groups[group]
 		inStatePermissions := inStateAssignmentOrder.Groups[group]
 		computedGroups = append(computedGroups, ComputedAssignment{
@@ -316,7 +578,12 @@ func updateGroups(inStateAssignmentOrder AssignmentOrder, plannedAssignmentOrder
 	for _, group := range removing {
 		systemName := group
 		if !userService.LookupGroup(group) {
-			found, _ := findGroupPermission(group)
+			found, err := findGroupPermission(group)
+			if err != nil {
+				return nil, []diag.Diagnostic{diag.NewErrorDiagnostic(errorFailedToLookUpPrincipal, err.Error())}
+			}
+
+			// a group that no longer exists has no permission left to remove
 			if found == nil {
 				continue
 			}
@@ -377,20 +644,15 @@ func RemoveAssignment(ctx context.Context,
 }
 
 func ComputeAssignment(ctx context.Context,
-	assignedPermissions *bamboo.ObjectPermission, assignmentOrder AssignmentOrder) (*AssignmentResult, diag.Diagnostics) {
+	assignedPermissions *bamboo.ObjectPermission, assignmentOrder AssignmentOrder, options AssignmentOptions,
+	findUserPermission FindUserPermissionsFunc,
+	findGroupPermission FindGroupPermissionsFunc) (*AssignmentResult, diag.Diagnostics) {
 
 	computedUsers := make([]ComputedAssignment, 0)
 	computedGroups := make([]ComputedAssignment, 0)
 
 	for _, user := range assignedPermissions.Users {
-		found := false
-		for key := range assignmentOrder.Users {
-			if strings.EqualFold(key, user.Name) {
-				found = true
-				break
-			}
-		}
-		if found {
+		if options.manages(user.Name, user.Permissions, assignmentOrder.Users) {
 			computedUsers = append(computedUsers, ComputedAssignment{
 				Name:        user.Name,
 				Permissions: user.Permissions,
@@ -399,14 +661,7 @@ func ComputeAssignment(ctx context.Context,
 	}
 
 	for _, group := range assignedPermissions.Groups {
-		found := false
-		for key := range assignmentOrder.Groups {
-			if strings.EqualFold(key, group.Name) {
-				found = true
-				break
-			}
-		}
-		if found {
+		if options.manages(group.Name, group.Permissions, assignmentOrder.Groups) {
 			computedGroups = append(computedGroups, ComputedAssignment{
 				Name:        group.Name,
 				Permissions: group.Permissions,
@@ -414,9 +669,185 @@ func ComputeAssignment(ctx context.Context,
 		}
 	}
 
+	// a declared user or group without any permission was revoked outside of Terraform, it is reported with no
+	// permission so the drift shows up in the plan, unless it does not exist at all
+	for _, user := range collections.Unique(assignmentOrder.UserNames) {
+		if computedContains(computedUsers, user) {
+			continue
+		}
+
+		found, err := findUserPermission(user)
+		if err != nil {
+			return nil, []diag.Diagnostic{diag.NewErrorDiagnostic(errorFailedToLookUpPrincipal, err.Error())}
+		}
+
+		if found != nil {
+			computedUsers = append(computedUsers, ComputedAssignment{
+				Name:        user,
+				Permissions: make([]string, 0),
+			})
+		}
+	}
+
+	for _, group := range collections.Unique(assignmentOrder.GroupNames) {
+		if computedContains(computedGroups, group) {
+			continue
+		}
+
+		found, err := findGroupPermission(group)
+		if err != nil {
+			return nil, []diag.Diagnostic{diag.NewErrorDiagnostic(errorFailedToLookUpPrincipal, err.Error())}
+		}
+
+		if found != nil {
+			computedGroups = append(computedGroups, ComputedAssignment{
+				Name:        group,
+				Permissions: make([]string, 0),
+			})
+		}
+	}
+
 	return createAssignmentResult(ctx, computedUsers, computedGroups)
 }
 
+func computedContains(computed []ComputedAssignment, name string) bool {
+	for _, assignment := range computed {
+		if strings.EqualFold(assignment.Name, name) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// AssignmentDrift reports whether the permissions read from Bamboo into computed_users and computed_groups differ from
+// the ones declared in assignments, such as a permission changed in the UI.
+func AssignmentDrift(ctx context.Context, state tfsdk.State) (bool, diag.Diagnostics) {
+	var (
+		assignments                   Assignments
+		mergeStrategy                 types.String
+		computedUsers, computedGroups types.List
+	)
+
+	diags := state.GetAttribute(ctx, path.Root("assignments"), &assignments)
+	if diags.HasError() {
+		return false, diags
+	}
+
+	diags = state.GetAttribute(ctx, path.Root("merge_strategy"), &mergeStrategy)
+	if diags.HasError() {
+		return false, diags
+	}
+
+	diags = state.GetAttribute(ctx, path.Root("computed_users"), &computedUsers)
+	if diags.HasError() {
+		return false, diags
+	}
+
+	diags = state.GetAttribute(ctx, path.Root("computed_groups"), &computedGroups)
+	if diags.HasError() {
+		return false, diags
+	}
+
+	// nothing has been read from Bamboo yet
+	if computedUsers.IsNull() || computedUsers.IsUnknown() || computedGroups.IsNull() || computedGroups.IsUnknown() {
+		return false, nil
+	}
+
+	assignmentOrder, diags := assignments.CreateAssignmentOrder(ctx, assignmentMergeStrategy(mergeStrategy).ValueString())
+	if diags != nil {
+		return false, diags
+	}
+
+	var users, groups []ComputedAssignment
+	diags = computedUsers.ElementsAs(ctx, &users, true)
+	if diags.HasError() {
+		return false, diags
+	}
+
+	diags = computedGroups.ElementsAs(ctx, &groups, true)
+	if diags.HasError() {
+		return false, diags
+	}
+
+	return computedDrift(users, assignmentOrder.Users) || computedDrift(groups, assignmentOrder.Groups), nil
+}
+
+func computedDrift(computed []ComputedAssignment, declared map[string][]string) bool {
+	for _, assignment := range computed {
+		drifted := true
+		for name, permissions := range declared {
+			if strings.EqualFold(name, assignment.Name) {
+				drifted = !collections.EqualsIgnoreOrder(permissions, assignment.Permissions)
+				break
+			}
+		}
+
+		if drifted {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ModifyAssignmentPlan plans an update of the computed assignments when they drifted from the declared assignments,
+// so the permissions are pushed again without bumping assignment_version.
+func ModifyAssignmentPlan(ctx context.Context, state tfsdk.State, plan *tfsdk.Plan) diag.Diagnostics {
+	drifted, diags := AssignmentDrift(ctx, state)
+	if diags.HasError() || !drifted {
+		return diags
+	}
+
+	diags = plan.SetAttribute(ctx, path.Root("computed_users"), types.ListUnknown(computedAssignmentType))
+	if diags.HasError() {
+		return diags
+	}
+
+	return plan.SetAttribute(ctx, path.Root("computed_groups"), types.ListUnknown(computedAssignmentType))
+}
+
+// RevokeUnmanagedAssignment revokes the permissions of the users and groups not declared in the assignments, in
+// authoritative mode only.
+func RevokeUnmanagedAssignment(assignmentOrder AssignmentOrder, options AssignmentOptions,
+	readPermissions func() (*bamboo.ObjectPermission, error),
+	updateUserPermissions UpdateUserPermissionsFunc,
+	updateGroupPermissions UpdateGroupPermissionsFunc) diag.Diagnostics {
+
+	if !options.Authoritative {
+		return nil
+	}
+
+	assignedPermissions, err := readPermissions()
+	if err != nil {
+		return []diag.Diagnostic{diag.NewErrorDiagnostic(errorFailedToReadPermissions, err.Error())}
+	}
+
+	for _, user := range assignedPermissions.Users {
+		if declares(assignmentOrder.Users, user.Name) || !options.manages(user.Name, user.Permissions, assignmentOrder.Users) {
+			continue
+		}
+
+		err = updateUserPermissions(user.Name, make([]string, 0))
+		if err != nil {
+			return []diag.Diagnostic{diag.NewErrorDiagnostic(failedToRemoveUserPermissions, err.Error())}
+		}
+	}
+
+	for _, group := range assignedPermissions.Groups {
+		if declares(assignmentOrder.Groups, group.Name) || !options.manages(group.Name, group.Permissions, assignmentOrder.Groups) {
+			continue
+		}
+
+		err = updateGroupPermissions(group.Name, make([]string, 0))
+		if err != nil {
+			return []diag.Diagnostic{diag.NewErrorDiagnostic(failedToRemoveGroupPermissions, err.Error())}
+		}
+	}
+
+	return nil
+}
+
 func createAssignmentResult(ctx context.Context, computedUsers []ComputedAssignment, computedGroups []ComputedAssignment) (*AssignmentResult, diag.Diagnostics) {
 	computedUsersList, diags := createTfList(ctx, computedUsers)
 	if diags != nil {
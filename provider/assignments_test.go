@@ -0,0 +1,219 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/yunarta/terraform-atlassian-api-client/bamboo"
+)
+
+func TestAssignments_CreateAssignmentOrder(t *testing.T) {
+	tests := []struct {
+		name          string
+		strategy      string
+		assignments   Assignments
+		wantUsers     map[string][]string
+		wantUserNames []string
+		wantGroups    map[string][]string
+	}{
+		{
+			name:     "single block",
+			strategy: mergeStrategyOverride,
+			assignments: Assignments{
+				{Users: []string{"alice"}, Groups: []string{"developers"}, Permissions: []string{"READ"}, Priority: 1},
+			},
+			wantUsers:     map[string][]string{"alice": {"READ"}},
+			wantUserNames: []string{"alice"},
+			wantGroups:    map[string][]string{"developers": {"READ"}},
+		},
+		{
+			name:     "highest priority overrides",
+			strategy: mergeStrategyOverride,
+			assignments: Assignments{
+				{Users: []string{"alice", "bob"}, Permissions: []string{"READ", "ADMINISTRATION"}, Priority: 2},
+				{Users: []string{"alice"}, Groups: []string{"developers"}, Permissions: []string{"READ"}, Priority: 1},
+			},
+			wantUsers:     map[string][]string{"alice": {"READ", "ADMINISTRATION"}, "bob": {"READ", "ADMINISTRATION"}},
+			wantUserNames: []string{"alice", "bob"},
+			wantGroups:    map[string][]string{"developers": {"READ"}},
+		},
+		{
+			name:     "lower priority does not override",
+			strategy: mergeStrategyOverride,
+			assignments: Assignments{
+				{Users: []string{"alice"}, Permissions: []string{"READ"}, Priority: 1},
+				{Users: []string{"alice"}, Permissions: []string{"ADMINISTRATION"}, Priority: 10},
+			},
+			wantUsers:     map[string][]string{"alice": {"ADMINISTRATION"}},
+			wantUserNames: []string{"alice"},
+			wantGroups:    map[string][]string{},
+		},
+		{
+			name:     "union merges every block",
+			strategy: mergeStrategyUnion,
+			assignments: Assignments{
+				{Users: []string{"alice"}, Groups: []string{"developers"}, Permissions: []string{"ADMINISTRATION"}, Priority: 2},
+				{Users: []string{"alice", "bob"}, Groups: []string{"developers"}, Permissions: []string{"READ", "ADMINISTRATION"}, Priority: 1},
+			},
+			wantUsers:     map[string][]string{"alice": {"READ", "ADMINISTRATION"}, "bob": {"READ", "ADMINISTRATION"}},
+			wantUserNames: []string{"alice", "bob"},
+			wantGroups:    map[string][]string{"developers": {"READ", "ADMINISTRATION"}},
+		},
+		{
+			name:     "duplicate priorities keep every block once",
+			strategy: mergeStrategyOverride,
+			assignments: Assignments{
+				{Users: []string{"alice"}, Permissions: []string{"READ"}, Priority: 1},
+				{Users: []string{"bob"}, Permissions: []string{"ADMINISTRATION"}, Priority: 1},
+			},
+			wantUsers:     map[string][]string{"alice": {"READ"}, "bob": {"ADMINISTRATION"}},
+			wantUserNames: []string{"alice", "bob"},
+			wantGroups:    map[string][]string{},
+		},
+		{
+			name:          "no block",
+			strategy:      mergeStrategyOverride,
+			assignments:   Assignments{},
+			wantUsers:     map[string][]string{},
+			wantUserNames: []string{},
+			wantGroups:    map[string][]string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			order, diags := tt.assignments.CreateAssignmentOrder(context.Background(), tt.strategy)
+			if diags.HasError() {
+				t.Fatalf("CreateAssignmentOrder() diagnostics = %v", diags)
+			}
+
+			if !reflect.DeepEqual(order.Users, tt.wantUsers) {
+				t.Errorf("CreateAssignmentOrder() users = %v, want %v", order.Users, tt.wantUsers)
+			}
+
+			if !reflect.DeepEqual(order.UserNames, tt.wantUserNames) {
+				t.Errorf("CreateAssignmentOrder() user names = %v, want %v", order.UserNames, tt.wantUserNames)
+			}
+
+			if !reflect.DeepEqual(order.Groups, tt.wantGroups) {
+				t.Errorf("CreateAssignmentOrder() groups = %v, want %v", order.Groups, tt.wantGroups)
+			}
+		})
+	}
+}
+
+func TestAssignmentPriorityValidator(t *testing.T) {
+	tests := []struct {
+		name        string
+		assignments []Assignment
+		wantErrors  int
+	}{
+		{
+			name: "distinct priorities",
+			assignments: []Assignment{
+				{Users: []string{"alice"}, Permissions: []string{"READ"}, Priority: 1},
+				{Users: []string{"bob"}, Permissions: []string{"READ"}, Priority: 2},
+			},
+		},
+		{
+			name: "duplicate priority",
+			assignments: []Assignment{
+				{Users: []string{"alice"}, Permissions: []string{"READ"}, Priority: 1},
+				{Users: []string{"bob"}, Permissions: []string{"READ"}, Priority: 1},
+			},
+			wantErrors: 1,
+		},
+		{
+			name: "every duplicate is reported",
+			assignments: []Assignment{
+				{Users: []string{"alice"}, Permissions: []string{"READ"}, Priority: 1},
+				{Users: []string{"bob"}, Permissions: []string{"READ"}, Priority: 1},
+				{Users: []string{"carol"}, Permissions: []string{"READ"}, Priority: 1},
+			},
+			wantErrors: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			value, diags := types.ListValueFrom(ctx, AssignmentSchema("READ").NestedObject.Type(), tt.assignments)
+			if diags.HasError() {
+				t.Fatalf("ListValueFrom() diagnostics = %v", diags)
+			}
+
+			response := &validator.ListResponse{}
+			assignmentPriorityValidator{}.ValidateList(ctx, validator.ListRequest{
+				Path:        path.Root("assignments"),
+				ConfigValue: value,
+			}, response)
+
+			if got := response.Diagnostics.ErrorsCount(); got != tt.wantErrors {
+				t.Errorf("ValidateList() errors = %d, want %d: %v", got, tt.wantErrors, response.Diagnostics)
+			}
+		})
+	}
+}
+
+func TestAssignmentOptions_ResolvePrincipals(t *testing.T) {
+	order := AssignmentOrder{
+		Users:      map[string][]string{"alice": {"READ"}, "ghost": {"READ"}},
+		UserNames:  []string{"alice", "ghost"},
+		Groups:     map[string][]string{"developers": {"READ"}},
+		GroupNames: []string{"developers"},
+	}
+
+	tests := []struct {
+		name         string
+		strict       bool
+		groupErr     error
+		wantUsers    map[string]bool
+		wantErrors   int
+		wantWarnings int
+	}{
+		{name: "not found warns", wantUsers: map[string]bool{"alice": true}, wantWarnings: 1},
+		{name: "not found fails when strict", strict: true, wantUsers: map[string]bool{"alice": true}, wantErrors: 1},
+		{name: "lookup failure always fails", groupErr: errors.New("connection refused"), wantUsers: map[string]bool{"alice": true}, wantErrors: 1, wantWarnings: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			options := AssignmentOptions{StrictPrincipals: tt.strict, Target: `project "PROJ"`}
+			users, groups, diags := options.resolvePrincipals(bamboo.NewBambooClient(nil).UserService(), order,
+				func(user string) (*bamboo.UserPermission, error) {
+					if user == "ghost" {
+						return nil, nil
+					}
+					return &bamboo.UserPermission{Name: user}, nil
+				},
+				func(group string) (*bamboo.GroupPermission, error) {
+					if tt.groupErr != nil {
+						return nil, tt.groupErr
+					}
+					return &bamboo.GroupPermission{Name: "Developers"}, nil
+				},
+			)
+
+			if !reflect.DeepEqual(users, tt.wantUsers) {
+				t.Errorf("resolvePrincipals() users = %v, want %v", users, tt.wantUsers)
+			}
+
+			if tt.groupErr == nil && groups["developers"] != "Developers" {
+				t.Errorf("resolvePrincipals() developers system name = %q, want Developers", groups["developers"])
+			}
+
+			if got := diags.ErrorsCount(); got != tt.wantErrors {
+				t.Errorf("resolvePrincipals() errors = %d, want %d: %v", got, tt.wantErrors, diags)
+			}
+
+			if got := diags.WarningsCount(); got != tt.wantWarnings {
+				t.Errorf("resolvePrincipals() warnings = %d, want %d: %v", got, tt.wantWarnings, diags)
+			}
+		})
+	}
+}
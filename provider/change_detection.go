@@ -0,0 +1,144 @@
+package provider
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+type ChangeDetectionModel struct {
+	Trigger               types.String `tfsdk:"trigger"`
+	PollingInterval       types.Int64  `tfsdk:"polling_interval"`
+	IncludePaths          types.String `tfsdk:"include_paths"`
+	ExcludePaths          types.String `tfsdk:"exclude_paths"`
+	ExcludeCommitMessages types.String `tfsdk:"exclude_commit_messages"`
+	ShallowClones         types.Bool   `tfsdk:"shallow_clones"`
+	Submodules            types.Bool   `tfsdk:"submodules"`
+	Lfs                   types.Bool   `tfsdk:"lfs"`
+}
+
+var ChangeDetectionSchema = schema.SingleNestedBlock{
+	MarkdownDescription: "Change detection and checkout settings of the linked repository, settings left unset keep the Bamboo default.",
+	Attributes: map[string]schema.Attribute{
+		"trigger": schema.StringAttribute{
+			Optional: true,
+			Validators: []validator.String{
+				stringvalidator.OneOf(changeDetectionTriggerWebhook, changeDetectionTriggerPolling),
+			},
+			MarkdownDescription: "How Bamboo detects new commits (webhook, polling). Default value is `webhook`.",
+		},
+		"polling_interval": schema.Int64Attribute{
+			Optional: true,
+			Validators: []validator.Int64{
+				int64validator.AtLeast(1),
+			},
+			MarkdownDescription: "Polling interval in seconds when `trigger` is `polling`. Default value is `180`.",
+		},
+		"include_paths": schema.StringAttribute{
+			Optional: true,
+			Validators: []validator.String{
+				stringvalidator.ConflictsWith(path.MatchRelative().AtParent().AtName("exclude_paths")),
+			},
+			MarkdownDescription: "Regular expression of file paths, only commits changing a matching file trigger a build.",
+		},
+		"exclude_paths": schema.StringAttribute{
+			Optional:            true,
+			MarkdownDescription: "Regular expression of file paths, commits changing only matching files do not trigger a build.",
+		},
+		"exclude_commit_messages": schema.StringAttribute{
+			Optional:            true,
+			MarkdownDescription: "Regular expression of commit messages, matching commits do not trigger a build.",
+		},
+		"shallow_clones": schema.BoolAttribute{
+			Optional:            true,
+			MarkdownDescription: "Fetch only the latest commit of the branch. Default value is `false`.",
+		},
+		"submodules": schema.BoolAttribute{
+			Optional:            true,
+			MarkdownDescription: "Check out the submodules of the repository. Default value is `false`.",
+		},
+		"lfs": schema.BoolAttribute{
+			Optional:            true,
+			MarkdownDescription: "Fetch Git LFS objects. Default value is `false`.",
+		},
+	},
+}
+
+// settings converts the block into the settings sent to Bamboo, a missing block or attribute takes the Bamboo default.
+func (model *ChangeDetectionModel) settings() RepositoryChangeDetection {
+	detection := DefaultRepositoryChangeDetection()
+	if model == nil {
+		return detection
+	}
+
+	if !model.Trigger.IsNull() {
+		detection.WebhookEnabled = model.Trigger.ValueString() != changeDetectionTriggerPolling
+	}
+
+	if !model.PollingInterval.IsNull() {
+		detection.PollingInterval = int(model.PollingInterval.ValueInt64())
+	}
+
+	detection.IncludePaths = model.IncludePaths.ValueString()
+	detection.ExcludePaths = model.ExcludePaths.ValueString()
+	detection.ExcludeCommitMessages = model.ExcludeCommitMessages.ValueString()
+	detection.ShallowClones = model.ShallowClones.ValueBool()
+	detection.Submodules = model.Submodules.ValueBool()
+	detection.Lfs = model.Lfs.ValueBool()
+	return detection
+}
+
+// refreshChangeDetection returns the block matching the settings read from Bamboo,
+// attributes left unset stay unset while Bamboo keeps the default value, so that only real drift is reported.
+func refreshChangeDetection(known *ChangeDetectionModel, remote RepositoryChangeDetection) *ChangeDetectionModel {
+	defaults := DefaultRepositoryChangeDetection()
+	if known == nil {
+		if remote == defaults {
+			return nil
+		}
+
+		known = &ChangeDetectionModel{}
+	}
+
+	trigger := changeDetectionTriggerWebhook
+	if !remote.WebhookEnabled {
+		trigger = changeDetectionTriggerPolling
+	}
+
+	refreshed := &ChangeDetectionModel{
+		Trigger:               known.Trigger,
+		PollingInterval:       known.PollingInterval,
+		IncludePaths:          refreshOptionalString(known.IncludePaths, remote.IncludePaths),
+		ExcludePaths:          refreshOptionalString(known.ExcludePaths, remote.ExcludePaths),
+		ExcludeCommitMessages: refreshOptionalString(known.ExcludeCommitMessages, remote.ExcludeCommitMessages),
+		ShallowClones:         refreshBool(known.ShallowClones, remote.ShallowClones),
+		Submodules:            refreshBool(known.Submodules, remote.Submodules),
+		Lfs:                   refreshBool(known.Lfs, remote.Lfs),
+	}
+
+	if !known.Trigger.IsNull() || remote.WebhookEnabled != defaults.WebhookEnabled {
+		refreshed.Trigger = types.StringValue(trigger)
+	}
+
+	if !known.PollingInterval.IsNull() || remote.PollingInterval != defaults.PollingInterval {
+		refreshed.PollingInterval = types.Int64Value(int64(remote.PollingInterval))
+	}
+
+	return refreshed
+}
+
+// refreshOptionalString returns the value read from Bamboo, where an empty value means the setting is unset.
+func refreshOptionalString(known types.String, remote string) types.String {
+	if known.ValueString() == remote {
+		return known
+	}
+
+	if remote == "" {
+		return types.StringNull()
+	}
+
+	return types.StringValue(remote)
+}
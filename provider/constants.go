@@ -4,10 +4,22 @@ const failedToUpdateUserPermissions = "Failed to update user permissions"
 const failedToRemoveUserPermissions = "Failed to remove user permissions"
 const failedToUpdateGroupPermissions = "Failed to update group permissions"
 const failedToRemoveGroupPermissions = "Failed to remove group permissions"
+const errorFailedToReadPermissions = "Failed to read permissions"
+
+const linkedRepositoryTypeBitbucketServer = "bitbucket_server"
+const linkedRepositoryTypeGit = "git"
+const linkedRepositoryTypeGitHub = "github"
+const linkedRepositoryTypeGitLab = "gitlab"
+
+const changeDetectionTriggerWebhook = "webhook"
+const changeDetectionTriggerPolling = "polling"
+const defaultPollingInterval = 180
 
 const errorProvidedRepositoryMustBeNumber = "Provided repository must be a number"
 const errorProvidedDeploymentIdMustBeNumber = "Provided ID must be a number"
+const errorProvidedReleaseIdMustBeNumber = "Provided release ID must be a number"
 const errorFailedToReadDeployment = "Failed to read deployment"
+const errorAmbiguousDeploymentName = "Ambiguous deployment name"
 const errorFailedToAddDeployment = "Failed to add deployment"
 const errorFailedToReadRepository = "Failed to read repository"
 const errorFailedToAddRepository = "Failed to add repository"
@@ -18,3 +30,27 @@ const errorFailedToRemoveProjectRepositories = "Failed to remove project reposit
 const errorFailedToReadRepositoryAccessor = "Failed to read repository accessor"
 const errorFailedToAddRepositoryAccessor = "Failed to add repository accessor"
 const errorFailedToRemoveRepositoryAccessor = "Failed to remove repository accessor"
+const errorFailedToUpdateRepositoryAccessor = "Failed to update repository accessor"
+const errorFailedToReadDeploymentReleases = "Failed to read deployment releases"
+const errorFailedToReadDeploymentStatus = "Failed to read deployment status"
+const errorFailedToCreateDeploymentRelease = "Failed to create deployment release"
+const errorFailedToReadDeploymentRelease = "Failed to read deployment release"
+const errorFailedToDeleteDeploymentRelease = "Failed to delete deployment release"
+const errorDeploymentManagedBySpecs = "Deployment is managed by repository specs"
+const errorFailedToScanRepository = "Failed to execute scan repository"
+const errorFailedToReadSpecsScan = "Failed to read Bamboo Specs scan"
+const errorSpecsScanCancelled = "Bamboo Specs scan wait cancelled"
+const errorSpecsScanTimeout = "Timed out waiting for Bamboo Specs scan"
+const errorFailedToReadRepositoryUsage = "Failed to read repository usage"
+const errorFailedToReadRepositoryPermissions = "Failed to read repository permissions"
+const errorLinkedRepositoryInUse = "Linked repository is in use"
+const errorFailedToReadProjects = "Failed to read projects"
+const errorFailedToReadProjectRepositories = "Failed to read project repositories"
+const errorFailedToReadDeploymentRepositories = "Failed to read deployment repositories"
+const errorFailedToAddDeploymentRepositories = "Failed to add deployment repositories"
+const errorFailedToRemoveDeploymentRepositories = "Failed to remove deployment repositories"
+const errorDuplicateAssignmentPriority = "Duplicate assignment priority"
+const errorUnresolvedPrincipal = "Unknown user or group"
+const errorFailedToLookUpPrincipal = "Failed to look up user or group"
+const errorFailedToReadRolePermissions = "Failed to read role permissions"
+const errorFailedToUpdateRolePermissions = "Failed to update role permissions"
@@ -8,20 +8,33 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/yunarta/terraform-atlassian-api-client/bamboo"
 	"github.com/yunarta/terraform-provider-commons/util"
+	"sort"
 	"strconv"
 )
 
 type DeploymentData struct {
-	Id     types.String `tfsdk:"id"`
-	Name   types.String `tfsdk:"name"`
-	Users  types.Map    `tfsdk:"users"`
-	Groups types.Map    `tfsdk:"groups"`
+	Id                     types.String                `tfsdk:"id"`
+	Name                   types.String                `tfsdk:"name"`
+	PlanKey                types.String                `tfsdk:"plan_key"`
+	Description            types.String                `tfsdk:"description"`
+	RepositorySpecsManaged types.Bool                  `tfsdk:"repository_specs_managed"`
+	Repositories           []string                    `tfsdk:"repositories"`
+	Environments           []DeploymentEnvironmentData `tfsdk:"environments"`
+	Users                  types.Map                   `tfsdk:"users"`
+	Groups                 types.Map                   `tfsdk:"groups"`
+}
+
+type DeploymentEnvironmentData struct {
+	Id       int64  `tfsdk:"id"`
+	Name     string `tfsdk:"name"`
+	Position int64  `tfsdk:"position"`
 }
 
 var (
 	_ datasource.DataSource              = &DeploymentDataSource{}
 	_ datasource.DataSourceWithConfigure = &DeploymentDataSource{}
 	_ ConfigurableReceiver               = &DeploymentDataSource{}
+	_ ExtendedConfigurableReceiver       = &DeploymentDataSource{}
 )
 
 func NewDeploymentDataSource() datasource.DataSource {
@@ -29,8 +42,9 @@ func NewDeploymentDataSource() datasource.DataSource {
 }
 
 type DeploymentDataSource struct {
-	config BambooProviderConfig
-	client *bamboo.Client
+	config         BambooProviderConfig
+	client         *bamboo.Client
+	extendedClient *ExtendedClient
 }
 
 func (receiver *DeploymentDataSource) setConfig(config BambooProviderConfig, client *bamboo.Client) {
@@ -38,6 +52,10 @@ func (receiver *DeploymentDataSource) setConfig(config BambooProviderConfig, cli
 	receiver.client = client
 }
 
+func (receiver *DeploymentDataSource) setExtendedClient(extendedClient *ExtendedClient) {
+	receiver.extendedClient = extendedClient
+}
+
 func (receiver *DeploymentDataSource) Configure(ctx context.Context, request datasource.ConfigureRequest, response *datasource.ConfigureResponse) {
 	ConfigureDataSource(receiver, ctx, request, response)
 }
@@ -48,7 +66,9 @@ func (receiver *DeploymentDataSource) Metadata(ctx context.Context, request data
 
 func (receiver *DeploymentDataSource) Schema(ctx context.Context, request datasource.SchemaRequest, response *datasource.SchemaResponse) {
 	response.Schema = schema.Schema{
-		MarkdownDescription: "This data source used define a lookup of deployment project by name.",
+		MarkdownDescription: `This data source used define a lookup of deployment project by name.
+
+The environments list can be used to feed environment ids into agent assignments and other environment level resources.`,
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
 				Computed:            true,
@@ -58,6 +78,43 @@ func (receiver *DeploymentDataSource) Schema(ctx context.Context, request dataso
 				Required:            true,
 				MarkdownDescription: "Deployment project name.",
 			},
+			"plan_key": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Plan key that is the source of the deployment.",
+			},
+			"description": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Description of the deployment.",
+			},
+			"repository_specs_managed": schema.BoolAttribute{
+				Computed:            true,
+				MarkdownDescription: "Computed value that defines the deployment is managed by repository spec.",
+			},
+			"repositories": schema.ListAttribute{
+				Computed:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "List of linked repository ids that are allowed to manage this deployment with Bamboo Spec.",
+			},
+			"environments": schema.ListNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "List of environments of the deployment, ordered by their position.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.Int64Attribute{
+							Computed:            true,
+							MarkdownDescription: "Numeric id of the environment.",
+						},
+						"name": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Name of the environment.",
+						},
+						"position": schema.Int64Attribute{
+							Computed:            true,
+							MarkdownDescription: "Position of the environment in the deployment, as ordered in Bamboo.",
+						},
+					},
+				},
+			},
 			"users": schema.MapAttribute{
 				Computed: true,
 				ElementType: types.ListType{
@@ -87,8 +144,8 @@ func (receiver *DeploymentDataSource) Read(ctx context.Context, request datasour
 		return
 	}
 
-	deployment, err := receiver.client.DeploymentService().Read(data.Name.ValueString())
-	if util.TestError(&response.Diagnostics, err, errorFailedToReadDeployment) {
+	deployment, diags := ReadDeploymentByName(receiver.client, receiver.extendedClient, data.Name.ValueString())
+	if util.TestDiagnostic(&response.Diagnostics, diags) {
 		return
 	}
 
@@ -97,6 +154,31 @@ func (receiver *DeploymentDataSource) Read(ctx context.Context, request datasour
 		return
 	}
 
+	repositories, err := receiver.client.DeploymentService().GetSpecRepositories(deployment.ID)
+	if util.TestError(&response.Diagnostics, err, "Failed to read deployment repositories") {
+		return
+	}
+
+	var repositoryIDs = make([]string, 0)
+	for _, repository := range repositories {
+		repositoryIDs = append(repositoryIDs, strconv.Itoa(repository.ID))
+	}
+	sort.Strings(repositoryIDs)
+
+	deploymentEnvironments, err := receiver.extendedClient.DeploymentService().ReadEnvironments(deployment.ID)
+	if util.TestError(&response.Diagnostics, err, errorFailedToReadDeployment) {
+		return
+	}
+
+	var environments = make([]DeploymentEnvironmentData, 0)
+	for _, environment := range deploymentEnvironments {
+		environments = append(environments, DeploymentEnvironmentData{
+			Id:       int64(environment.ID),
+			Name:     environment.Name,
+			Position: int64(environment.Position),
+		})
+	}
+
 	assignedPermissions, err := receiver.client.DeploymentService().ReadPermissions(deployment.ID)
 	if util.TestError(&response.Diagnostics, err, "Failed to read deployment permissions") {
 		return
@@ -108,10 +190,15 @@ func (receiver *DeploymentDataSource) Read(ctx context.Context, request datasour
 	}
 
 	diags = response.State.Set(ctx, &DeploymentData{
-		Id:     types.StringValue(strconv.Itoa(deployment.ID)),
-		Name:   types.StringValue(deployment.Name),
-		Users:  users,
-		Groups: groups,
+		Id:                     types.StringValue(strconv.Itoa(deployment.ID)),
+		Name:                   types.StringValue(deployment.Name),
+		PlanKey:                types.StringValue(deployment.PlanKey.Key),
+		Description:            util.NullString(deployment.Description),
+		RepositorySpecsManaged: types.BoolValue(deployment.RepositorySpecsManaged),
+		Repositories:           repositoryIDs,
+		Environments:           environments,
+		Users:                  users,
+		Groups:                 groups,
 	})
 	if util.TestDiagnostic(&response.Diagnostics, diags) {
 		return
@@ -0,0 +1,178 @@
+package provider
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/yunarta/terraform-atlassian-api-client/bamboo"
+	"github.com/yunarta/terraform-provider-commons/util"
+	"sort"
+	"strconv"
+	"time"
+)
+
+type DeploymentReleasesData struct {
+	DeploymentId types.String            `tfsdk:"deployment_id"`
+	Releases     []DeploymentReleaseData `tfsdk:"releases"`
+}
+
+type DeploymentReleaseData struct {
+	Id            string   `tfsdk:"id"`
+	Name          string   `tfsdk:"name"`
+	CreationDate  string   `tfsdk:"creation_date"`
+	PlanResultKey string   `tfsdk:"plan_result_key"`
+	Environments  []string `tfsdk:"environments"`
+}
+
+var (
+	_ datasource.DataSource              = &DeploymentReleasesDataSource{}
+	_ datasource.DataSourceWithConfigure = &DeploymentReleasesDataSource{}
+	_ ConfigurableReceiver               = &DeploymentReleasesDataSource{}
+	_ ExtendedConfigurableReceiver       = &DeploymentReleasesDataSource{}
+)
+
+func NewDeploymentReleasesDataSource() datasource.DataSource {
+	return &DeploymentReleasesDataSource{}
+}
+
+type DeploymentReleasesDataSource struct {
+	config         BambooProviderConfig
+	client         *bamboo.Client
+	extendedClient *ExtendedClient
+}
+
+func (receiver *DeploymentReleasesDataSource) setConfig(config BambooProviderConfig, client *bamboo.Client) {
+	receiver.config = config
+	receiver.client = client
+}
+
+func (receiver *DeploymentReleasesDataSource) setExtendedClient(extendedClient *ExtendedClient) {
+	receiver.extendedClient = extendedClient
+}
+
+func (receiver *DeploymentReleasesDataSource) Configure(ctx context.Context, request datasource.ConfigureRequest, response *datasource.ConfigureResponse) {
+	ConfigureDataSource(receiver, ctx, request, response)
+}
+
+func (receiver *DeploymentReleasesDataSource) Metadata(ctx context.Context, request datasource.MetadataRequest, response *datasource.MetadataResponse) {
+	response.TypeName = request.ProviderTypeName + "_deployment_releases"
+}
+
+func (receiver *DeploymentReleasesDataSource) Schema(ctx context.Context, request datasource.SchemaRequest, response *datasource.SchemaResponse) {
+	response.Schema = schema.Schema{
+		MarkdownDescription: "This data source list the releases of a deployment, and the environments where each release is currently deployed.",
+		Attributes: map[string]schema.Attribute{
+			"deployment_id": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Numeric id of the deployment.",
+			},
+			"releases": schema.ListNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "List of releases, newest first.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Numeric id of the release.",
+						},
+						"name": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Name of the release.",
+						},
+						"creation_date": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Creation date of the release in RFC 3339 format.",
+						},
+						"plan_result_key": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Plan result key the release was created from.",
+						},
+						"environments": schema.ListAttribute{
+							Computed:            true,
+							ElementType:         types.StringType,
+							MarkdownDescription: "Names of the environments where this release is the latest deployment.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (receiver *DeploymentReleasesDataSource) Read(ctx context.Context, request datasource.ReadRequest, response *datasource.ReadResponse) {
+	var (
+		diags diag.Diagnostics
+
+		data DeploymentReleasesData
+	)
+
+	diags = request.Config.Get(ctx, &data)
+	if util.TestDiagnostic(&response.Diagnostics, diags) {
+		return
+	}
+
+	deploymentId, err := strconv.Atoi(data.DeploymentId.ValueString())
+	if util.TestError(&response.Diagnostics, err, errorProvidedDeploymentIdMustBeNumber) {
+		return
+	}
+
+	versions, err := receiver.extendedClient.DeploymentService().ReadVersions(deploymentId)
+	if util.TestError(&response.Diagnostics, err, errorFailedToReadDeploymentReleases) {
+		return
+	}
+
+	status, err := receiver.extendedClient.DeploymentService().ReadStatus(deploymentId)
+	if util.TestError(&response.Diagnostics, err, errorFailedToReadDeploymentStatus) {
+		return
+	}
+
+	var deployedEnvironments = make(map[int][]string)
+	for _, environmentStatus := range status.EnvironmentStatuses {
+		result := environmentStatus.DeploymentResult
+		if result == nil || result.DeploymentVersion == nil {
+			continue
+		}
+
+		versionId := result.DeploymentVersion.ID
+		deployedEnvironments[versionId] = append(deployedEnvironments[versionId], environmentStatus.Environment.Name)
+	}
+
+	sort.SliceStable(versions, func(i, j int) bool {
+		return versions[i].CreationDate > versions[j].CreationDate
+	})
+
+	var releases = make([]DeploymentReleaseData, 0)
+	for _, version := range versions {
+		environments, ok := deployedEnvironments[version.ID]
+		if !ok {
+			environments = make([]string, 0)
+		}
+
+		releases = append(releases, DeploymentReleaseData{
+			Id:            strconv.Itoa(version.ID),
+			Name:          version.Name,
+			CreationDate:  formatBambooTime(version.CreationDate),
+			PlanResultKey: version.PlanResultKey(),
+			Environments:  environments,
+		})
+	}
+
+	diags = response.State.Set(ctx, &DeploymentReleasesData{
+		DeploymentId: data.DeploymentId,
+		Releases:     releases,
+	})
+	if util.TestDiagnostic(&response.Diagnostics, diags) {
+		return
+	}
+}
+
+// formatBambooTime converts Bamboo epoch milliseconds into RFC 3339 format.
+func formatBambooTime(milliseconds int64) string {
+	if milliseconds == 0 {
+		return ""
+	}
+
+	return time.UnixMilli(milliseconds).UTC().Format(time.RFC3339)
+}
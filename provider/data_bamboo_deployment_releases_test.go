@@ -0,0 +1,54 @@
+package provider
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/yunarta/terraform-atlassian-api-client/bamboo"
+	"github.com/yunarta/terraform-provider-bamboo/provider/test"
+)
+
+func TestDeploymentReleasesDataSource_Environments(t *testing.T) {
+	virtualization := test.NewServiceVirtualization()
+	virtualization.AddDeployment(bamboo.Deployment{ID: 7, Name: "app-deploy", PlanKey: bamboo.Key{Key: "PROJ-APP"}})
+	virtualization.AddEnvironment(7, test.Environment{ID: 10, Name: "Development", Position: 1})
+	virtualization.AddEnvironment(7, test.Environment{ID: 20, Name: "Staging", Position: 2})
+	virtualization.AddEnvironment(7, test.Environment{ID: 30, Name: "Production", Position: 3})
+	virtualization.AddRelease(test.Release{ID: 100, DeploymentId: 7, Name: "release-1", CreationDate: 1700000000000, PlanResultKey: "PROJ-APP-1"})
+	virtualization.AddRelease(test.Release{ID: 101, DeploymentId: 7, Name: "release-2", CreationDate: 1700000100000, PlanResultKey: "PROJ-APP-2"})
+	virtualization.AddRelease(test.Release{ID: 102, DeploymentId: 7, Name: "release-3", CreationDate: 1700000200000, PlanResultKey: "PROJ-APP-3"})
+	virtualization.Deploy(10, 102)
+	virtualization.Deploy(20, 102)
+	virtualization.Deploy(30, 100)
+	// a release of another deployment is not listed
+	virtualization.AddRelease(test.Release{ID: 200, DeploymentId: 8, Name: "other", CreationDate: 1700000300000, PlanResultKey: "PROJ-OTHER-1"})
+	// the releases span several pages
+	virtualization.LimitPageSize(2)
+
+	state, diags := readDataSource(t, NewDeploymentReleasesDataSource(), virtualization, testBambooRss(false), map[string]any{"deployment_id": "7"})
+	if diags.HasError() {
+		t.Fatalf("Read() diagnostics = %v", diags)
+	}
+
+	var data DeploymentReleasesData
+	if diags := state.Get(context.Background(), &data); diags.HasError() {
+		t.Fatalf("Get() diagnostics = %v", diags)
+	}
+
+	want := []DeploymentReleaseData{
+		{Id: "102", Name: "release-3", CreationDate: "2023-11-14T22:16:40Z", PlanResultKey: "PROJ-APP-3", Environments: []string{"Development", "Staging"}},
+		{Id: "101", Name: "release-2", CreationDate: "2023-11-14T22:15:00Z", PlanResultKey: "PROJ-APP-2", Environments: []string{}},
+		{Id: "100", Name: "release-1", CreationDate: "2023-11-14T22:13:20Z", PlanResultKey: "PROJ-APP-1", Environments: []string{"Production"}},
+	}
+	if !reflect.DeepEqual(data.Releases, want) {
+		t.Errorf("Read() releases = %v, want %v", data.Releases, want)
+	}
+}
+
+func TestDeploymentReleasesDataSource_InvalidId(t *testing.T) {
+	_, diags := readDataSource(t, NewDeploymentReleasesDataSource(), test.NewServiceVirtualization(), testBambooRss(false), map[string]any{"deployment_id": "app-deploy"})
+	if !diags.HasError() || diags.Errors()[0].Summary() != errorProvidedDeploymentIdMustBeNumber {
+		t.Errorf("Read() diagnostics = %v, want %q", diags, errorProvidedDeploymentIdMustBeNumber)
+	}
+}
@@ -1,7 +1,12 @@
 package provider
 
 import (
+	"context"
+	"reflect"
 	"testing"
+
+	"github.com/yunarta/terraform-atlassian-api-client/bamboo"
+	"github.com/yunarta/terraform-provider-bamboo/provider/test"
 )
 
 func TestDeploymentDataSource_Read(t *testing.T) {
@@ -31,3 +36,57 @@ func TestDeploymentDataSource_Read(t *testing.T) {
 	//	},
 	//})
 }
+
+func TestDeploymentDataSource_Environments(t *testing.T) {
+	virtualization := test.NewServiceVirtualization()
+	virtualization.AddDeployment(bamboo.Deployment{ID: 7, Name: "app-deploy", PlanKey: bamboo.Key{Key: "PROJ-APP"}, RepositorySpecsManaged: true})
+	// served out of order, the positions come from Bamboo and do not have to be contiguous
+	virtualization.AddEnvironment(7, test.Environment{ID: 30, Name: "Production", Position: 5})
+	virtualization.AddEnvironment(7, test.Environment{ID: 10, Name: "Development", Position: 1})
+	virtualization.AddEnvironment(7, test.Environment{ID: 20, Name: "Staging", Position: 3})
+	virtualization.AddRepository(test.Repository{ID: 1, Name: "app-specs"})
+	virtualization.AddRepository(test.Repository{ID: 2, Name: "app-deploy-specs"})
+	virtualization.GrantAccess("deployment/7", 2)
+	virtualization.GrantAccess("deployment/7", 1)
+
+	state, diags := readDataSource(t, NewDeploymentDataSource(), virtualization, testBambooRss(false), map[string]any{"name": "APP-DEPLOY"})
+	if diags.HasError() {
+		t.Fatalf("Read() diagnostics = %v", diags)
+	}
+
+	var data DeploymentData
+	if diags := state.Get(context.Background(), &data); diags.HasError() {
+		t.Fatalf("Get() diagnostics = %v", diags)
+	}
+
+	if data.Id.ValueString() != "7" || data.Name.ValueString() != "app-deploy" || data.PlanKey.ValueString() != "PROJ-APP" {
+		t.Errorf("Read() deployment = %s %s %s, want 7 app-deploy PROJ-APP", data.Id, data.Name, data.PlanKey)
+	}
+
+	if !data.RepositorySpecsManaged.ValueBool() {
+		t.Errorf("Read() repository_specs_managed = false, want true")
+	}
+
+	if want := []string{"1", "2"}; !reflect.DeepEqual(data.Repositories, want) {
+		t.Errorf("Read() repositories = %v, want %v", data.Repositories, want)
+	}
+
+	want := []DeploymentEnvironmentData{
+		{Id: 10, Name: "Development", Position: 1},
+		{Id: 20, Name: "Staging", Position: 3},
+		{Id: 30, Name: "Production", Position: 5},
+	}
+	if !reflect.DeepEqual(data.Environments, want) {
+		t.Errorf("Read() environments = %v, want %v", data.Environments, want)
+	}
+}
+
+func TestDeploymentDataSource_NotFound(t *testing.T) {
+	virtualization := test.NewServiceVirtualization()
+	virtualization.AddDeployment(bamboo.Deployment{ID: 7, Name: "app-deploy"})
+
+	_, diags := readDataSource(t, NewDeploymentDataSource(), virtualization, testBambooRss(false), map[string]any{"name": "app"})
+	if !diags.HasError() {
+		t.Errorf("Read() diagnostics = %v, want an error for a partial name", diags)
+	}
+}
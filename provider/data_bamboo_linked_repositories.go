@@ -0,0 +1,227 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/yunarta/terraform-atlassian-api-client/bamboo"
+	"github.com/yunarta/terraform-provider-commons/util"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+type LinkedRepositoriesData struct {
+	NameRegex                  types.String                `tfsdk:"name_regex"`
+	Project                    types.String                `tfsdk:"project"`
+	RssEnabled                 types.Bool                  `tfsdk:"rss_enabled"`
+	IncludeProjectRepositories types.Bool                  `tfsdk:"include_project_repositories"`
+	Repositories               []LinkedRepositoryEntryData `tfsdk:"repositories"`
+}
+
+type LinkedRepositoryEntryData struct {
+	Id         string `tfsdk:"id"`
+	Name       string `tfsdk:"name"`
+	Owner      string `tfsdk:"owner"`
+	Project    string `tfsdk:"project"`
+	Slug       string `tfsdk:"slug"`
+	Branch     string `tfsdk:"branch"`
+	RssEnabled bool   `tfsdk:"rss_enabled"`
+}
+
+var (
+	_ datasource.DataSource              = &LinkedRepositoriesDataSource{}
+	_ datasource.DataSourceWithConfigure = &LinkedRepositoriesDataSource{}
+	_ ConfigurableReceiver               = &LinkedRepositoriesDataSource{}
+	_ ExtendedConfigurableReceiver       = &LinkedRepositoriesDataSource{}
+)
+
+func NewLinkedRepositoriesDataSource() datasource.DataSource {
+	return &LinkedRepositoriesDataSource{}
+}
+
+type LinkedRepositoriesDataSource struct {
+	config         BambooProviderConfig
+	client         *bamboo.Client
+	extendedClient *ExtendedClient
+}
+
+func (receiver *LinkedRepositoriesDataSource) setConfig(config BambooProviderConfig, client *bamboo.Client) {
+	receiver.config = config
+	receiver.client = client
+}
+
+func (receiver *LinkedRepositoriesDataSource) setExtendedClient(extendedClient *ExtendedClient) {
+	receiver.extendedClient = extendedClient
+}
+
+func (receiver *LinkedRepositoriesDataSource) Configure(ctx context.Context, request datasource.ConfigureRequest, response *datasource.ConfigureResponse) {
+	ConfigureDataSource(receiver, ctx, request, response)
+}
+
+func (receiver *LinkedRepositoriesDataSource) Metadata(ctx context.Context, request datasource.MetadataRequest, response *datasource.MetadataResponse) {
+	response.TypeName = request.ProviderTypeName + "_linked_repositories"
+}
+
+func (receiver *LinkedRepositoriesDataSource) Schema(ctx context.Context, request datasource.SchemaRequest, response *datasource.SchemaResponse) {
+	response.Schema = schema.Schema{
+		MarkdownDescription: "This data source lists the linked repositories, optionally filtered, for example to bring existing repositories under management with `for_each`.",
+		Attributes: map[string]schema.Attribute{
+			"name_regex": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Only list repositories whose name matches this regular expression.",
+			},
+			"project": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Only list repositories in this Bitbucket project, compared case-insensitively.",
+			},
+			"rss_enabled": schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: "Only list repositories with this Bamboo Specs flag.",
+			},
+			"include_project_repositories": schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: "Also list the linked repositories owned by every Bamboo project. Default value is `false`.",
+			},
+			"repositories": schema.ListNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "List of matching repositories, global repositories first.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Numeric id of the linked repository.",
+						},
+						"name": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Name of the linked repository.",
+						},
+						"owner": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Bamboo project key owning the repository, empty for a global repository.",
+						},
+						"project": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Bitbucket project key, empty when the repository is not a Bitbucket Server repository.",
+						},
+						"slug": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Bitbucket repository slug, empty when the repository is not a Bitbucket Server repository.",
+						},
+						"branch": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Repository branch.",
+						},
+						"rss_enabled": schema.BoolAttribute{
+							Computed:            true,
+							MarkdownDescription: "Bamboo Specs flag of the repository.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (receiver *LinkedRepositoriesDataSource) Read(ctx context.Context, request datasource.ReadRequest, response *datasource.ReadResponse) {
+	var (
+		diags diag.Diagnostics
+
+		data LinkedRepositoriesData
+	)
+
+	diags = request.Config.Get(ctx, &data)
+	if util.TestDiagnostic(&response.Diagnostics, diags) {
+		return
+	}
+
+	var nameRegex *regexp.Regexp
+	if !data.NameRegex.IsNull() {
+		var err error
+		nameRegex, err = regexp.Compile(data.NameRegex.ValueString())
+		if err != nil {
+			response.Diagnostics.AddAttributeError(path.Root("name_regex"), "Invalid regular expression", err.Error())
+			return
+		}
+	}
+
+	type ownedRepository struct {
+		owner      string
+		repository bamboo.Repository
+	}
+
+	var candidates []ownedRepository
+	repositories, err := receiver.extendedClient.RepositoryService().Search("")
+	if util.TestError(&response.Diagnostics, err, errorFailedToReadRepository) {
+		return
+	}
+
+	for _, repository := range repositories {
+		candidates = append(candidates, ownedRepository{repository: repository})
+	}
+
+	if data.IncludeProjectRepositories.ValueBool() {
+		projects, err := receiver.extendedClient.ProjectService().ReadAll()
+		if util.TestError(&response.Diagnostics, err, errorFailedToReadProjects) {
+			return
+		}
+
+		for _, project := range projects {
+			repositories, err = receiver.extendedClient.RepositoryService().SearchProject(project.Key, "")
+			if util.TestError(&response.Diagnostics, err, errorFailedToReadProjectRepositories) {
+				return
+			}
+
+			for _, repository := range repositories {
+				candidates = append(candidates, ownedRepository{owner: project.Key, repository: repository})
+			}
+		}
+	}
+
+	var entries = make([]LinkedRepositoryEntryData, 0)
+	for _, candidate := range candidates {
+		repository := candidate.repository
+		if nameRegex != nil && !nameRegex.MatchString(repository.Name) {
+			continue
+		}
+
+		if !data.RssEnabled.IsNull() && data.RssEnabled.ValueBool() != repository.RssEnabled {
+			continue
+		}
+
+		// the repository details are only read for the repositories passing the cheaper filters
+		detail, err := receiver.extendedClient.RepositoryService().ReadDetail(repository.ID)
+		if util.TestError(&response.Diagnostics, err, errorFailedToReadRepository) {
+			return
+		}
+
+		if detail == nil {
+			response.Diagnostics.AddError(errorFailedToReadRepository, fmt.Sprintf("Linked repository %d disappeared while listing", repository.ID))
+			return
+		}
+
+		if !data.Project.IsNull() && !strings.EqualFold(data.Project.ValueString(), detail.ProjectKey) {
+			continue
+		}
+
+		entries = append(entries, LinkedRepositoryEntryData{
+			Id:         strconv.Itoa(repository.ID),
+			Name:       repository.Name,
+			Owner:      candidate.owner,
+			Project:    detail.ProjectKey,
+			Slug:       detail.Slug,
+			Branch:     detail.Branch,
+			RssEnabled: repository.RssEnabled,
+		})
+	}
+
+	data.Repositories = entries
+	diags = response.State.Set(ctx, &data)
+	if util.TestDiagnostic(&response.Diagnostics, diags) {
+		return
+	}
+}
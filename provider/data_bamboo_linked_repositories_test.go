@@ -0,0 +1,75 @@
+package provider
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/yunarta/terraform-provider-bamboo/provider/test"
+)
+
+func TestLinkedRepositoriesDataSource_Read(t *testing.T) {
+	virtualization := test.NewServiceVirtualization()
+	virtualization.AddProject("PROJ", "Project")
+	virtualization.AddRepository(test.Repository{
+		ID: 1, Name: "app", RssEnabled: true,
+		Spec: map[string]any{"projectKey": "SCM", "repositorySlug": "app", "branch": "main"},
+	})
+	virtualization.AddRepository(test.Repository{
+		ID: 2, Name: "lib",
+		Spec: map[string]any{"projectKey": "SCM", "repositorySlug": "lib", "branch": "develop"},
+	})
+	virtualization.AddRepository(test.Repository{
+		ID: 3, Name: "tools",
+		Spec: map[string]any{"projectKey": "OPS", "repositorySlug": "tools", "branch": "main"},
+	})
+	virtualization.AddRepository(test.Repository{
+		ID: 4, Name: "project-app", RssEnabled: true,
+		Spec: map[string]any{"projectKey": "SCM", "repositorySlug": "project-app", "branch": "main", "project": map[string]any{"key": "PROJ"}},
+	})
+
+	app := LinkedRepositoryEntryData{Id: "1", Name: "app", Project: "SCM", Slug: "app", Branch: "main", RssEnabled: true}
+	lib := LinkedRepositoryEntryData{Id: "2", Name: "lib", Project: "SCM", Slug: "lib", Branch: "develop"}
+	tools := LinkedRepositoryEntryData{Id: "3", Name: "tools", Project: "OPS", Slug: "tools", Branch: "main"}
+	projectApp := LinkedRepositoryEntryData{Id: "4", Name: "project-app", Owner: "PROJ", Project: "SCM", Slug: "project-app", Branch: "main", RssEnabled: true}
+
+	tests := []struct {
+		name       string
+		attributes map[string]any
+		want       []LinkedRepositoryEntryData
+	}{
+		{name: "global", attributes: map[string]any{"include_project_repositories": false}, want: []LinkedRepositoryEntryData{app, lib, tools}},
+		{name: "with project repositories", attributes: map[string]any{"include_project_repositories": true}, want: []LinkedRepositoryEntryData{app, lib, tools, projectApp}},
+		{name: "name regex", attributes: map[string]any{"name_regex": "^(app|lib)$"}, want: []LinkedRepositoryEntryData{app, lib}},
+		{name: "bitbucket project", attributes: map[string]any{"project": "ops"}, want: []LinkedRepositoryEntryData{tools}},
+		{name: "rss enabled", attributes: map[string]any{"rss_enabled": true, "include_project_repositories": true}, want: []LinkedRepositoryEntryData{app, projectApp}},
+		{name: "no match", attributes: map[string]any{"name_regex": "^none$"}, want: []LinkedRepositoryEntryData{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			state, diags := readDataSource(t, NewLinkedRepositoriesDataSource(), virtualization, testBambooRss(false), tt.attributes)
+			if diags.HasError() {
+				t.Fatalf("Read() diagnostics = %v", diags)
+			}
+
+			var data LinkedRepositoriesData
+			if diags = state.Get(context.Background(), &data); diags.HasError() {
+				t.Fatalf("Get() diagnostics = %v", diags)
+			}
+
+			if !reflect.DeepEqual(data.Repositories, tt.want) {
+				t.Errorf("Read() = %+v, want %+v", data.Repositories, tt.want)
+			}
+		})
+	}
+}
+
+func TestLinkedRepositoriesDataSource_InvalidRegex(t *testing.T) {
+	virtualization := test.NewServiceVirtualization()
+
+	_, diags := readDataSource(t, NewLinkedRepositoriesDataSource(), virtualization, testBambooRss(false), map[string]any{"name_regex": "("})
+	if !diags.HasError() {
+		t.Fatalf("Read() expected an error for an invalid regular expression")
+	}
+}
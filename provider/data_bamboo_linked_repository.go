@@ -2,7 +2,6 @@ package provider
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
@@ -10,20 +9,27 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/yunarta/terraform-atlassian-api-client/bamboo"
 	"github.com/yunarta/terraform-provider-commons/util"
-	"os"
-	"path/filepath"
 	"strconv"
 )
 
 type LinkedRepositoryData struct {
-	Id   types.String `tfsdk:"id"`
-	Name types.String `tfsdk:"name"`
+	Id         types.String `tfsdk:"id"`
+	Name       types.String `tfsdk:"name"`
+	Owner      types.String `tfsdk:"owner"`
+	Project    types.String `tfsdk:"project"`
+	Slug       types.String `tfsdk:"slug"`
+	Branch     types.String `tfsdk:"branch"`
+	RssEnabled types.Bool   `tfsdk:"rss_enabled"`
+	Server     types.String `tfsdk:"server"`
+	Users      types.Map    `tfsdk:"users"`
+	Groups     types.Map    `tfsdk:"groups"`
 }
 
 var (
 	_ datasource.DataSource              = &LinkedRepositoryDataSource{}
 	_ datasource.DataSourceWithConfigure = &LinkedRepositoryDataSource{}
 	_ ConfigurableReceiver               = &LinkedRepositoryDataSource{}
+	_ ExtendedConfigurableReceiver       = &LinkedRepositoryDataSource{}
 )
 
 func NewLinkedRepositoryDataSource() datasource.DataSource {
@@ -31,8 +37,9 @@ func NewLinkedRepositoryDataSource() datasource.DataSource {
 }
 
 type LinkedRepositoryDataSource struct {
-	config BambooProviderConfig
-	client *bamboo.Client
+	config         BambooProviderConfig
+	client         *bamboo.Client
+	extendedClient *ExtendedClient
 }
 
 func (receiver *LinkedRepositoryDataSource) setConfig(config BambooProviderConfig, client *bamboo.Client) {
@@ -40,6 +47,10 @@ func (receiver *LinkedRepositoryDataSource) setConfig(config BambooProviderConfi
 	receiver.client = client
 }
 
+func (receiver *LinkedRepositoryDataSource) setExtendedClient(extendedClient *ExtendedClient) {
+	receiver.extendedClient = extendedClient
+}
+
 func (receiver *LinkedRepositoryDataSource) Configure(ctx context.Context, request datasource.ConfigureRequest, response *datasource.ConfigureResponse) {
 	ConfigureDataSource(receiver, ctx, request, response)
 }
@@ -50,7 +61,7 @@ func (receiver *LinkedRepositoryDataSource) Metadata(ctx context.Context, reques
 
 func (receiver *LinkedRepositoryDataSource) Schema(ctx context.Context, request datasource.SchemaRequest, response *datasource.SchemaResponse) {
 	response.Schema = schema.Schema{
-		MarkdownDescription: "This data source used define a lookup of linked repository by name.",
+		MarkdownDescription: "This data source used define a lookup of linked repository by name, with its source repository and permissions.",
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
 				Computed:            true,
@@ -60,6 +71,45 @@ func (receiver *LinkedRepositoryDataSource) Schema(ctx context.Context, request
 				Required:            true,
 				MarkdownDescription: "Linked repository name.",
 			},
+			"owner": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Bamboo project key owning the repository, empty for a global repository. Set it to look up a project linked repository, otherwise only global linked repositories are found.",
+			},
+			"project": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Bitbucket project key.",
+			},
+			"slug": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Bitbucket repository slug.",
+			},
+			"branch": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Repository branch.",
+			},
+			"rss_enabled": schema.BoolAttribute{
+				Computed:            true,
+				MarkdownDescription: "Bamboo Specs flag of the repository.",
+			},
+			"server": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Name of the Bitbucket server application link.",
+			},
+			"users": schema.MapAttribute{
+				Computed: true,
+				ElementType: types.ListType{
+					ElemType: types.StringType,
+				},
+				MarkdownDescription: "A map with the permission as the key and list of users as the value.",
+			},
+			"groups": schema.MapAttribute{
+				Computed: true,
+				ElementType: types.ListType{
+					ElemType: types.StringType,
+				},
+				MarkdownDescription: "A map with the permission as the key and list of groups as the value.",
+			},
 		},
 	}
 }
@@ -67,7 +117,6 @@ func (receiver *LinkedRepositoryDataSource) Schema(ctx context.Context, request
 func (receiver *LinkedRepositoryDataSource) Read(ctx context.Context, request datasource.ReadRequest, response *datasource.ReadResponse) {
 	var (
 		diags diag.Diagnostics
-		err   error
 
 		data LinkedRepositoryData
 	)
@@ -77,35 +126,65 @@ func (receiver *LinkedRepositoryDataSource) Read(ctx context.Context, request da
 		return
 	}
 
-	cacheDir := filepath.Join(".cache", "bamboo_linked_repository")
-	_ = os.MkdirAll(cacheDir, 0755)
-	filename := filepath.Join(cacheDir, fmt.Sprintf("%s.json", data.Name.ValueString()))
-
+	// a project linked repository is only listed in its project
 	var repository *bamboo.Repository
-	if _, err := os.Stat(filename); err == nil {
-		fileBytes, err := os.ReadFile(filename)
-		if err != nil {
-			err = json.Unmarshal(fileBytes, repository)
-		}
+	var err error
+	ownerKey := data.Owner.ValueString()
+	if ownerKey != "" {
+		repository, err = receiver.client.RepositoryService().ReadProject(ownerKey, data.Name.ValueString())
+	} else {
+		repository, err = receiver.client.RepositoryService().Read(data.Name.ValueString())
+	}
+	if util.TestError(&response.Diagnostics, err, "Failed to retrieve linked repository") {
+		return
 	}
 
 	if repository == nil {
-		repository, err = receiver.client.RepositoryService().Read(data.Name.ValueString())
-		if util.TestError(&response.Diagnostics, err, "Failed to retrieve linked repository") {
-			return
+		message := fmt.Sprintf("Unable to find linked repository with name '%s'", data.Name.ValueString())
+		if ownerKey != "" {
+			message = fmt.Sprintf("%s in project %s", message, ownerKey)
 		}
-		marshal, _ := json.Marshal(repository)
-		_ = os.WriteFile(filename, marshal, 0644)
+
+		response.Diagnostics.AddError("Missing linked repository", message)
+		return
 	}
 
-	if repository == nil {
-		response.Diagnostics.AddError("Missing linked repository", fmt.Sprintf("Unable to find linked repository with name '%s'", data.Name.ValueString()))
+	detail, err := receiver.extendedClient.RepositoryService().ReadDetail(repository.ID)
+	if util.TestError(&response.Diagnostics, err, errorFailedToReadRepository) {
+		return
+	}
+
+	if detail == nil {
+		response.Diagnostics.AddError("Missing linked repository", fmt.Sprintf("Unable to find linked repository with id %d", repository.ID))
 		return
 	}
 
+	assignedPermissions, err := receiver.client.RepositoryService().ReadPermissions(repository.ID)
+	if util.TestError(&response.Diagnostics, err, errorFailedToReadRepositoryPermissions) {
+		return
+	}
+
+	users, groups, diags := CreateAttestation(ctx, assignedPermissions, &response.Diagnostics)
+	if util.TestDiagnostic(&response.Diagnostics, diags) {
+		return
+	}
+
+	var owner string
+	if detail.Project != nil {
+		owner = detail.Project.Key
+	}
+
 	diags = response.State.Set(ctx, &LinkedRepositoryData{
-		Id:   types.StringValue(strconv.Itoa(repository.ID)),
-		Name: types.StringValue(repository.Name),
+		Id:         types.StringValue(strconv.Itoa(repository.ID)),
+		Name:       types.StringValue(repository.Name),
+		Owner:      types.StringValue(owner),
+		Project:    types.StringValue(detail.ProjectKey),
+		Slug:       types.StringValue(detail.Slug),
+		Branch:     types.StringValue(detail.Branch),
+		RssEnabled: types.BoolValue(repository.RssEnabled),
+		Server:     types.StringValue(detail.Server.Name),
+		Users:      users,
+		Groups:     groups,
 	})
 	if util.TestDiagnostic(&response.Diagnostics, diags) {
 		return
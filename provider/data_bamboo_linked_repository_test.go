@@ -0,0 +1,132 @@
+package provider
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/yunarta/terraform-provider-bamboo/provider/test"
+)
+
+func TestLinkedRepositoryDataSource_Read(t *testing.T) {
+	virtualization := test.NewServiceVirtualization()
+	virtualization.AddRepository(test.Repository{
+		ID: 1, Name: "app", RssEnabled: true,
+		Spec: map[string]any{
+			"projectKey":     "SCM",
+			"repositorySlug": "app",
+			"branch":         "main",
+			"server":         map[string]any{"id": "server-id", "name": "bitbucket"},
+		},
+	})
+
+	permissions := virtualization.Permissions("repository/1")
+	permissions.Users["alice"] = []string{"READ", "ADMINISTRATION"}
+	permissions.Users["bob"] = []string{"READ"}
+	permissions.Groups["developers"] = []string{"READ"}
+
+	state, diags := readDataSource(t, NewLinkedRepositoryDataSource(), virtualization, testBambooRss(false), map[string]any{"name": "app"})
+	if diags.HasError() {
+		t.Fatalf("Read() diagnostics = %v", diags)
+	}
+
+	var data LinkedRepositoryData
+	if diags = state.Get(context.Background(), &data); diags.HasError() {
+		t.Fatalf("Get() diagnostics = %v", diags)
+	}
+
+	got := LinkedRepositoryData{
+		Id:         data.Id,
+		Name:       data.Name,
+		Owner:      data.Owner,
+		Project:    data.Project,
+		Slug:       data.Slug,
+		Branch:     data.Branch,
+		RssEnabled: data.RssEnabled,
+		Server:     data.Server,
+	}
+	want := LinkedRepositoryData{
+		Id:         types.StringValue("1"),
+		Name:       types.StringValue("app"),
+		Owner:      types.StringValue(""),
+		Project:    types.StringValue("SCM"),
+		Slug:       types.StringValue("app"),
+		Branch:     types.StringValue("main"),
+		RssEnabled: types.BoolValue(true),
+		Server:     types.StringValue("bitbucket"),
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Read() = %+v, want %+v", got, want)
+	}
+
+	var users, groups map[string][]string
+	data.Users.ElementsAs(context.Background(), &users, false)
+	data.Groups.ElementsAs(context.Background(), &groups, false)
+
+	if wantUsers := map[string][]string{"READ": {"alice", "bob"}, "ADMINISTRATION": {"alice"}}; !reflect.DeepEqual(users, wantUsers) {
+		t.Errorf("Read() users = %v, want %v", users, wantUsers)
+	}
+
+	if wantGroups := map[string][]string{"READ": {"developers"}}; !reflect.DeepEqual(groups, wantGroups) {
+		t.Errorf("Read() groups = %v, want %v", groups, wantGroups)
+	}
+}
+
+func TestLinkedRepositoryDataSource_Missing(t *testing.T) {
+	virtualization := test.NewServiceVirtualization()
+
+	_, diags := readDataSource(t, NewLinkedRepositoryDataSource(), virtualization, testBambooRss(false), map[string]any{"name": "missing"})
+	if !diags.HasError() {
+		t.Fatalf("Read() expected an error for a missing repository")
+	}
+}
+
+func TestLinkedRepositoryDataSource_ProjectRepository(t *testing.T) {
+	virtualization := test.NewServiceVirtualization()
+	virtualization.AddRepository(test.Repository{
+		ID: 4, Name: "app",
+		Spec: map[string]any{
+			"project":        map[string]any{"key": "BAM"},
+			"projectKey":     "SCM",
+			"repositorySlug": "app",
+			"branch":         "main",
+		},
+	})
+
+	tests := []struct {
+		name    string
+		config  map[string]any
+		wantErr bool
+	}{
+		// project linked repositories are not listed with the global linked repositories
+		{name: "global lookup", config: map[string]any{"name": "app"}, wantErr: true},
+		{name: "project lookup", config: map[string]any{"name": "app", "owner": "BAM"}},
+		{name: "other project", config: map[string]any{"name": "app", "owner": "OPS"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			state, diags := readDataSource(t, NewLinkedRepositoryDataSource(), virtualization, testBambooRss(false), tt.config)
+			if tt.wantErr {
+				if !diags.HasError() || diags.Errors()[0].Summary() != "Missing linked repository" {
+					t.Fatalf("Read() diagnostics = %v, want a missing linked repository", diags)
+				}
+				return
+			}
+
+			if diags.HasError() {
+				t.Fatalf("Read() diagnostics = %v", diags)
+			}
+
+			var data LinkedRepositoryData
+			if diags = state.Get(context.Background(), &data); diags.HasError() {
+				t.Fatalf("Get() diagnostics = %v", diags)
+			}
+
+			if data.Id.ValueString() != "4" || data.Owner.ValueString() != "BAM" || data.Project.ValueString() != "SCM" {
+				t.Errorf("Read() id/owner/project = %s/%s/%s, want 4/BAM/SCM", data.Id, data.Owner, data.Project)
+			}
+		})
+	}
+}
@@ -0,0 +1,317 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/yunarta/golang-quality-of-life-pack/collections"
+	"github.com/yunarta/terraform-atlassian-api-client/bamboo"
+	"github.com/yunarta/terraform-provider-commons/util"
+	"sort"
+	"strconv"
+)
+
+type LinkedRepositoryUsageData struct {
+	Id                     types.String              `tfsdk:"id"`
+	Name                   types.String              `tfsdk:"name"`
+	Plans                  []PlanReferenceData       `tfsdk:"plans"`
+	Deployments            []DeploymentReferenceData `tfsdk:"deployments"`
+	Projects               []string                  `tfsdk:"projects"`
+	RssProjects            []string                  `tfsdk:"rss_projects"`
+	RssDeployments         []DeploymentReferenceData `tfsdk:"rss_deployments"`
+	AccessibleRepositories []RepositoryReferenceData `tfsdk:"accessible_repositories"`
+}
+
+type PlanReferenceData struct {
+	Key  string `tfsdk:"key"`
+	Name string `tfsdk:"name"`
+}
+
+type DeploymentReferenceData struct {
+	Id   string `tfsdk:"id"`
+	Name string `tfsdk:"name"`
+}
+
+type RepositoryReferenceData struct {
+	Id   string `tfsdk:"id"`
+	Name string `tfsdk:"name"`
+}
+
+var (
+	_ datasource.DataSource              = &LinkedRepositoryUsageDataSource{}
+	_ datasource.DataSourceWithConfigure = &LinkedRepositoryUsageDataSource{}
+	_ ConfigurableReceiver               = &LinkedRepositoryUsageDataSource{}
+	_ ExtendedConfigurableReceiver       = &LinkedRepositoryUsageDataSource{}
+)
+
+func NewLinkedRepositoryUsageDataSource() datasource.DataSource {
+	return &LinkedRepositoryUsageDataSource{}
+}
+
+type LinkedRepositoryUsageDataSource struct {
+	config         BambooProviderConfig
+	client         *bamboo.Client
+	extendedClient *ExtendedClient
+}
+
+func (receiver *LinkedRepositoryUsageDataSource) setConfig(config BambooProviderConfig, client *bamboo.Client) {
+	receiver.config = config
+	receiver.client = client
+}
+
+func (receiver *LinkedRepositoryUsageDataSource) setExtendedClient(extendedClient *ExtendedClient) {
+	receiver.extendedClient = extendedClient
+}
+
+func (receiver *LinkedRepositoryUsageDataSource) Configure(ctx context.Context, request datasource.ConfigureRequest, response *datasource.ConfigureResponse) {
+	ConfigureDataSource(receiver, ctx, request, response)
+}
+
+func (receiver *LinkedRepositoryUsageDataSource) Metadata(ctx context.Context, request datasource.MetadataRequest, response *datasource.MetadataResponse) {
+	response.TypeName = request.ProviderTypeName + "_linked_repository_usage"
+}
+
+func (receiver *LinkedRepositoryUsageDataSource) Schema(ctx context.Context, request datasource.SchemaRequest, response *datasource.SchemaResponse) {
+	referenceSchema := func(description string, idName string, idDescription string) schema.ListNestedAttribute {
+		return schema.ListNestedAttribute{
+			Computed:            true,
+			MarkdownDescription: description,
+			NestedObject: schema.NestedAttributeObject{
+				Attributes: map[string]schema.Attribute{
+					idName: schema.StringAttribute{
+						Computed:            true,
+						MarkdownDescription: idDescription,
+					},
+					"name": schema.StringAttribute{
+						Computed:            true,
+						MarkdownDescription: "Name.",
+					},
+				},
+			},
+		}
+	}
+
+	response.Schema = schema.Schema{
+		MarkdownDescription: `This data source lists where a linked repository is used, for impact analysis before changing its branch or permissions.
+
+The Bamboo Specs access is resolved by reading the access list of every project, deployment and linked repository, one request each, which may take a while on large instances.`,
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Optional: true,
+				Computed: true,
+				Validators: []validator.String{
+					stringvalidator.ExactlyOneOf(path.MatchRoot("id"), path.MatchRoot("name")),
+				},
+				MarkdownDescription: "Numeric id of the linked repository, either `id` or `name` must be set.",
+			},
+			"name": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Name of the linked repository, either `id` or `name` must be set.",
+			},
+			"plans":       referenceSchema("Plans using the repository.", "key", "Plan key."),
+			"deployments": referenceSchema("Deployment projects using the repository.", "id", "Numeric id of the deployment."),
+			"projects": schema.ListAttribute{
+				Computed:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "Keys of the projects owning the plans using the repository.",
+			},
+			"rss_projects": schema.ListAttribute{
+				Computed:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "Keys of the projects the repository Bamboo Specs are allowed to manage.",
+			},
+			"rss_deployments":         referenceSchema("Deployments the repository Bamboo Specs are allowed to manage.", "id", "Numeric id of the deployment."),
+			"accessible_repositories": referenceSchema("Linked repositories the repository Bamboo Specs are allowed to use.", "id", "Numeric id of the linked repository."),
+		},
+	}
+}
+
+func (receiver *LinkedRepositoryUsageDataSource) Read(ctx context.Context, request datasource.ReadRequest, response *datasource.ReadResponse) {
+	var (
+		diags diag.Diagnostics
+
+		data LinkedRepositoryUsageData
+	)
+
+	diags = request.Config.Get(ctx, &data)
+	if util.TestDiagnostic(&response.Diagnostics, diags) {
+		return
+	}
+
+	var repositoryId int
+	var repositoryName string
+	if !data.Id.IsNull() {
+		id, err := strconv.Atoi(data.Id.ValueString())
+		if util.TestError(&response.Diagnostics, err, errorProvidedRepositoryMustBeNumber) {
+			return
+		}
+
+		detail, err := receiver.extendedClient.RepositoryService().ReadDetail(id)
+		if util.TestError(&response.Diagnostics, err, errorFailedToReadRepository) {
+			return
+		}
+
+		if detail == nil {
+			response.Diagnostics.AddError("Missing linked repository", fmt.Sprintf("Unable to find linked repository with id %d", id))
+			return
+		}
+
+		repositoryId, repositoryName = id, detail.Name
+	} else {
+		repository, err := receiver.client.RepositoryService().Read(data.Name.ValueString())
+		if util.TestError(&response.Diagnostics, err, errorFailedToReadRepository) {
+			return
+		}
+
+		if repository == nil {
+			response.Diagnostics.AddError("Missing linked repository", fmt.Sprintf("Unable to find linked repository with name '%s'", data.Name.ValueString()))
+			return
+		}
+
+		repositoryId, repositoryName = repository.ID, repository.Name
+	}
+
+	usage, err := receiver.extendedClient.RepositoryService().ReadUsage(repositoryId)
+	if util.TestError(&response.Diagnostics, err, errorFailedToReadRepositoryUsage) {
+		return
+	}
+
+	var plans = make([]PlanReferenceData, 0)
+	var projects = make([]string, 0)
+	for _, plan := range usage.Plans {
+		plans = append(plans, PlanReferenceData{Key: plan.Key, Name: plan.Name})
+
+		projects = collections.Unique(append(projects, plan.ProjectKey))
+	}
+	sort.Strings(projects)
+
+	var deployments = make([]DeploymentReferenceData, 0)
+	for _, deployment := range usage.Deployments {
+		deployments = append(deployments, DeploymentReferenceData{Id: strconv.Itoa(deployment.ID), Name: deployment.Name})
+	}
+
+	rssProjects, diags := receiver.readRssProjects(repositoryId)
+	if util.TestDiagnostic(&response.Diagnostics, diags) {
+		return
+	}
+
+	rssDeployments, diags := receiver.readRssDeployments(repositoryId)
+	if util.TestDiagnostic(&response.Diagnostics, diags) {
+		return
+	}
+
+	accessibleRepositories, diags := receiver.readAccessibleRepositories(repositoryId)
+	if util.TestDiagnostic(&response.Diagnostics, diags) {
+		return
+	}
+
+	diags = response.State.Set(ctx, &LinkedRepositoryUsageData{
+		Id:                     types.StringValue(strconv.Itoa(repositoryId)),
+		Name:                   types.StringValue(repositoryName),
+		Plans:                  plans,
+		Deployments:            deployments,
+		Projects:               projects,
+		RssProjects:            rssProjects,
+		RssDeployments:         rssDeployments,
+		AccessibleRepositories: accessibleRepositories,
+	})
+	if util.TestDiagnostic(&response.Diagnostics, diags) {
+		return
+	}
+}
+
+func (receiver *LinkedRepositoryUsageDataSource) readRssProjects(repositoryId int) ([]string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	projects, err := receiver.extendedClient.ProjectService().ReadAll()
+	if err != nil {
+		diags.AddError(errorFailedToReadProjects, err.Error())
+		return nil, diags
+	}
+
+	var rssProjects = make([]string, 0)
+	for _, project := range projects {
+		repositories, err := receiver.client.ProjectService().GetSpecRepositories(project.Key)
+		if err != nil {
+			diags.AddError(errorFailedToReadProjectRepositories, fmt.Sprintf("%s: %s", project.Key, err.Error()))
+			return nil, diags
+		}
+
+		if containsRepository(repositories, repositoryId) {
+			rssProjects = append(rssProjects, project.Key)
+		}
+	}
+
+	return rssProjects, diags
+}
+
+func (receiver *LinkedRepositoryUsageDataSource) readRssDeployments(repositoryId int) ([]DeploymentReferenceData, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	deployments, err := receiver.extendedClient.DeploymentService().ReadAll()
+	if err != nil {
+		diags.AddError(errorFailedToReadDeployment, err.Error())
+		return nil, diags
+	}
+
+	var rssDeployments = make([]DeploymentReferenceData, 0)
+	for _, deployment := range deployments {
+		repositories, err := receiver.client.DeploymentService().GetSpecRepositories(deployment.ID)
+		if err != nil {
+			diags.AddError(errorFailedToReadDeploymentRepositories, fmt.Sprintf("%d: %s", deployment.ID, err.Error()))
+			return nil, diags
+		}
+
+		if containsRepository(repositories, repositoryId) {
+			rssDeployments = append(rssDeployments, DeploymentReferenceData{Id: strconv.Itoa(deployment.ID), Name: deployment.Name})
+		}
+	}
+
+	return rssDeployments, diags
+}
+
+func (receiver *LinkedRepositoryUsageDataSource) readAccessibleRepositories(repositoryId int) ([]RepositoryReferenceData, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	repositories, err := receiver.extendedClient.RepositoryService().Search("")
+	if err != nil {
+		diags.AddError(errorFailedToReadRepository, err.Error())
+		return nil, diags
+	}
+
+	var accessible = make([]RepositoryReferenceData, 0)
+	for _, repository := range repositories {
+		if repository.ID == repositoryId {
+			continue
+		}
+
+		accessors, err := receiver.client.RepositoryService().ReadAccessor(repository.ID)
+		if err != nil {
+			diags.AddError(errorFailedToReadRepositoryAccessor, fmt.Sprintf("%d: %s", repository.ID, err.Error()))
+			return nil, diags
+		}
+
+		if containsRepository(accessors, repositoryId) {
+			accessible = append(accessible, RepositoryReferenceData{Id: strconv.Itoa(repository.ID), Name: repository.Name})
+		}
+	}
+
+	return accessible, diags
+}
+
+func containsRepository(repositories []bamboo.Repository, repositoryId int) bool {
+	for _, repository := range repositories {
+		if repository.ID == repositoryId {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,62 @@
+package provider
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/yunarta/terraform-atlassian-api-client/bamboo"
+	"github.com/yunarta/terraform-provider-bamboo/provider/test"
+)
+
+func TestLinkedRepositoryUsageDataSource_Read(t *testing.T) {
+	virtualization := test.NewServiceVirtualization()
+	virtualization.AddRepository(test.Repository{
+		ID:          1,
+		Name:        "app",
+		Plans:       map[string]string{"PROJ-APP": "Application", "PROJ-LIB": "Library", "OPS-APP": "Operations"},
+		Deployments: map[int]string{7: "Application Deployment"},
+	})
+	virtualization.AddRepository(test.Repository{ID: 2, Name: "shared-specs"})
+	virtualization.AddRepository(test.Repository{ID: 3, Name: "unrelated"})
+	virtualization.AddProject("PROJ", "Project")
+	virtualization.AddProject("OPS", "Operations")
+	virtualization.AddDeployment(bamboo.Deployment{ID: 7, Name: "Application Deployment"})
+	virtualization.AddDeployment(bamboo.Deployment{ID: 8, Name: "Other Deployment"})
+
+	virtualization.GrantAccess("project/PROJ", 1)
+	virtualization.GrantAccess("deployment/7", 1)
+	virtualization.GrantAccess("deployment/8", 3)
+	virtualization.GrantAccess("repository/2", 1)
+
+	for _, lookup := range []map[string]any{{"name": "app"}, {"id": "1"}} {
+		state, diags := readDataSource(t, NewLinkedRepositoryUsageDataSource(), virtualization, testBambooRss(false), lookup)
+		if diags.HasError() {
+			t.Fatalf("Read() diagnostics = %v", diags)
+		}
+
+		var data LinkedRepositoryUsageData
+		if diags = state.Get(context.Background(), &data); diags.HasError() {
+			t.Fatalf("Get() diagnostics = %v", diags)
+		}
+
+		want := LinkedRepositoryUsageData{
+			Id:   types.StringValue("1"),
+			Name: types.StringValue("app"),
+			Plans: []PlanReferenceData{
+				{Key: "OPS-APP", Name: "Operations"},
+				{Key: "PROJ-APP", Name: "Application"},
+				{Key: "PROJ-LIB", Name: "Library"},
+			},
+			Deployments:            []DeploymentReferenceData{{Id: "7", Name: "Application Deployment"}},
+			Projects:               []string{"OPS", "PROJ"},
+			RssProjects:            []string{"PROJ"},
+			RssDeployments:         []DeploymentReferenceData{{Id: "7", Name: "Application Deployment"}},
+			AccessibleRepositories: []RepositoryReferenceData{{Id: "2", Name: "shared-specs"}},
+		}
+		if !reflect.DeepEqual(data, want) {
+			t.Errorf("Read(%v) = %+v, want %+v", lookup, data, want)
+		}
+	}
+}
@@ -0,0 +1,443 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/yunarta/terraform-atlassian-api-client/bamboo"
+	"github.com/yunarta/terraform-provider-commons/util"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const accessNodeRepository = "repository"
+const accessNodeProject = "project"
+const accessNodeDeployment = "deployment"
+
+type RepositoryAccessGraphData struct {
+	IncludeProjects    types.Bool                `tfsdk:"include_projects"`
+	IncludeDeployments types.Bool                `tfsdk:"include_deployments"`
+	Nodes              []AccessNodeData          `tfsdk:"nodes"`
+	Edges              []AccessEdgeData          `tfsdk:"edges"`
+	Orphans            []RepositoryReferenceData `tfsdk:"orphans"`
+	Cycles             [][]string                `tfsdk:"cycles"`
+	Dot                types.String              `tfsdk:"dot"`
+}
+
+type AccessNodeData struct {
+	Id   string `tfsdk:"id"`
+	Kind string `tfsdk:"kind"`
+	Key  string `tfsdk:"key"`
+	Name string `tfsdk:"name"`
+}
+
+type AccessEdgeData struct {
+	From string `tfsdk:"from"`
+	To   string `tfsdk:"to"`
+}
+
+var (
+	_ datasource.DataSource              = &RepositoryAccessGraphDataSource{}
+	_ datasource.DataSourceWithConfigure = &RepositoryAccessGraphDataSource{}
+	_ ConfigurableReceiver               = &RepositoryAccessGraphDataSource{}
+	_ ExtendedConfigurableReceiver       = &RepositoryAccessGraphDataSource{}
+)
+
+func NewRepositoryAccessGraphDataSource() datasource.DataSource {
+	return &RepositoryAccessGraphDataSource{}
+}
+
+type RepositoryAccessGraphDataSource struct {
+	config         BambooProviderConfig
+	client         *bamboo.Client
+	extendedClient *ExtendedClient
+}
+
+func (receiver *RepositoryAccessGraphDataSource) setConfig(config BambooProviderConfig, client *bamboo.Client) {
+	receiver.config = config
+	receiver.client = client
+}
+
+func (receiver *RepositoryAccessGraphDataSource) setExtendedClient(extendedClient *ExtendedClient) {
+	receiver.extendedClient = extendedClient
+}
+
+func (receiver *RepositoryAccessGraphDataSource) Configure(ctx context.Context, request datasource.ConfigureRequest, response *datasource.ConfigureResponse) {
+	ConfigureDataSource(receiver, ctx, request, response)
+}
+
+func (receiver *RepositoryAccessGraphDataSource) Metadata(ctx context.Context, request datasource.MetadataRequest, response *datasource.MetadataResponse) {
+	response.TypeName = request.ProviderTypeName + "_repository_access_graph"
+}
+
+func (receiver *RepositoryAccessGraphDataSource) Schema(ctx context.Context, request datasource.SchemaRequest, response *datasource.SchemaResponse) {
+	response.Schema = schema.Schema{
+		MarkdownDescription: `This data source crawls the Bamboo Specs access granted by every linked repository, to review the access relationships as a graph.
+
+An edge goes from the repository granting the access to the repository, project or deployment allowed to use it. The node ids are ` + "`repository/<id>`, `project/<key>` and `deployment/<id>`" + `.
+
+The access list of every linked repository, project and deployment is read, which may take a while on large instances.`,
+		Attributes: map[string]schema.Attribute{
+			"include_projects": schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: "Include the access granted to projects. Default value is `true`.",
+			},
+			"include_deployments": schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: "Include the access granted to deployments. Default value is `true`.",
+			},
+			"nodes": schema.ListNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "Linked repositories, projects and deployments in the graph.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Node id, the kind and the key separated by a slash.",
+						},
+						"kind": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Either `repository`, `project` or `deployment`.",
+						},
+						"key": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Numeric id of the repository or deployment, or the project key.",
+						},
+						"name": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Name.",
+						},
+					},
+				},
+			},
+			"edges": schema.ListNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "Access granted, from the repository to the node allowed to use it.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"from": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Id of the repository node granting the access.",
+						},
+						"to": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Id of the node allowed to use the repository.",
+						},
+					},
+				},
+			},
+			"orphans": schema.ListNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "Linked repositories granting access to nothing.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Numeric id of the linked repository.",
+						},
+						"name": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Name of the linked repository.",
+						},
+					},
+				},
+			},
+			"cycles": schema.ListAttribute{
+				Computed: true,
+				ElementType: types.ListType{
+					ElemType: types.StringType,
+				},
+				MarkdownDescription: "Groups of linked repository node ids granting access to each other in a cycle.",
+			},
+			"dot": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The graph in Graphviz DOT format.",
+			},
+		},
+	}
+}
+
+func (receiver *RepositoryAccessGraphDataSource) Read(ctx context.Context, request datasource.ReadRequest, response *datasource.ReadResponse) {
+	var (
+		diags diag.Diagnostics
+
+		data RepositoryAccessGraphData
+	)
+
+	diags = request.Config.Get(ctx, &data)
+	if util.TestDiagnostic(&response.Diagnostics, diags) {
+		return
+	}
+
+	repositories, err := receiver.extendedClient.RepositoryService().Search("")
+	if util.TestError(&response.Diagnostics, err, errorFailedToReadRepository) {
+		return
+	}
+
+	graph := newAccessGraph()
+	for _, repository := range repositories {
+		graph.addNode(accessNodeRepository, strconv.Itoa(repository.ID), repository.Name)
+	}
+
+	for _, repository := range repositories {
+		accessors, err := receiver.client.RepositoryService().ReadAccessor(repository.ID)
+		if util.TestError(&response.Diagnostics, err, errorFailedToReadRepositoryAccessor) {
+			return
+		}
+
+		for _, accessor := range accessors {
+			graph.addNode(accessNodeRepository, strconv.Itoa(accessor.ID), accessor.Name)
+			graph.addEdge(repository, accessNodeRepository, strconv.Itoa(accessor.ID))
+		}
+	}
+
+	if data.IncludeProjects.IsNull() || data.IncludeProjects.ValueBool() {
+		projects, err := receiver.extendedClient.ProjectService().ReadAll()
+		if util.TestError(&response.Diagnostics, err, errorFailedToReadProjects) {
+			return
+		}
+
+		for _, project := range projects {
+			granting, err := receiver.client.ProjectService().GetSpecRepositories(project.Key)
+			if util.TestError(&response.Diagnostics, err, errorFailedToReadProjectRepositories) {
+				return
+			}
+
+			graph.addNode(accessNodeProject, project.Key, project.Name)
+			for _, repository := range granting {
+				graph.addNode(accessNodeRepository, strconv.Itoa(repository.ID), repository.Name)
+				graph.addEdge(repository, accessNodeProject, project.Key)
+			}
+		}
+	}
+
+	if data.IncludeDeployments.IsNull() || data.IncludeDeployments.ValueBool() {
+		deployments, err := receiver.extendedClient.DeploymentService().ReadAll()
+		if util.TestError(&response.Diagnostics, err, errorFailedToReadDeployment) {
+			return
+		}
+
+		for _, deployment := range deployments {
+			granting, err := receiver.client.DeploymentService().GetSpecRepositories(deployment.ID)
+			if util.TestError(&response.Diagnostics, err, errorFailedToReadDeploymentRepositories) {
+				return
+			}
+
+			graph.addNode(accessNodeDeployment, strconv.Itoa(deployment.ID), deployment.Name)
+			for _, repository := range granting {
+				graph.addNode(accessNodeRepository, strconv.Itoa(repository.ID), repository.Name)
+				graph.addEdge(repository, accessNodeDeployment, strconv.Itoa(deployment.ID))
+			}
+		}
+	}
+
+	data.Nodes = graph.sortedNodes()
+	data.Edges = graph.sortedEdges()
+	data.Orphans = graph.orphans()
+	data.Cycles = graph.cycles()
+	data.Dot = types.StringValue(graph.dot())
+
+	diags = response.State.Set(ctx, &data)
+	if util.TestDiagnostic(&response.Diagnostics, diags) {
+		return
+	}
+}
+
+// accessGraph is the Bamboo Specs access granted by the linked repositories, edges go from the repository to the node
+// allowed to use it.
+type accessGraph struct {
+	nodes map[string]AccessNodeData
+	edges map[string][]string
+}
+
+func newAccessGraph() *accessGraph {
+	return &accessGraph{
+		nodes: make(map[string]AccessNodeData),
+		edges: make(map[string][]string),
+	}
+}
+
+func accessNodeId(kind string, key string) string {
+	return kind + "/" + key
+}
+
+func (graph *accessGraph) addNode(kind string, key string, name string) {
+	id := accessNodeId(kind, key)
+	if _, ok := graph.nodes[id]; !ok {
+		graph.nodes[id] = AccessNodeData{Id: id, Kind: kind, Key: key, Name: name}
+	}
+}
+
+func (graph *accessGraph) addEdge(repository bamboo.Repository, kind string, key string) {
+	from := accessNodeId(accessNodeRepository, strconv.Itoa(repository.ID))
+	to := accessNodeId(kind, key)
+	for _, existing := range graph.edges[from] {
+		if existing == to {
+			return
+		}
+	}
+
+	graph.edges[from] = append(graph.edges[from], to)
+}
+
+func (graph *accessGraph) sortedNodes() []AccessNodeData {
+	var nodes = make([]AccessNodeData, 0)
+	for _, node := range graph.nodes {
+		nodes = append(nodes, node)
+	}
+
+	sort.Slice(nodes, func(i, j int) bool {
+		return compareAccessNodes(nodes[i].Id, nodes[j].Id)
+	})
+
+	return nodes
+}
+
+func (graph *accessGraph) sortedEdges() []AccessEdgeData {
+	var edges = make([]AccessEdgeData, 0)
+	for _, node := range graph.sortedNodes() {
+		targets := append([]string{}, graph.edges[node.Id]...)
+		sort.Slice(targets, func(i, j int) bool {
+			return compareAccessNodes(targets[i], targets[j])
+		})
+
+		for _, target := range targets {
+			edges = append(edges, AccessEdgeData{From: node.Id, To: target})
+		}
+	}
+
+	return edges
+}
+
+// orphans returns the repositories without an outgoing edge.
+func (graph *accessGraph) orphans() []RepositoryReferenceData {
+	var orphans = make([]RepositoryReferenceData, 0)
+	for _, node := range graph.sortedNodes() {
+		if node.Kind == accessNodeRepository && len(graph.edges[node.Id]) == 0 {
+			orphans = append(orphans, RepositoryReferenceData{Id: node.Key, Name: node.Name})
+		}
+	}
+
+	return orphans
+}
+
+// cycles returns the strongly connected groups of repositories, found with Tarjan's algorithm, that have more than one
+// repository or a repository granting access to itself.
+func (graph *accessGraph) cycles() [][]string {
+	var (
+		index   = 0
+		indexes = make(map[string]int)
+		lowest  = make(map[string]int)
+		onStack = make(map[string]bool)
+		stack   []string
+		cycles  = make([][]string, 0)
+	)
+
+	var connect func(node string)
+	connect = func(node string) {
+		indexes[node] = index
+		lowest[node] = index
+		index++
+		stack = append(stack, node)
+		onStack[node] = true
+
+		for _, target := range graph.edges[node] {
+			if graph.nodes[target].Kind != accessNodeRepository {
+				continue
+			}
+
+			if _, visited := indexes[target]; !visited {
+				connect(target)
+				lowest[node] = min(lowest[node], lowest[target])
+			} else if onStack[target] {
+				lowest[node] = min(lowest[node], indexes[target])
+			}
+		}
+
+		if lowest[node] != indexes[node] {
+			return
+		}
+
+		var component []string
+		for {
+			last := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			onStack[last] = false
+			component = append(component, last)
+			if last == node {
+				break
+			}
+		}
+
+		if len(component) > 1 || graph.grantsItself(node) {
+			sort.Slice(component, func(i, j int) bool {
+				return compareAccessNodes(component[i], component[j])
+			})
+			cycles = append(cycles, component)
+		}
+	}
+
+	for _, node := range graph.sortedNodes() {
+		if _, visited := indexes[node.Id]; !visited && node.Kind == accessNodeRepository {
+			connect(node.Id)
+		}
+	}
+
+	sort.Slice(cycles, func(i, j int) bool {
+		return compareAccessNodes(cycles[i][0], cycles[j][0])
+	})
+
+	return cycles
+}
+
+func (graph *accessGraph) grantsItself(node string) bool {
+	for _, target := range graph.edges[node] {
+		if target == node {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (graph *accessGraph) dot() string {
+	shapes := map[string]string{
+		accessNodeRepository: "box",
+		accessNodeProject:    "folder",
+		accessNodeDeployment: "component",
+	}
+
+	var builder strings.Builder
+	builder.WriteString("digraph \"repository_access\" {\n")
+	for _, node := range graph.sortedNodes() {
+		builder.WriteString(fmt.Sprintf("  %s [label=%s, shape=%s];\n", strconv.Quote(node.Id), strconv.Quote(node.Name), shapes[node.Kind]))
+	}
+
+	for _, edge := range graph.sortedEdges() {
+		builder.WriteString(fmt.Sprintf("  %s -> %s;\n", strconv.Quote(edge.From), strconv.Quote(edge.To)))
+	}
+
+	builder.WriteString("}\n")
+	return builder.String()
+}
+
+// compareAccessNodes orders the nodes by kind, then by numeric id or key.
+func compareAccessNodes(left string, right string) bool {
+	leftKind, leftKey, _ := strings.Cut(left, "/")
+	rightKind, rightKey, _ := strings.Cut(right, "/")
+	if leftKind != rightKind {
+		return leftKind > rightKind
+	}
+
+	leftId, leftErr := strconv.Atoi(leftKey)
+	rightId, rightErr := strconv.Atoi(rightKey)
+	if leftErr == nil && rightErr == nil {
+		return leftId < rightId
+	}
+
+	return leftKey < rightKey
+}
@@ -0,0 +1,105 @@
+package provider
+
+import (
+	"context"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/yunarta/terraform-atlassian-api-client/bamboo"
+)
+
+func TestRepositoryAccessGraphDataSource_Read(t *testing.T) {
+	virtualization := newAccessVirtualization()
+	virtualization.AddProject("PROJ", "Project")
+	virtualization.AddDeployment(bamboo.Deployment{ID: 7, Name: "Application Deployment"})
+
+	// app and lib grant access to each other, specs grants access to itself
+	virtualization.GrantAccess("repository/1", 2)
+	virtualization.GrantAccess("repository/2", 1)
+	virtualization.GrantAccess("repository/3", 3)
+	virtualization.GrantAccess("project/PROJ", 1)
+	virtualization.GrantAccess("deployment/7", 2)
+
+	tests := []struct {
+		name       string
+		attributes map[string]any
+		edges      []AccessEdgeData
+		orphans    []RepositoryReferenceData
+	}{
+		{
+			name:       "all",
+			attributes: map[string]any{"include_projects": true},
+			edges: []AccessEdgeData{
+				{From: "repository/1", To: "repository/2"},
+				{From: "repository/1", To: "project/PROJ"},
+				{From: "repository/2", To: "repository/1"},
+				{From: "repository/2", To: "deployment/7"},
+				{From: "repository/3", To: "repository/3"},
+			},
+			orphans: []RepositoryReferenceData{{Id: "4", Name: "other"}},
+		},
+		{
+			name:       "repositories only",
+			attributes: map[string]any{"include_projects": false, "include_deployments": false},
+			edges: []AccessEdgeData{
+				{From: "repository/1", To: "repository/2"},
+				{From: "repository/2", To: "repository/1"},
+				{From: "repository/3", To: "repository/3"},
+			},
+			orphans: []RepositoryReferenceData{{Id: "4", Name: "other"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			state, diags := readDataSource(t, NewRepositoryAccessGraphDataSource(), virtualization, testBambooRss(false), tt.attributes)
+			if diags.HasError() {
+				t.Fatalf("Read() diagnostics = %v", diags)
+			}
+
+			var data RepositoryAccessGraphData
+			if diags = state.Get(context.Background(), &data); diags.HasError() {
+				t.Fatalf("Get() diagnostics = %v", diags)
+			}
+
+			if !reflect.DeepEqual(data.Edges, tt.edges) {
+				t.Errorf("Read() edges = %+v, want %+v", data.Edges, tt.edges)
+			}
+
+			if !reflect.DeepEqual(data.Orphans, tt.orphans) {
+				t.Errorf("Read() orphans = %+v, want %+v", data.Orphans, tt.orphans)
+			}
+
+			wantCycles := [][]string{{"repository/1", "repository/2"}, {"repository/3"}}
+			if !reflect.DeepEqual(data.Cycles, wantCycles) {
+				t.Errorf("Read() cycles = %v, want %v", data.Cycles, wantCycles)
+			}
+
+			for _, edge := range tt.edges {
+				if !strings.Contains(data.Dot.ValueString(), `"`+edge.From+`" -> "`+edge.To+`";`) {
+					t.Errorf("Read() dot is missing edge %s -> %s in\n%s", edge.From, edge.To, data.Dot.ValueString())
+				}
+			}
+		})
+	}
+}
+
+func TestAccessGraph_Cycles(t *testing.T) {
+	graph := newAccessGraph()
+	for _, repository := range []bamboo.Repository{{ID: 1}, {ID: 2}, {ID: 3}, {ID: 10}} {
+		graph.addNode(accessNodeRepository, strconv.Itoa(repository.ID), repository.Name)
+	}
+
+	// 1 -> 2 -> 3 -> 1 is a cycle, 10 only reaches it
+	graph.addEdge(bamboo.Repository{ID: 1}, accessNodeRepository, "2")
+	graph.addEdge(bamboo.Repository{ID: 2}, accessNodeRepository, "3")
+	graph.addEdge(bamboo.Repository{ID: 3}, accessNodeRepository, "1")
+	graph.addEdge(bamboo.Repository{ID: 10}, accessNodeRepository, "1")
+
+	want := [][]string{{"repository/1", "repository/2", "repository/3"}}
+	if got := graph.cycles(); !reflect.DeepEqual(got, want) {
+		t.Errorf("cycles() = %v, want %v", got, want)
+	}
+}
@@ -2,6 +2,7 @@ package provider
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/types"
@@ -20,7 +21,12 @@ type DeploymentModel struct {
 	Repositories           types.List   `tfsdk:"repositories"`
 
 	AssignmentVersion types.String `tfsdk:"assignment_version"`
+	Authoritative     types.Bool   `tfsdk:"authoritative"`
+	IgnorePrincipals  types.List   `tfsdk:"ignore_principals"`
+	MergeStrategy     types.String `tfsdk:"merge_strategy"`
+	StrictPrincipals  types.Bool   `tfsdk:"strict_principals"`
 	Assignments       types.List   `tfsdk:"assignments"`
+	Roles             *RolesModel  `tfsdk:"roles"`
 	ComputedUsers     types.List   `tfsdk:"computed_users"`
 	ComputedGroups    types.List   `tfsdk:"computed_groups"`
 }
@@ -34,6 +40,14 @@ func (d DeploymentModel) getAssignment(ctx context.Context) (Assignments, diag.D
 	return assignments, diags
 }
 
+func (d DeploymentModel) getAssignmentOptions(ctx context.Context) (AssignmentOptions, diag.Diagnostics) {
+	return NewAssignmentOptions(ctx, d.Authoritative, d.IgnorePrincipals, d.MergeStrategy, d.StrictPrincipals)
+}
+
+func (d DeploymentModel) getRoles(ctx context.Context) *RolesModel {
+	return d.Roles
+}
+
 func (d DeploymentModel) getDeploymentId(ctx context.Context) int {
 	deploymentId, _ := strconv.Atoi(d.ID.ValueString())
 	return deploymentId
@@ -49,8 +63,36 @@ func NewDeploymentModel(plan DeploymentModel, deployment *bamboo.Deployment, ass
 		RepositorySpecsManaged: types.BoolValue(deployment.RepositorySpecsManaged),
 		Repositories:           plan.Repositories,
 		AssignmentVersion:      plan.AssignmentVersion,
+		Authoritative:          assignmentAuthoritative(plan.Authoritative),
+		IgnorePrincipals:       plan.IgnorePrincipals,
+		MergeStrategy:          assignmentMergeStrategy(plan.MergeStrategy),
+		StrictPrincipals:       assignmentStrictPrincipals(plan.StrictPrincipals),
 		Assignments:            plan.Assignments,
+		Roles:                  assignmentResult.Roles,
 		ComputedUsers:          assignmentResult.ComputedUsers,
 		ComputedGroups:         assignmentResult.ComputedGroups,
 	}
 }
+
+// ReadDeploymentByName resolves a deployment by its exact name, it returns nil when there is no such deployment.
+func ReadDeploymentByName(client *bamboo.Client, extendedClient *ExtendedClient, name string) (*bamboo.Deployment, diag.Diagnostics) {
+	var ambiguousDeploymentError *AmbiguousDeploymentError
+
+	deploymentId, err := extendedClient.DeploymentService().FindId(name)
+	if errors.As(err, &ambiguousDeploymentError) {
+		return nil, []diag.Diagnostic{diag.NewErrorDiagnostic(errorAmbiguousDeploymentName, err.Error())}
+	} else if err != nil {
+		return nil, []diag.Diagnostic{diag.NewErrorDiagnostic(errorFailedToReadDeployment, err.Error())}
+	}
+
+	if deploymentId == 0 {
+		return nil, nil
+	}
+
+	deployment, err := client.DeploymentService().ReadWithId(deploymentId)
+	if err != nil {
+		return nil, []diag.Diagnostic{diag.NewErrorDiagnostic(errorFailedToReadDeployment, err.Error())}
+	}
+
+	return deployment, nil
+}
@@ -2,6 +2,7 @@ package provider
 
 import (
 	"context"
+	"fmt"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/yunarta/terraform-atlassian-api-client/bamboo"
 )
@@ -12,6 +13,8 @@ type DeploymentPermissionsReceiver interface {
 
 type DeploymentPermissionInterface interface {
 	getAssignment(ctx context.Context) (Assignments, diag.Diagnostics)
+	getAssignmentOptions(ctx context.Context) (AssignmentOptions, diag.Diagnostics)
+	getRoles(ctx context.Context) *RolesModel
 	getDeploymentId(ctx context.Context) int
 }
 
@@ -21,18 +24,29 @@ func CreateDeploymentAssignments(ctx context.Context, receiver DeploymentPermiss
 		return nil, diags
 	}
 
-	assignmentOrder, diags := assignments.CreateAssignmentOrder(ctx)
+	options, diags := plan.getAssignmentOptions(ctx)
+	if diags != nil {
+		return nil, diags
+	}
+
+	assignmentOrder, diags := assignments.CreateAssignmentOrder(ctx, options.MergeStrategy)
 	if diags != nil {
 		return nil, diags
 	}
 
 	deploymentId := plan.getDeploymentId(ctx)
+	options.Target = fmt.Sprintf("deployment %d", deploymentId)
 
-	_ = receiver.getClient().DeploymentService().UpdateRolePermissions(deploymentId, "LOGGED_IN", make([]string, 0))
-	_ = receiver.getClient().DeploymentService().UpdateRolePermissions(deploymentId, "ANONYMOUS", make([]string, 0))
+	diags = ApplyRoles(ctx, plan.getRoles(ctx), deploymentRoles, options.Target, func(role string, requestedPermissions []string) error {
+		return receiver.getClient().DeploymentService().UpdateRolePermissions(deploymentId, role, requestedPermissions)
+	})
+	if diags != nil {
+		return nil, diags
+	}
 
-	return ApplyNewAssignmentSet(ctx, receiver.getClient().UserService(),
+	result, diags := ApplyNewAssignmentSet(ctx, receiver.getClient().UserService(),
 		*assignmentOrder,
+		options,
 		func(user string) (*bamboo.UserPermission, error) {
 			return receiver.getClient().DeploymentService().FindAvailableUser(deploymentId, user)
 		},
@@ -46,6 +60,27 @@ func CreateDeploymentAssignments(ctx context.Context, receiver DeploymentPermiss
 			return receiver.getClient().DeploymentService().UpdateGroupPermissions(deploymentId, group, requestedPermissions)
 		},
 	)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	revokeDiags := RevokeUnmanagedAssignment(*assignmentOrder, options,
+		func() (*bamboo.ObjectPermission, error) {
+			return receiver.getClient().DeploymentService().ReadPermissions(deploymentId)
+		},
+		func(user string, requestedPermissions []string) error {
+			return receiver.getClient().DeploymentService().UpdateUserPermissions(deploymentId, user, requestedPermissions)
+		},
+		func(group string, requestedPermissions []string) error {
+			return receiver.getClient().DeploymentService().UpdateGroupPermissions(deploymentId, group, requestedPermissions)
+		},
+	)
+	if revokeDiags != nil {
+		return nil, append(diags, revokeDiags...)
+	}
+
+	result.Roles = plan.getRoles(ctx)
+	return result, diags
 }
 
 func ComputeDeploymentAssignments(ctx context.Context, receiver DeploymentPermissionsReceiver, state DeploymentPermissionInterface) (*AssignmentResult, diag.Diagnostics) {
@@ -54,7 +89,12 @@ func ComputeDeploymentAssignments(ctx context.Context, receiver DeploymentPermis
 		return nil, diags
 	}
 
-	assignmentOrder, diags := assignments.CreateAssignmentOrder(ctx)
+	options, diags := state.getAssignmentOptions(ctx)
+	if diags != nil {
+		return nil, diags
+	}
+
+	assignmentOrder, diags := assignments.CreateAssignmentOrder(ctx, options.MergeStrategy)
 	if diags != nil {
 		return nil, diags
 	}
@@ -65,7 +105,24 @@ func ComputeDeploymentAssignments(ctx context.Context, receiver DeploymentPermis
 		return nil, []diag.Diagnostic{diag.NewErrorDiagnostic("Failed to read deployment permissions", err.Error())}
 	}
 
-	return ComputeAssignment(ctx, assignedPermissions, *assignmentOrder)
+	result, diags := ComputeAssignment(ctx, assignedPermissions, *assignmentOrder, options,
+		func(user string) (*bamboo.UserPermission, error) {
+			return receiver.getClient().DeploymentService().FindAvailableUser(deploymentId, user)
+		},
+		func(group string) (*bamboo.GroupPermission, error) {
+			return receiver.getClient().DeploymentService().FindAvailableGroup(deploymentId, group)
+		},
+	)
+	if diags != nil {
+		return nil, diags
+	}
+
+	result.Roles, diags = ComputeRoles(ctx, state.getRoles(ctx), assignedPermissions.Roles, deploymentRoles)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	return result, nil
 }
 
 func UpdateDeploymentAssignments(ctx context.Context, receiver DeploymentPermissionsReceiver,
@@ -78,28 +135,40 @@ func UpdateDeploymentAssignments(ctx context.Context, receiver DeploymentPermiss
 		return nil, diags
 	}
 
+	options, diags := plan.getAssignmentOptions(ctx)
+	if diags != nil {
+		return nil, diags
+	}
+
 	inStateAssignments, diags := state.getAssignment(ctx)
 	if diags != nil {
 		return nil, diags
 	}
 
-	plannedAssignmentOrder, diags := plannedAssignments.CreateAssignmentOrder(ctx)
+	inStateOptions, diags := state.getAssignmentOptions(ctx)
+	if diags != nil {
+		return nil, diags
+	}
+
+	plannedAssignmentOrder, diags := plannedAssignments.CreateAssignmentOrder(ctx, options.MergeStrategy)
 	if diags != nil {
 		return nil, diags
 	}
 
-	inStateAssignmentOrder, diags := inStateAssignments.CreateAssignmentOrder(ctx)
+	inStateAssignmentOrder, diags := inStateAssignments.CreateAssignmentOrder(ctx, inStateOptions.MergeStrategy)
 	if diags != nil {
 		return nil, diags
 	}
 
 	// the plan does not have computed value deployment ID
 	deploymentId := state.getDeploymentId(ctx)
+	options.Target = fmt.Sprintf("deployment %d", deploymentId)
 
-	return UpdateAssignment(ctx, receiver.getClient().UserService(),
+	result, diags := UpdateAssignment(ctx, receiver.getClient().UserService(),
 		*inStateAssignmentOrder,
 		*plannedAssignmentOrder,
 		forceUpdate,
+		options,
 		func(user string) (*bamboo.UserPermission, error) {
 			return receiver.getClient().DeploymentService().FindAvailableUser(deploymentId, user)
 		},
@@ -113,6 +182,34 @@ func UpdateDeploymentAssignments(ctx context.Context, receiver DeploymentPermiss
 			return receiver.getClient().DeploymentService().UpdateGroupPermissions(deploymentId, group, requestedPermissions)
 		},
 	)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	revokeDiags := RevokeUnmanagedAssignment(*plannedAssignmentOrder, options,
+		func() (*bamboo.ObjectPermission, error) {
+			return receiver.getClient().DeploymentService().ReadPermissions(deploymentId)
+		},
+		func(user string, requestedPermissions []string) error {
+			return receiver.getClient().DeploymentService().UpdateUserPermissions(deploymentId, user, requestedPermissions)
+		},
+		func(group string, requestedPermissions []string) error {
+			return receiver.getClient().DeploymentService().UpdateGroupPermissions(deploymentId, group, requestedPermissions)
+		},
+	)
+	if revokeDiags != nil {
+		return nil, append(diags, revokeDiags...)
+	}
+
+	roleDiags := ApplyRoles(ctx, plan.getRoles(ctx), deploymentRoles, options.Target, func(role string, requestedPermissions []string) error {
+		return receiver.getClient().DeploymentService().UpdateRolePermissions(deploymentId, role, requestedPermissions)
+	})
+	if roleDiags != nil {
+		return nil, append(diags, roleDiags...)
+	}
+
+	result.Roles = plan.getRoles(ctx)
+	return result, diags
 }
 
 func DeleteDeploymentAssignments(ctx context.Context, receiver DeploymentPermissionsReceiver, state DeploymentPermissionInterface) diag.Diagnostics {
@@ -121,7 +218,12 @@ func DeleteDeploymentAssignments(ctx context.Context, receiver DeploymentPermiss
 		return diags
 	}
 
-	assignmentOrder, diags := assignments.CreateAssignmentOrder(ctx)
+	options, diags := state.getAssignmentOptions(ctx)
+	if diags != nil {
+		return diags
+	}
+
+	assignmentOrder, diags := assignments.CreateAssignmentOrder(ctx, options.MergeStrategy)
 	if diags != nil {
 		return diags
 	}
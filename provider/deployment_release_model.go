@@ -0,0 +1,31 @@
+package provider
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"strconv"
+)
+
+type DeploymentReleaseModel struct {
+	RetainOnDelete types.Bool   `tfsdk:"retain_on_delete"`
+	ID             types.String `tfsdk:"id"`
+	DeploymentId   types.String `tfsdk:"deployment_id"`
+	Name           types.String `tfsdk:"name"`
+	PlanResultKey  types.String `tfsdk:"plan_result_key"`
+	CreationDate   types.String `tfsdk:"creation_date"`
+}
+
+func NewDeploymentReleaseModel(plan DeploymentReleaseModel, version *DeploymentVersion) *DeploymentReleaseModel {
+	planResultKey := plan.PlanResultKey
+	if key := version.PlanResultKey(); key != "" {
+		planResultKey = types.StringValue(key)
+	}
+
+	return &DeploymentReleaseModel{
+		RetainOnDelete: plan.RetainOnDelete,
+		ID:             types.StringValue(strconv.Itoa(version.ID)),
+		DeploymentId:   plan.DeploymentId,
+		Name:           types.StringValue(version.Name),
+		PlanResultKey:  planResultKey,
+		CreationDate:   types.StringValue(formatBambooTime(version.CreationDate)),
+	}
+}
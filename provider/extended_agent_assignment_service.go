@@ -0,0 +1,35 @@
+package provider
+
+import (
+	"fmt"
+	"github.com/yunarta/terraform-api-transport/transport"
+	"github.com/yunarta/terraform-atlassian-api-client/bamboo"
+	"net/http"
+	"net/url"
+)
+
+const agentAssignmentEndpoint = "/rest/api/latest/agent/assignment?executorType=%s&executorId=%d"
+
+type ExtendedAgentAssignmentService struct {
+	transport transport.PayloadTransport
+}
+
+// Read returns the executables assigned to an agent. bamboo.AgentAssignmentService reads with POST, the method Bamboo
+// uses to create an assignment.
+func (service *ExtendedAgentAssignmentService) Read(query bamboo.AgentQuery) ([]bamboo.AgentAssignment, error) {
+	reply, err := service.transport.SendWithExpectedStatus(&transport.PayloadRequest{
+		Method: http.MethodGet,
+		Url:    fmt.Sprintf(agentAssignmentEndpoint, url.QueryEscape(query.ExecutorType), query.ExecutorId),
+	}, 200)
+	if err != nil {
+		return nil, err
+	}
+
+	assignments := make([]bamboo.AgentAssignment, 0)
+	err = reply.Object(&assignments)
+	if err != nil {
+		return nil, err
+	}
+
+	return assignments, nil
+}
@@ -0,0 +1,39 @@
+package provider
+
+import (
+	"github.com/yunarta/terraform-api-transport/transport"
+)
+
+// ExtendedClient provides access to Bamboo REST endpoints that are not covered by bamboo.Client.
+// It shares the transport of bamboo.Client, so authentication and recording behave the same way.
+type ExtendedClient struct {
+	deploymentService *ExtendedDeploymentService
+	repositoryService *ExtendedRepositoryService
+	projectService    *ExtendedProjectService
+	agentService      *ExtendedAgentAssignmentService
+}
+
+func NewExtendedClient(transport transport.PayloadTransport) *ExtendedClient {
+	return &ExtendedClient{
+		deploymentService: &ExtendedDeploymentService{transport: transport},
+		repositoryService: &ExtendedRepositoryService{transport: transport},
+		projectService:    &ExtendedProjectService{transport: transport},
+		agentService:      &ExtendedAgentAssignmentService{transport: transport},
+	}
+}
+
+func (client *ExtendedClient) DeploymentService() *ExtendedDeploymentService {
+	return client.deploymentService
+}
+
+func (client *ExtendedClient) RepositoryService() *ExtendedRepositoryService {
+	return client.repositoryService
+}
+
+func (client *ExtendedClient) ProjectService() *ExtendedProjectService {
+	return client.projectService
+}
+
+func (client *ExtendedClient) AgentAssignmentService() *ExtendedAgentAssignmentService {
+	return client.agentService
+}
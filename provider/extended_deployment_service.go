@@ -0,0 +1,311 @@
+package provider
+
+import (
+	"fmt"
+	"github.com/yunarta/terraform-api-transport/transport"
+	"github.com/yunarta/terraform-atlassian-api-client/bamboo"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	deploymentSearchPageSize     = 100
+	deploymentSearchEndpoint     = "/rest/api/latest/search/deployments?searchTerm=%s&start-index=%d&max-result=%d"
+	deploymentVersionsEndpoint   = "/rest/api/latest/deploy/project/%d/versions?start-index=%d&max-result=%d"
+	deploymentNewVersionEndpoint = "/rest/api/latest/deploy/project/%d/version"
+	deploymentVersionEndpoint    = "/rest/api/latest/deploy/version/%d"
+	deploymentDashboardEndpoint  = "/rest/api/latest/deploy/dashboard/%d"
+	deploymentAllEndpoint        = "/rest/api/latest/deploy/project/all"
+	deploymentWithIdEndpoint     = "/rest/api/latest/deploy/project/%d"
+)
+
+// DeploymentVersion is a release of a deployment project.
+type DeploymentVersion struct {
+	ID           int                     `json:"id,omitempty"`
+	Name         string                  `json:"name,omitempty"`
+	CreationDate int64                   `json:"creationDate,omitempty"`
+	Items        []DeploymentVersionItem `json:"items,omitempty"`
+}
+
+// PlanResultKey returns the plan result key of the first artifact item, which is the build the release was created from.
+func (version DeploymentVersion) PlanResultKey() string {
+	for _, item := range version.Items {
+		if item.PlanResultKey.Key != "" {
+			return item.PlanResultKey.Key
+		}
+	}
+
+	return ""
+}
+
+type DeploymentVersionItem struct {
+	PlanResultKey PlanResultKey `json:"planResultKey,omitempty"`
+}
+
+type PlanResultKey struct {
+	Key string `json:"key,omitempty"`
+}
+
+type DeploymentVersionList struct {
+	Start     int                 `json:"start-index,omitempty"`
+	MaxResult int                 `json:"max-result,omitempty"`
+	Versions  []DeploymentVersion `json:"versions,omitempty"`
+}
+
+type CreateDeploymentVersion struct {
+	Name          string `json:"name"`
+	PlanResultKey string `json:"planResultKey"`
+}
+
+// DeploymentEnvironment is an environment of a deployment project along with its position, which the API client
+// environment does not carry.
+type DeploymentEnvironment struct {
+	ID       int    `json:"id,omitempty"`
+	Name     string `json:"name,omitempty"`
+	Position int    `json:"position"`
+}
+
+// DeploymentStatus is the dashboard view of a deployment project, holding the latest result of each environment.
+type DeploymentStatus struct {
+	EnvironmentStatuses []EnvironmentStatus `json:"environmentStatuses,omitempty"`
+}
+
+type EnvironmentStatus struct {
+	Environment      bamboo.Environment `json:"environment,omitempty"`
+	DeploymentResult *DeploymentResult  `json:"deploymentResult,omitempty"`
+}
+
+type DeploymentResult struct {
+	DeploymentVersion *DeploymentVersion `json:"deploymentVersion,omitempty"`
+	DeploymentState   string             `json:"deploymentState,omitempty"`
+	LifeCycleState    string             `json:"lifeCycleState,omitempty"`
+}
+
+// AmbiguousDeploymentError is returned when a deployment name matches more than one deployment.
+type AmbiguousDeploymentError struct {
+	Name string
+	IDs  []int
+}
+
+func (e *AmbiguousDeploymentError) Error() string {
+	return fmt.Sprintf("deployment name %s matches more than one deployment (ids %v), use the deployment id instead", e.Name, e.IDs)
+}
+
+// lastPage reports whether a page of results is the last one. The server may cap max-result below the requested page
+// size, so a page is only short when it holds fewer results than the max-result the server replied with.
+func lastPage(results int, maxResult int) bool {
+	return results == 0 || (maxResult > 0 && results < maxResult)
+}
+
+type ExtendedDeploymentService struct {
+	transport transport.PayloadTransport
+}
+
+// FindId pages through the deployment search results and returns the id of the deployment
+// whose name is an exact, case-insensitive, match of the requested name.
+//
+// It returns 0 when no deployment matches, and an error when more than one deployment matches.
+func (service *ExtendedDeploymentService) FindId(deploymentName string) (int, error) {
+	var matches = make([]int, 0)
+
+	for start := 0; ; {
+		reply, err := service.transport.SendWithExpectedStatus(&transport.PayloadRequest{
+			Method: http.MethodGet,
+			Url:    fmt.Sprintf(deploymentSearchEndpoint, url.QueryEscape(deploymentName), start, deploymentSearchPageSize),
+		}, 200)
+		if err != nil {
+			return 0, err
+		}
+
+		deploymentList := bamboo.DeploymentList{}
+		err = reply.Object(&deploymentList)
+		if err != nil {
+			return 0, err
+		}
+
+		for _, deployment := range deploymentList.Results {
+			if !strings.EqualFold(deployment.SearchEntity.ProjectName, deploymentName) {
+				continue
+			}
+
+			id, err := strconv.Atoi(deployment.Id)
+			if err != nil {
+				return 0, err
+			}
+
+			matches = append(matches, id)
+		}
+
+		if lastPage(len(deploymentList.Results), deploymentList.MaxResult) {
+			break
+		}
+
+		start += len(deploymentList.Results)
+	}
+
+	switch len(matches) {
+	case 0:
+		return 0, nil
+	case 1:
+		return matches[0], nil
+	default:
+		return 0, &AmbiguousDeploymentError{Name: deploymentName, IDs: matches}
+	}
+}
+
+// ReadEnvironments retrieves the environments of the deployment project, ordered by their position.
+func (service *ExtendedDeploymentService) ReadEnvironments(deploymentId int) ([]DeploymentEnvironment, error) {
+	reply, err := service.transport.SendWithExpectedStatus(&transport.PayloadRequest{
+		Method: http.MethodGet,
+		Url:    fmt.Sprintf(deploymentWithIdEndpoint, deploymentId),
+	}, 200)
+	if err != nil {
+		return nil, err
+	}
+
+	deployment := struct {
+		Environments []DeploymentEnvironment `json:"environments,omitempty"`
+	}{}
+	err = reply.Object(&deployment)
+	if err != nil {
+		return nil, err
+	}
+
+	environments := deployment.Environments
+	if environments == nil {
+		environments = make([]DeploymentEnvironment, 0)
+	}
+
+	sort.SliceStable(environments, func(i, j int) bool {
+		return environments[i].Position < environments[j].Position
+	})
+
+	return environments, nil
+}
+
+// ReadVersions pages through all releases of the deployment project.
+func (service *ExtendedDeploymentService) ReadVersions(deploymentId int) ([]DeploymentVersion, error) {
+	var versions = make([]DeploymentVersion, 0)
+
+	for start := 0; ; {
+		reply, err := service.transport.SendWithExpectedStatus(&transport.PayloadRequest{
+			Method: http.MethodGet,
+			Url:    fmt.Sprintf(deploymentVersionsEndpoint, deploymentId, start, deploymentSearchPageSize),
+		}, 200)
+		if err != nil {
+			return nil, err
+		}
+
+		versionList := DeploymentVersionList{}
+		err = reply.Object(&versionList)
+		if err != nil {
+			return nil, err
+		}
+
+		versions = append(versions, versionList.Versions...)
+		if lastPage(len(versionList.Versions), versionList.MaxResult) {
+			break
+		}
+
+		start += len(versionList.Versions)
+	}
+
+	return versions, nil
+}
+
+// ReadVersion retrieves a release by its id, it returns nil when the release no longer exists.
+func (service *ExtendedDeploymentService) ReadVersion(versionId int) (*DeploymentVersion, error) {
+	reply, err := service.transport.SendWithExpectedStatus(&transport.PayloadRequest{
+		Method: http.MethodGet,
+		Url:    fmt.Sprintf(deploymentVersionEndpoint, versionId),
+	}, 200, 404)
+	if err != nil {
+		return nil, err
+	}
+
+	if reply.StatusCode == 404 {
+		return nil, nil
+	}
+
+	version := DeploymentVersion{}
+	err = reply.Object(&version)
+	if err != nil {
+		return nil, err
+	}
+
+	return &version, nil
+}
+
+// CreateVersion creates a release of the deployment project from a plan result.
+func (service *ExtendedDeploymentService) CreateVersion(deploymentId int, request CreateDeploymentVersion) (*DeploymentVersion, error) {
+	reply, err := service.transport.SendWithExpectedStatus(&transport.PayloadRequest{
+		Method:  http.MethodPost,
+		Url:     fmt.Sprintf(deploymentNewVersionEndpoint, deploymentId),
+		Payload: transport.JsonPayloadData{Payload: request},
+	}, 200)
+	if err != nil {
+		return nil, err
+	}
+
+	version := DeploymentVersion{}
+	err = reply.Object(&version)
+	if err != nil {
+		return nil, err
+	}
+
+	return &version, nil
+}
+
+func (service *ExtendedDeploymentService) DeleteVersion(versionId int) error {
+	_, err := service.transport.SendWithExpectedStatus(&transport.PayloadRequest{
+		Method: http.MethodDelete,
+		Url:    fmt.Sprintf(deploymentVersionEndpoint, versionId),
+	}, 200, 204)
+
+	return err
+}
+
+// ReadStatus retrieves the latest deployment result of every environment of the deployment project.
+func (service *ExtendedDeploymentService) ReadStatus(deploymentId int) (*DeploymentStatus, error) {
+	reply, err := service.transport.SendWithExpectedStatus(&transport.PayloadRequest{
+		Method: http.MethodGet,
+		Url:    fmt.Sprintf(deploymentDashboardEndpoint, deploymentId),
+	}, 200)
+	if err != nil {
+		return nil, err
+	}
+
+	var statuses []DeploymentStatus
+	err = reply.Object(&statuses)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(statuses) == 0 {
+		return &DeploymentStatus{}, nil
+	}
+
+	return &statuses[0], nil
+}
+
+// ReadAll retrieves every deployment project visible to the user.
+func (service *ExtendedDeploymentService) ReadAll() ([]bamboo.Deployment, error) {
+	reply, err := service.transport.SendWithExpectedStatus(&transport.PayloadRequest{
+		Method: http.MethodGet,
+		Url:    deploymentAllEndpoint,
+	}, 200)
+	if err != nil {
+		return nil, err
+	}
+
+	var deployments []bamboo.Deployment
+	err = reply.Object(&deployments)
+	if err != nil {
+		return nil, err
+	}
+
+	return deployments, nil
+}
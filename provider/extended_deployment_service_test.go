@@ -0,0 +1,77 @@
+package provider
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/yunarta/terraform-atlassian-api-client/bamboo"
+	"github.com/yunarta/terraform-provider-bamboo/provider/test"
+)
+
+func TestExtendedDeploymentService_FindId(t *testing.T) {
+	virtualization := test.NewServiceVirtualization()
+	virtualization.AddDeployment(bamboo.Deployment{ID: 1, Name: "app-deploy-legacy"})
+	virtualization.AddDeployment(bamboo.Deployment{ID: 2, Name: "app-deploy"})
+	for id := 10; id < 260; id++ {
+		virtualization.AddDeployment(bamboo.Deployment{ID: id, Name: fmt.Sprintf("app-deploy-%d", id)})
+	}
+	virtualization.AddDeployment(bamboo.Deployment{ID: 500, Name: "app-deploy-last"})
+
+	service := NewExtendedClient(virtualization).DeploymentService()
+
+	tests := []struct {
+		name string
+		want int
+	}{
+		{name: "app-deploy", want: 2},
+		{name: "APP-DEPLOY", want: 2},
+		{name: "app-deploy-last", want: 500},
+		{name: "app-deploy-missing", want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := service.FindId(tt.name)
+			if err != nil {
+				t.Fatalf("FindId() error = %v", err)
+			}
+
+			if got != tt.want {
+				t.Errorf("FindId() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExtendedDeploymentService_FindIdLimitedPageSize(t *testing.T) {
+	virtualization := test.NewServiceVirtualization()
+	virtualization.LimitPageSize(25)
+	for id := 10; id < 60; id++ {
+		virtualization.AddDeployment(bamboo.Deployment{ID: id, Name: fmt.Sprintf("app-deploy-%d", id)})
+	}
+	virtualization.AddDeployment(bamboo.Deployment{ID: 500, Name: "app-deploy"})
+
+	// the server replies with 25 results per page, the match is on the third page
+	got, err := NewExtendedClient(virtualization).DeploymentService().FindId("app-deploy")
+	if err != nil {
+		t.Fatalf("FindId() error = %v", err)
+	}
+
+	if got != 500 {
+		t.Errorf("FindId() = %v, want 500", got)
+	}
+}
+
+func TestExtendedDeploymentService_FindIdAmbiguous(t *testing.T) {
+	virtualization := test.NewServiceVirtualization()
+	virtualization.AddDeployment(bamboo.Deployment{ID: 1, Name: "app-deploy"})
+	virtualization.AddDeployment(bamboo.Deployment{ID: 2, Name: "App-Deploy"})
+
+	_, err := NewExtendedClient(virtualization).DeploymentService().FindId("app-deploy")
+
+	var ambiguousDeploymentError *AmbiguousDeploymentError
+	if !errors.As(err, &ambiguousDeploymentError) {
+		t.Fatalf("FindId() error = %v, want AmbiguousDeploymentError", err)
+	}
+}
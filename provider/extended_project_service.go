@@ -0,0 +1,97 @@
+package provider
+
+import (
+	"fmt"
+	"github.com/yunarta/golang-quality-of-life-pack/collections"
+	"github.com/yunarta/terraform-api-transport/transport"
+	"github.com/yunarta/terraform-atlassian-api-client/bamboo"
+	"net/http"
+	"slices"
+)
+
+const (
+	projectListPageSize = 100
+	projectListEndpoint = "/rest/api/latest/project?start-index=%d&max-result=%d"
+
+	projectRolePermissionEndpoint     = "/rest/api/latest/permissions/project/%s/roles"
+	projectPlanRolePermissionEndpoint = "/rest/api/latest/permissions/projectplan/%s/roles"
+)
+
+// ProjectSummary is a Bamboo project as listed by the project endpoint.
+type ProjectSummary struct {
+	Key  string `json:"key,omitempty"`
+	Name string `json:"name,omitempty"`
+}
+
+type ProjectList struct {
+	Projects struct {
+		Size      int              `json:"size,omitempty"`
+		Start     int              `json:"start-index,omitempty"`
+		MaxResult int              `json:"max-result,omitempty"`
+		Project   []ProjectSummary `json:"project,omitempty"`
+	} `json:"projects"`
+}
+
+type ExtendedProjectService struct {
+	transport transport.PayloadTransport
+}
+
+// ReadAll pages through every Bamboo project visible to the user.
+func (service *ExtendedProjectService) ReadAll() ([]ProjectSummary, error) {
+	var projects = make([]ProjectSummary, 0)
+
+	for start := 0; ; {
+		reply, err := service.transport.SendWithExpectedStatus(&transport.PayloadRequest{
+			Method: http.MethodGet,
+			Url:    fmt.Sprintf(projectListEndpoint, start, projectListPageSize),
+		}, 200)
+		if err != nil {
+			return nil, err
+		}
+
+		projectList := ProjectList{}
+		err = reply.Object(&projectList)
+		if err != nil {
+			return nil, err
+		}
+
+		projects = append(projects, projectList.Projects.Project...)
+		if lastPage(len(projectList.Projects.Project), projectList.Projects.MaxResult) {
+			break
+		}
+
+		start += len(projectList.Projects.Project)
+	}
+
+	return projects, nil
+}
+
+// ReadRolePermissions reads the role permissions of a project, merging the permissions granted on the project and on
+// its plans the same way the project permissions are read.
+func (service *ExtendedProjectService) ReadRolePermissions(projectKey string) ([]bamboo.RolePermission, error) {
+	var roles = make([]bamboo.RolePermission, 0)
+
+	for _, endpoint := range []string{projectRolePermissionEndpoint, projectPlanRolePermissionEndpoint} {
+		reply, err := bamboo.PermissionsHelper{
+			Transport: service.transport,
+			Url:       fmt.Sprintf(endpoint, projectKey),
+		}.ReadRolePermissions()
+		if err != nil {
+			return nil, err
+		}
+
+		for _, role := range reply.Results {
+			index := slices.IndexFunc(roles, func(known bamboo.RolePermission) bool {
+				return known.Name == role.Name
+			})
+
+			if index < 0 {
+				roles = append(roles, role)
+			} else {
+				roles[index].Permissions = collections.Unique(append(roles[index].Permissions, role.Permissions...))
+			}
+		}
+	}
+
+	return roles, nil
+}
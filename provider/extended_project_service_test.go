@@ -0,0 +1,26 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/yunarta/terraform-provider-bamboo/provider/test"
+)
+
+func TestExtendedProjectService_ReadAllLimitedPageSize(t *testing.T) {
+	virtualization := test.NewServiceVirtualization()
+	virtualization.LimitPageSize(25)
+	for index := 0; index < 60; index++ {
+		virtualization.AddProject(fmt.Sprintf("PROJ%02d", index), fmt.Sprintf("Project %d", index))
+	}
+
+	// the server replies with 25 results per page, the last project is on the third page
+	got, err := NewExtendedClient(virtualization).ProjectService().ReadAll()
+	if err != nil {
+		t.Fatalf("ReadAll() error = %v", err)
+	}
+
+	if len(got) != 60 || got[59].Key != "PROJ59" {
+		t.Errorf("ReadAll() = %d projects, want 60 ending with PROJ59", len(got))
+	}
+}
@@ -0,0 +1,533 @@
+package provider
+
+import (
+	"fmt"
+	"github.com/yunarta/terraform-api-transport/transport"
+	"github.com/yunarta/terraform-atlassian-api-client/bamboo"
+	"net/http"
+	"net/url"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+const (
+	repositorySearchPageSize  = 100
+	repositorySearchEndpoint  = "/rest/api/latest/repository?searchTerm=%s&start-index=%d&max-result=%d"
+	repositoryProjectEndpoint = "/rest/api/latest/project/%s/repositories?filter=%s&start-index=%d&max-result=%d"
+	repositoryImportEndpoint  = "/rest/api/latest/import/repository"
+	repositoryExportEndpoint  = "/rest/api/latest/export/repository/id/%d"
+	repositoryEndpoint        = "/rest/api/latest/repository/%d"
+	repositorySpecsEndpoint   = "/rest/api/latest/repository/%d/specsState"
+	repositoryUsageEndpoint   = "/rest/api/latest/repository/%d/usage"
+)
+
+const (
+	specsScanQueued     = "QUEUED"
+	specsScanInProgress = "IN_PROGRESS"
+	specsScanSuccess    = "SUCCESS"
+	specsScanFailed     = "FAILED"
+)
+
+// RepositorySpecsState is the outcome of the latest Bamboo Specs scan of a linked repository, times are in epoch milliseconds.
+type RepositorySpecsState struct {
+	State      string `json:"state,omitempty"`
+	StartTime  int64  `json:"startTime,omitempty"`
+	FinishTime int64  `json:"finishTime,omitempty"`
+	Log        string `json:"log,omitempty"`
+}
+
+func (state RepositorySpecsState) running() bool {
+	return state.State == specsScanQueued || state.State == specsScanInProgress
+}
+
+// RepositoryDetail is the configuration of a linked repository, the source repository fields follow the Bamboo Specs properties.
+type RepositoryDetail struct {
+	ID          int              `json:"id,omitempty"`
+	Name        string           `json:"name,omitempty"`
+	RssEnabled  bool             `json:"rssEnabled,omitempty"`
+	Project     *bamboo.Key      `json:"project,omitempty"`
+	ProjectKey  string           `json:"projectKey,omitempty"`
+	Slug        string           `json:"repositorySlug,omitempty"`
+	Branch      string           `json:"branch,omitempty"`
+	Server      RepositoryServer `json:"server,omitempty"`
+	SshCloneUrl string           `json:"sshCloneUrl,omitempty"`
+
+	// Url is the clone URL of a Git repository, and Repository the owner/name of a GitHub repository.
+	Url                      string                 `json:"url,omitempty"`
+	Repository               string                 `json:"repository,omitempty"`
+	AuthenticationProperties *RepositoryCredentials `json:"authenticationProperties,omitempty"`
+	SharedCredentials        *RepositoryCredentials `json:"sharedCredentials,omitempty"`
+
+	UseShallowClones   bool                          `json:"useShallowClones,omitempty"`
+	UseSubmodules      bool                          `json:"useSubmodules,omitempty"`
+	UseLfs             bool                          `json:"useLfs,omitempty"`
+	WebhookEnabled     *bool                         `json:"webhookEnabled,omitempty"`
+	VcsChangeDetection *RepositoryVcsChangeDetection `json:"vcsChangeDetection,omitempty"`
+}
+
+// RepositoryCredentials names the shared credential of a repository, it is empty when the repository uses an SSH key.
+type RepositoryCredentials struct {
+	Name string `json:"name,omitempty"`
+}
+
+// Type returns the linked repository type matching the source repository fields, or an empty string when Bamboo
+// reports none of them. A GitLab project is linked as a Git repository, so it reads back as git.
+func (detail RepositoryDetail) Type() string {
+	switch {
+	case detail.Slug != "" || detail.ProjectKey != "":
+		return linkedRepositoryTypeBitbucketServer
+	case detail.Repository != "":
+		return linkedRepositoryTypeGitHub
+	case detail.Url != "":
+		return linkedRepositoryTypeGit
+	default:
+		return ""
+	}
+}
+
+func (credentials *RepositoryCredentials) name() string {
+	if credentials == nil {
+		return ""
+	}
+
+	return credentials.Name
+}
+
+type RepositoryVcsChangeDetection struct {
+	PollingInterval             int    `json:"pollingInterval,omitempty"`
+	ChangesetFilterPatternRegex string `json:"changesetFilterPatternRegex,omitempty"`
+	FilterFilePatternOption     string `json:"filterFilePatternOption,omitempty"`
+	FilterFilePatternRegex      string `json:"filterFilePatternRegex,omitempty"`
+}
+
+// ChangeDetection returns the change detection settings of the repository, settings missing from Bamboo are reported with their default value.
+func (detail RepositoryDetail) ChangeDetection() RepositoryChangeDetection {
+	detection := DefaultRepositoryChangeDetection()
+	detection.ShallowClones = detail.UseShallowClones
+	detection.Submodules = detail.UseSubmodules
+	detection.Lfs = detail.UseLfs
+	if detail.WebhookEnabled != nil {
+		detection.WebhookEnabled = *detail.WebhookEnabled
+	}
+
+	if vcs := detail.VcsChangeDetection; vcs != nil {
+		if vcs.PollingInterval != 0 {
+			detection.PollingInterval = vcs.PollingInterval
+		}
+
+		detection.ExcludeCommitMessages = vcs.ChangesetFilterPatternRegex
+		switch vcs.FilterFilePatternOption {
+		case filterFilePatternIncludeOnly:
+			detection.IncludePaths = vcs.FilterFilePatternRegex
+		case filterFilePatternExcludeAll:
+			detection.ExcludePaths = vcs.FilterFilePatternRegex
+		}
+	}
+
+	return detection
+}
+
+const (
+	filterFilePatternNone        = "NONE"
+	filterFilePatternIncludeOnly = "INCLUDE_ONLY"
+	filterFilePatternExcludeAll  = "EXCLUDE_ALL"
+)
+
+// RepositoryChangeDetection holds how Bamboo detects and checks out changes of a linked repository, path and commit message filters are regular expressions.
+type RepositoryChangeDetection struct {
+	WebhookEnabled        bool
+	PollingInterval       int
+	IncludePaths          string
+	ExcludePaths          string
+	ExcludeCommitMessages string
+	ShallowClones         bool
+	Submodules            bool
+	Lfs                   bool
+}
+
+// DefaultRepositoryChangeDetection returns the settings Bamboo applies to a repository created without explicit change detection.
+func DefaultRepositoryChangeDetection() RepositoryChangeDetection {
+	return RepositoryChangeDetection{
+		WebhookEnabled:  true,
+		PollingInterval: defaultPollingInterval,
+	}
+}
+
+func (detection RepositoryChangeDetection) yaml() string {
+	option, pattern := filterFilePatternNone, ""
+	if detection.IncludePaths != "" {
+		option, pattern = filterFilePatternIncludeOnly, detection.IncludePaths
+	} else if detection.ExcludePaths != "" {
+		option, pattern = filterFilePatternExcludeAll, detection.ExcludePaths
+	}
+
+	var filters string
+	if pattern != "" {
+		filters += fmt.Sprintf(`
+    filterFilePatternRegex: %s`, strconv.Quote(pattern))
+	}
+
+	if detection.ExcludeCommitMessages != "" {
+		filters += fmt.Sprintf(`
+    changesetFilterPatternRegex: %s`, strconv.Quote(detection.ExcludeCommitMessages))
+	}
+
+	return fmt.Sprintf(`
+  useShallowClones: %t
+  useSubmodules: %t
+  useLfs: %t
+  webhookEnabled: %t
+  vcsChangeDetection:
+    pollingInterval: %d
+    filterFilePatternOption: %s%s`,
+		detection.ShallowClones,
+		detection.Submodules,
+		detection.Lfs,
+		detection.WebhookEnabled,
+		detection.PollingInterval,
+		option,
+		filters,
+	)
+}
+
+// RepositoryUsage lists the plans and deployments that check out a linked repository.
+type RepositoryUsage struct {
+	Plans       []RepositoryPlanUsage       `json:"plans"`
+	Deployments []RepositoryDeploymentUsage `json:"deployments"`
+}
+
+type RepositoryPlanUsage struct {
+	Key        string `json:"key"`
+	Name       string `json:"name"`
+	ProjectKey string `json:"projectKey"`
+}
+
+type RepositoryDeploymentUsage struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+func (usage RepositoryUsage) inUse() bool {
+	return len(usage.Plans) > 0 || len(usage.Deployments) > 0
+}
+
+type RepositoryServer struct {
+	ID   string `json:"id,omitempty"`
+	Name string `json:"name,omitempty"`
+}
+
+// RepositorySpec is a Bamboo Specs repository definition that can be imported into Bamboo.
+type RepositorySpec interface {
+	// Yaml renders the spec, oid is the identifier of an existing repository, or empty for a new one.
+	Yaml(oid string) string
+}
+
+// BitbucketServerRepositorySpec is a Bitbucket Server repository, Project is the owning Bamboo project key of a project linked repository.
+type BitbucketServerRepositorySpec struct {
+	Project        string
+	Name           string
+	ProjectKey     string
+	RepositorySlug string
+	Branch         string
+	ServerId       string
+	ServerName     string
+	CloneUrl       string
+
+	ChangeDetection RepositoryChangeDetection
+}
+
+func (spec BitbucketServerRepositorySpec) Yaml(oid string) string {
+	var project string
+	if spec.Project != "" {
+		project = fmt.Sprintf(`
+  project:
+    key: %s`, strconv.Quote(spec.Project))
+	}
+
+	return fmt.Sprintf(`
+!!com.atlassian.bamboo.specs.util.BambooSpecProperties
+rootEntity: !!com.atlassian.bamboo.specs.model.repository.bitbucket.server.BitbucketServerRepositoryProperties%s%s
+  name: %s
+  projectKey: %s
+  repositorySlug: %s
+  branch: %s
+  server:
+    id: %s
+    name: %s
+  sshCloneUrl: %s%s
+specModelVersion: 9.3.0
+`,
+		specOid(oid),
+		project,
+		strconv.Quote(spec.Name),
+		strconv.Quote(spec.ProjectKey),
+		strconv.Quote(spec.RepositorySlug),
+		strconv.Quote(spec.Branch),
+		strconv.Quote(spec.ServerId),
+		strconv.Quote(spec.ServerName),
+		strconv.Quote(spec.CloneUrl),
+		spec.ChangeDetection.yaml(),
+	)
+}
+
+// GitRepositorySpec is a plain Git repository, authenticated by a shared credential or an SSH private key.
+type GitRepositorySpec struct {
+	Name             string
+	Url              string
+	Branch           string
+	SharedCredential string
+	SshKey           string
+	SshPassphrase    string
+
+	ChangeDetection RepositoryChangeDetection
+}
+
+func (spec GitRepositorySpec) Yaml(oid string) string {
+	var authentication string
+	if spec.SharedCredential != "" {
+		authentication = fmt.Sprintf(`
+  authenticationProperties: !!com.atlassian.bamboo.specs.model.credentials.SharedCredentialsIdentifierProperties
+    name: %s`, strconv.Quote(spec.SharedCredential))
+	} else if spec.SshKey != "" {
+		authentication = fmt.Sprintf(`
+  authenticationProperties: !!com.atlassian.bamboo.specs.model.repository.git.SshPrivateKeyAuthenticationProperties
+    sshPrivateKey: %s
+    passphrase: %s`, strconv.Quote(spec.SshKey), strconv.Quote(spec.SshPassphrase))
+	}
+
+	return fmt.Sprintf(`
+!!com.atlassian.bamboo.specs.util.BambooSpecProperties
+rootEntity: !!com.atlassian.bamboo.specs.model.repository.git.GitRepositoryProperties%s
+  name: %s
+  url: %s
+  branch: %s%s%s
+specModelVersion: 9.3.0
+`,
+		specOid(oid),
+		strconv.Quote(spec.Name),
+		strconv.Quote(spec.Url),
+		strconv.Quote(spec.Branch),
+		authentication,
+		spec.ChangeDetection.yaml(),
+	)
+}
+
+// GitHubRepositorySpec is a GitHub repository, authenticated by a shared credential holding the access token.
+type GitHubRepositorySpec struct {
+	Name             string
+	Repository       string
+	Branch           string
+	SharedCredential string
+
+	ChangeDetection RepositoryChangeDetection
+}
+
+func (spec GitHubRepositorySpec) Yaml(oid string) string {
+	return fmt.Sprintf(`
+!!com.atlassian.bamboo.specs.util.BambooSpecProperties
+rootEntity: !!com.atlassian.bamboo.specs.model.repository.github.GitHubRepositoryProperties%s
+  name: %s
+  repository: %s
+  branch: %s
+  sharedCredentials: !!com.atlassian.bamboo.specs.model.credentials.SharedCredentialsIdentifierProperties
+    name: %s%s
+specModelVersion: 9.3.0
+`,
+		specOid(oid),
+		strconv.Quote(spec.Name),
+		strconv.Quote(spec.Repository),
+		strconv.Quote(spec.Branch),
+		strconv.Quote(spec.SharedCredential),
+		spec.ChangeDetection.yaml(),
+	)
+}
+
+func specOid(oid string) string {
+	if oid == "" {
+		return ""
+	}
+
+	return fmt.Sprintf(`
+  oid:
+    oid: %s`, oid)
+}
+
+type ExtendedRepositoryService struct {
+	transport transport.PayloadTransport
+}
+
+// Import creates or updates a linked repository from its spec, and returns the id of the repository.
+func (service *ExtendedRepositoryService) Import(spec RepositorySpec, oid string) (int, error) {
+	reply, err := service.transport.SendWithExpectedStatus(&transport.PayloadRequest{
+		Method: http.MethodPost,
+		Url:    repositoryImportEndpoint,
+		Payload: &bamboo.XYamlPayload{
+			Data: spec.Yaml(oid),
+		},
+	}, 200)
+	if err != nil {
+		return 0, err
+	}
+
+	parsedURL, err := url.Parse(reply.Body)
+	if err != nil {
+		return 0, err
+	}
+
+	repositoryId := parsedURL.Query().Get("repositoryId")
+	if strings.TrimSpace(repositoryId) == "" {
+		return 0, fmt.Errorf("repositoryId not found")
+	}
+
+	return strconv.Atoi(repositoryId)
+}
+
+// ReadOid retrieves the spec identifier of an existing linked repository, which is required to update it through Import.
+func (service *ExtendedRepositoryService) ReadOid(repositoryId int) (string, error) {
+	reply, err := service.transport.SendWithExpectedStatus(&transport.PayloadRequest{
+		Method: http.MethodPost,
+		Url:    fmt.Sprintf(repositoryExportEndpoint, repositoryId),
+	}, 200)
+	if err != nil {
+		return "", err
+	}
+
+	var export []string
+	err = reply.Object(&export)
+	if err != nil {
+		return "", err
+	}
+
+	if len(export) == 0 {
+		return "", fmt.Errorf("unable to find repository with id %d", repositoryId)
+	}
+
+	filename := filepath.Base(export[0])
+	return strings.TrimSuffix(filename, filepath.Ext(filename)), nil
+}
+
+// ReadDetail retrieves the configuration of a linked repository, it returns nil when the repository no longer exists.
+func (service *ExtendedRepositoryService) ReadDetail(repositoryId int) (*RepositoryDetail, error) {
+	reply, err := service.transport.SendWithExpectedStatus(&transport.PayloadRequest{
+		Method: http.MethodGet,
+		Url:    fmt.Sprintf(repositoryEndpoint, repositoryId),
+	}, 200, 404)
+	if err != nil {
+		return nil, err
+	}
+
+	if reply.StatusCode == 404 {
+		return nil, nil
+	}
+
+	detail := RepositoryDetail{}
+	err = reply.Object(&detail)
+	if err != nil {
+		return nil, err
+	}
+
+	return &detail, nil
+}
+
+// ReadSpecsState retrieves the latest Bamboo Specs scan of a linked repository, it returns nil when the repository was never scanned.
+func (service *ExtendedRepositoryService) ReadSpecsState(repositoryId int) (*RepositorySpecsState, error) {
+	reply, err := service.transport.SendWithExpectedStatus(&transport.PayloadRequest{
+		Method: http.MethodGet,
+		Url:    fmt.Sprintf(repositorySpecsEndpoint, repositoryId),
+	}, 200, 404)
+	if err != nil {
+		return nil, err
+	}
+
+	if reply.StatusCode == 404 {
+		return nil, nil
+	}
+
+	state := RepositorySpecsState{}
+	err = reply.Object(&state)
+	if err != nil {
+		return nil, err
+	}
+
+	return &state, nil
+}
+
+// ReadUsage retrieves the plans and deployments using a linked repository.
+func (service *ExtendedRepositoryService) ReadUsage(repositoryId int) (*RepositoryUsage, error) {
+	reply, err := service.transport.SendWithExpectedStatus(&transport.PayloadRequest{
+		Method: http.MethodGet,
+		Url:    fmt.Sprintf(repositoryUsageEndpoint, repositoryId),
+	}, 200)
+	if err != nil {
+		return nil, err
+	}
+
+	usage := RepositoryUsage{}
+	err = reply.Object(&usage)
+	if err != nil {
+		return nil, err
+	}
+
+	return &usage, nil
+}
+
+// Search pages through the global linked repositories whose name contains the search term, an empty term returns all of them.
+func (service *ExtendedRepositoryService) Search(searchTerm string) ([]bamboo.Repository, error) {
+	var repositories = make([]bamboo.Repository, 0)
+
+	for start := 0; ; {
+		reply, err := service.transport.SendWithExpectedStatus(&transport.PayloadRequest{
+			Method: http.MethodGet,
+			Url:    fmt.Sprintf(repositorySearchEndpoint, url.QueryEscape(searchTerm), start, repositorySearchPageSize),
+		}, 200)
+		if err != nil {
+			return nil, err
+		}
+
+		repositoryList := bamboo.RepositoryList{}
+		err = reply.Object(&repositoryList)
+		if err != nil {
+			return nil, err
+		}
+
+		repositories = append(repositories, repositoryList.Results...)
+		if lastPage(len(repositoryList.Results), repositoryList.MaxResult) {
+			break
+		}
+
+		start += len(repositoryList.Results)
+	}
+
+	return repositories, nil
+}
+
+// SearchProject pages through the linked repositories of a Bamboo project whose name contains the filter, an empty filter returns all of them.
+func (service *ExtendedRepositoryService) SearchProject(projectKey string, filter string) ([]bamboo.Repository, error) {
+	var repositories = make([]bamboo.Repository, 0)
+
+	for start := 0; ; {
+		reply, err := service.transport.SendWithExpectedStatus(&transport.PayloadRequest{
+			Method: http.MethodGet,
+			Url:    fmt.Sprintf(repositoryProjectEndpoint, url.PathEscape(projectKey), url.QueryEscape(filter), start, repositorySearchPageSize),
+		}, 200)
+		if err != nil {
+			return nil, err
+		}
+
+		repositoryList := bamboo.ProjectRepositoryList{}
+		err = reply.Object(&repositoryList)
+		if err != nil {
+			return nil, err
+		}
+
+		repositories = append(repositories, repositoryList.Results...)
+		if lastPage(len(repositoryList.Results), repositoryList.MaxResult) {
+			break
+		}
+
+		start += len(repositoryList.Results)
+	}
+
+	return repositories, nil
+}
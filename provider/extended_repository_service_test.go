@@ -0,0 +1,122 @@
+package provider
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/yunarta/terraform-provider-bamboo/provider/test"
+)
+
+func TestRepositorySpec_Yaml(t *testing.T) {
+	tests := []struct {
+		name     string
+		spec     RepositorySpec
+		oid      string
+		contains []string
+		excludes []string
+	}{
+		{
+			name: "git with shared credential",
+			spec: GitRepositorySpec{Name: "app", Url: "https://git.example.com/app.git", Branch: "main", SharedCredential: "git-user"},
+			contains: []string{
+				"model.repository.git.GitRepositoryProperties",
+				`url: "https://git.example.com/app.git"`,
+				`branch: "main"`,
+				"SharedCredentialsIdentifierProperties",
+				`name: "git-user"`,
+			},
+			excludes: []string{"oid:", "sshPrivateKey"},
+		},
+		{
+			name: "git with ssh key",
+			spec: GitRepositorySpec{Name: "app", Url: "ssh://git@git.example.com/app.git", Branch: "main", SshKey: "-----BEGIN KEY-----\nabc\n-----END KEY-----"},
+			oid:  "abc123",
+			contains: []string{
+				"oid: abc123",
+				"SshPrivateKeyAuthenticationProperties",
+				`sshPrivateKey: "-----BEGIN KEY-----\nabc\n-----END KEY-----"`,
+			},
+			excludes: []string{"SharedCredentialsIdentifierProperties"},
+		},
+		{
+			name: "github",
+			spec: GitHubRepositorySpec{Name: "app", Repository: "owner/app", Branch: "main", SharedCredential: "github-token", ChangeDetection: DefaultRepositoryChangeDetection()},
+			contains: []string{
+				"model.repository.github.GitHubRepositoryProperties",
+				`repository: "owner/app"`,
+				`name: "github-token"`,
+				"webhookEnabled: true",
+				"filterFilePatternOption: NONE",
+			},
+			excludes: []string{"filterFilePatternRegex", "changesetFilterPatternRegex"},
+		},
+		{
+			name: "bitbucket server with change detection",
+			spec: BitbucketServerRepositorySpec{Name: "app", ProjectKey: "PROJ", RepositorySlug: "app", Branch: "main", ChangeDetection: RepositoryChangeDetection{
+				PollingInterval:       60,
+				IncludePaths:          "src/.*",
+				ExcludeCommitMessages: ".*WIP.*",
+				Submodules:            true,
+			}},
+			contains: []string{
+				"webhookEnabled: false",
+				"pollingInterval: 60",
+				"filterFilePatternOption: INCLUDE_ONLY",
+				`filterFilePatternRegex: "src/.*"`,
+				`changesetFilterPatternRegex: ".*WIP.*"`,
+				"useSubmodules: true",
+				"useLfs: false",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			yaml := tt.spec.Yaml(tt.oid)
+			for _, expected := range tt.contains {
+				if !strings.Contains(yaml, expected) {
+					t.Errorf("Yaml() does not contain %q\n%s", expected, yaml)
+				}
+			}
+
+			for _, unexpected := range tt.excludes {
+				if strings.Contains(yaml, unexpected) {
+					t.Errorf("Yaml() contains %q\n%s", unexpected, yaml)
+				}
+			}
+		})
+	}
+}
+
+func TestExtendedRepositoryService_SearchLimitedPageSize(t *testing.T) {
+	virtualization := test.NewServiceVirtualization()
+	virtualization.LimitPageSize(25)
+	for id := 1; id <= 60; id++ {
+		virtualization.AddRepository(test.Repository{ID: id, Name: fmt.Sprintf("app-%02d", id)})
+		virtualization.AddRepository(test.Repository{ID: 100 + id, Name: fmt.Sprintf("app-%02d", id), Spec: map[string]any{
+			"project": map[string]any{"key": "BAM"},
+		}})
+	}
+
+	// the server replies with 25 results per page, the last repository is on the third page
+	for name, search := range map[string]func() (int, error){
+		"Search": func() (int, error) {
+			repositories, err := NewExtendedClient(virtualization).RepositoryService().Search("app")
+			return len(repositories), err
+		},
+		"SearchProject": func() (int, error) {
+			repositories, err := NewExtendedClient(virtualization).RepositoryService().SearchProject("BAM", "app")
+			return len(repositories), err
+		},
+	} {
+		got, err := search()
+		if err != nil {
+			t.Fatalf("%s() error = %v", name, err)
+		}
+
+		if got != 60 {
+			t.Errorf("%s() = %d repositories, want 60", name, got)
+		}
+	}
+}
@@ -5,24 +5,67 @@ import (
 	"fmt"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"net/url"
+	"reflect"
 	"strconv"
+	"strings"
 )
 
 type LinkedRepositoryModel struct {
-	ID         types.String `tfsdk:"id"`
-	Name       types.String `tfsdk:"name"`
-	RssEnabled types.Bool   `tfsdk:"rss_enabled"`
+	ID            types.String `tfsdk:"id"`
+	Name          types.String `tfsdk:"name"`
+	RssEnabled    types.Bool   `tfsdk:"rss_enabled"`
+	AdoptExisting types.Bool   `tfsdk:"adopt_existing"`
+
+	RetainOnDelete types.Bool `tfsdk:"retain_on_delete"`
+	ForceDelete    types.Bool `tfsdk:"force_delete"`
+
+	WaitForSpecsScan     types.Bool   `tfsdk:"wait_for_specs_scan"`
+	SpecsScanTimeout     types.Int64  `tfsdk:"specs_scan_timeout"`
+	FailOnSpecsScanError types.Bool   `tfsdk:"fail_on_specs_scan_error"`
+	LastSpecsScanResult  types.String `tfsdk:"last_specs_scan_result"`
+	LastSpecsScanTime    types.String `tfsdk:"last_specs_scan_time"`
+	Type                 types.String `tfsdk:"type"`
 
 	Project types.String `tfsdk:"project"`
 	Slug    types.String `tfsdk:"slug"`
 	Branch  types.String `tfsdk:"branch"`
 
+	Git    *LinkedRepositoryGitModel    `tfsdk:"git"`
+	GitHub *LinkedRepositoryGitHubModel `tfsdk:"github"`
+	GitLab *LinkedRepositoryGitLabModel `tfsdk:"gitlab"`
+
+	ChangeDetection *ChangeDetectionModel `tfsdk:"change_detection"`
+
 	AssignmentVersion types.String `tfsdk:"assignment_version"`
+	Authoritative     types.Bool   `tfsdk:"authoritative"`
+	IgnorePrincipals  types.List   `tfsdk:"ignore_principals"`
+	MergeStrategy     types.String `tfsdk:"merge_strategy"`
+	StrictPrincipals  types.Bool   `tfsdk:"strict_principals"`
 	Assignments       types.List   `tfsdk:"assignments"`
+	Roles             *RolesModel  `tfsdk:"roles"`
 	ComputedUsers     types.List   `tfsdk:"computed_users"`
 	ComputedGroups    types.List   `tfsdk:"computed_groups"`
 }
 
+type LinkedRepositoryGitModel struct {
+	Url              types.String `tfsdk:"url"`
+	SharedCredential types.String `tfsdk:"shared_credential"`
+	SshKey           types.String `tfsdk:"ssh_key"`
+	SshPassphrase    types.String `tfsdk:"ssh_passphrase"`
+}
+
+type LinkedRepositoryGitHubModel struct {
+	Repository      types.String `tfsdk:"repository"`
+	TokenCredential types.String `tfsdk:"token_credential"`
+}
+
+type LinkedRepositoryGitLabModel struct {
+	Url             types.String `tfsdk:"url"`
+	Project         types.String `tfsdk:"project"`
+	TokenCredential types.String `tfsdk:"token_credential"`
+}
+
 var _ LinkedRepositoryPermissionInterface = &LinkedRepositoryModel{}
 
 func (d LinkedRepositoryModel) getAssignment(ctx context.Context) (Assignments, diag.Diagnostics) {
@@ -32,6 +75,18 @@ func (d LinkedRepositoryModel) getAssignment(ctx context.Context) (Assignments,
 	return assignments, diags
 }
 
+func (d LinkedRepositoryModel) getAssignmentOptions(ctx context.Context) (AssignmentOptions, diag.Diagnostics) {
+	return NewAssignmentOptions(ctx, d.Authoritative, d.IgnorePrincipals, d.MergeStrategy, d.StrictPrincipals)
+}
+
+func (d LinkedRepositoryModel) getRoles(ctx context.Context) *RolesModel {
+	return d.Roles
+}
+
+func (d LinkedRepositoryModel) specsScanSettings() SpecsScanSettings {
+	return NewSpecsScanSettings(d.RssEnabled, d.WaitForSpecsScan, d.SpecsScanTimeout, d.FailOnSpecsScanError)
+}
+
 func (d LinkedRepositoryModel) getLinkedRepositoryId(ctx context.Context) int {
 	deploymentId, _ := strconv.Atoi(d.ID.ValueString())
 	return deploymentId
@@ -39,15 +94,194 @@ func (d LinkedRepositoryModel) getLinkedRepositoryId(ctx context.Context) int {
 
 func NewLinkedRepositoryModel(plan LinkedRepositoryModel, repositoryId int, assignmentResult *AssignmentResult) *LinkedRepositoryModel {
 	return &LinkedRepositoryModel{
-		ID:                types.StringValue(fmt.Sprintf("%v", repositoryId)),
-		Name:              plan.Name,
-		RssEnabled:        plan.RssEnabled,
-		Project:           plan.Project,
-		Slug:              plan.Slug,
-		Branch:            plan.Branch,
-		AssignmentVersion: plan.AssignmentVersion,
-		Assignments:       plan.Assignments,
-		ComputedUsers:     assignmentResult.ComputedUsers,
-		ComputedGroups:    assignmentResult.ComputedGroups,
+		ID:                   types.StringValue(fmt.Sprintf("%v", repositoryId)),
+		Name:                 plan.Name,
+		AdoptExisting:        plan.AdoptExisting,
+		RetainOnDelete:       plan.RetainOnDelete,
+		ForceDelete:          plan.ForceDelete,
+		WaitForSpecsScan:     plan.WaitForSpecsScan,
+		SpecsScanTimeout:     plan.SpecsScanTimeout,
+		FailOnSpecsScanError: plan.FailOnSpecsScanError,
+		LastSpecsScanResult:  plan.LastSpecsScanResult,
+		LastSpecsScanTime:    plan.LastSpecsScanTime,
+		Type:                 plan.Type,
+		RssEnabled:           plan.RssEnabled,
+		Project:              plan.Project,
+		Slug:                 plan.Slug,
+		Branch:               plan.Branch,
+		Git:                  plan.Git,
+		GitHub:               plan.GitHub,
+		GitLab:               plan.GitLab,
+		ChangeDetection:      plan.ChangeDetection,
+		AssignmentVersion:    plan.AssignmentVersion,
+		Authoritative:        assignmentAuthoritative(plan.Authoritative),
+		IgnorePrincipals:     plan.IgnorePrincipals,
+		MergeStrategy:        assignmentMergeStrategy(plan.MergeStrategy),
+		StrictPrincipals:     assignmentStrictPrincipals(plan.StrictPrincipals),
+		Assignments:          plan.Assignments,
+		Roles:                assignmentResult.Roles,
+		ComputedUsers:        assignmentResult.ComputedUsers,
+		ComputedGroups:       assignmentResult.ComputedGroups,
+	}
+}
+
+func (d LinkedRepositoryModel) getType() string {
+	if d.Type.IsNull() || d.Type.ValueString() == "" {
+		return linkedRepositoryTypeBitbucketServer
+	}
+
+	return d.Type.ValueString()
+}
+
+// sameLocation reports whether both models point to the same source repository with the same change detection.
+func (d LinkedRepositoryModel) sameLocation(other LinkedRepositoryModel) bool {
+	return d.getType() == other.getType() &&
+		d.Project.Equal(other.Project) &&
+		d.Slug.Equal(other.Slug) &&
+		d.Branch.Equal(other.Branch) &&
+		reflect.DeepEqual(d.Git, other.Git) &&
+		reflect.DeepEqual(d.GitHub, other.GitHub) &&
+		reflect.DeepEqual(d.GitLab, other.GitLab) &&
+		d.ChangeDetection.settings() == other.ChangeDetection.settings()
+}
+
+const defaultGitLabUrl = "https://gitlab.com"
+
+// cloneUrl returns the URL Bamboo clones the GitLab project from.
+func (d LinkedRepositoryGitLabModel) cloneUrl() string {
+	serverUrl := defaultGitLabUrl
+	if !d.Url.IsNull() {
+		serverUrl = strings.TrimSuffix(d.Url.ValueString(), "/")
 	}
+
+	return fmt.Sprintf("%s/%s.git", serverUrl, d.Project.ValueString())
+}
+
+// refreshLocation sets the type and the source repository read from Bamboo, and fills the block of the type when the
+// repository was imported. Secrets are not reported by Bamboo, so the SSH key is kept as known.
+func (d *LinkedRepositoryModel) refreshLocation(detail RepositoryDetail, ignoreCase bool) {
+	remoteType := detail.Type()
+	if remoteType == "" || (remoteType == linkedRepositoryTypeGit && d.getType() == linkedRepositoryTypeGitLab) {
+		// Bamboo has no GitLab repository, the project is kept as known while it is linked as a Git repository
+		remoteType = d.getType()
+	}
+
+	if remoteType != d.getType() {
+		// the repository was imported, or its type changed outside of Terraform
+		d.Type = types.StringValue(remoteType)
+		d.Project, d.Slug = types.StringNull(), types.StringNull()
+		d.Git, d.GitHub, d.GitLab = nil, nil, nil
+	}
+
+	switch remoteType {
+	case linkedRepositoryTypeBitbucketServer:
+		d.Project = refreshString(d.Project, detail.ProjectKey, ignoreCase)
+		d.Slug = refreshString(d.Slug, detail.Slug, ignoreCase)
+	case linkedRepositoryTypeGit:
+		if d.Git == nil {
+			d.Git = &LinkedRepositoryGitModel{
+				Url:              types.StringNull(),
+				SharedCredential: types.StringNull(),
+				SshKey:           types.StringNull(),
+				SshPassphrase:    types.StringNull(),
+			}
+		}
+
+		d.Git.Url = refreshString(d.Git.Url, detail.Url, false)
+		d.Git.SharedCredential = refreshString(d.Git.SharedCredential, detail.AuthenticationProperties.name(), false)
+	case linkedRepositoryTypeGitHub:
+		if d.GitHub == nil {
+			d.GitHub = &LinkedRepositoryGitHubModel{
+				Repository:      types.StringNull(),
+				TokenCredential: types.StringNull(),
+			}
+		}
+
+		d.GitHub.Repository = refreshString(d.GitHub.Repository, detail.Repository, false)
+		d.GitHub.TokenCredential = refreshString(d.GitHub.TokenCredential, detail.SharedCredentials.name(), false)
+	case linkedRepositoryTypeGitLab:
+		if d.GitLab == nil {
+			// unreachable on import, a GitLab project reads back as a Git repository
+			return
+		}
+
+		d.GitLab.refreshUrl(detail.Url)
+		d.GitLab.TokenCredential = refreshString(d.GitLab.TokenCredential, detail.AuthenticationProperties.name(), false)
+	}
+}
+
+// refreshUrl sets the server and the project from the clone URL read from Bamboo, when it moved to another project or server.
+func (d *LinkedRepositoryGitLabModel) refreshUrl(remote string) {
+	if remote == "" || remote == d.cloneUrl() {
+		return
+	}
+
+	parsed, err := url.Parse(remote)
+	if err != nil || parsed.Host == "" {
+		return
+	}
+
+	serverUrl := fmt.Sprintf("%s://%s", parsed.Scheme, parsed.Host)
+	if !d.Url.IsNull() || serverUrl != defaultGitLabUrl {
+		d.Url = refreshString(d.Url, serverUrl, false)
+	}
+
+	d.Project = refreshString(d.Project, strings.TrimSuffix(strings.TrimPrefix(parsed.Path, "/"), ".git"), false)
+}
+
+// NewBitbucketServerRepositorySpec creates the spec of a Bitbucket Server repository, applying the normalisation configured for the server.
+func NewBitbucketServerRepositorySpec(server BambooRss, owner string, name string, project string, slug string, branch string, changeDetection RepositoryChangeDetection) BitbucketServerRepositorySpec {
+	if server.Lowercase.ValueBool() {
+		project = strings.ToLower(project)
+		slug = strings.ToLower(slug)
+	}
+
+	return BitbucketServerRepositorySpec{
+		Project:        owner,
+		Name:           name,
+		ProjectKey:     project,
+		RepositorySlug: slug,
+		Branch:         branch,
+		ServerId:       server.Server.ValueString(),
+		ServerName:     server.Name.ValueString(),
+		CloneUrl:       fmt.Sprintf(server.CloneUrl.ValueString(), project, slug),
+
+		ChangeDetection: changeDetection,
+	}
+}
+
+// describeRepositoryUsage lists the plans and deployments using a repository, one per line.
+func describeRepositoryUsage(usage RepositoryUsage) string {
+	var lines []string
+	for _, plan := range usage.Plans {
+		lines = append(lines, fmt.Sprintf("- plan %s (%s)", plan.Key, plan.Name))
+	}
+
+	for _, deployment := range usage.Deployments {
+		lines = append(lines, fmt.Sprintf("- deployment %d (%s)", deployment.ID, deployment.Name))
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// refreshString returns the value read from Bamboo, with ignoreCase the known value is kept when both only differ by case.
+func refreshString(known types.String, remote string, ignoreCase bool) types.String {
+	if remote == "" {
+		return known
+	}
+
+	if known.ValueString() == remote || (ignoreCase && strings.EqualFold(known.ValueString(), remote)) {
+		return known
+	}
+
+	return types.StringValue(remote)
+}
+
+// refreshBool returns the flag read from Bamboo, keeping an unset flag unset while Bamboo has it disabled.
+func refreshBool(known types.Bool, remote bool) types.Bool {
+	if known.IsNull() && !remote {
+		return known
+	}
+
+	return types.BoolValue(remote)
 }
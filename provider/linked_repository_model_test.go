@@ -0,0 +1,53 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestRefreshString(t *testing.T) {
+	tests := []struct {
+		name       string
+		known      types.String
+		remote     string
+		ignoreCase bool
+		want       types.String
+	}{
+		{name: "unchanged", known: types.StringValue("PROJ"), remote: "PROJ", want: types.StringValue("PROJ")},
+		{name: "case only", known: types.StringValue("PROJ"), remote: "proj", want: types.StringValue("proj")},
+		{name: "case only ignored", known: types.StringValue("PROJ"), remote: "proj", ignoreCase: true, want: types.StringValue("PROJ")},
+		{name: "drifted", known: types.StringValue("PROJ"), remote: "other", ignoreCase: true, want: types.StringValue("other")},
+		{name: "imported", known: types.StringNull(), remote: "proj", want: types.StringValue("proj")},
+		{name: "not reported", known: types.StringValue("main"), remote: "", want: types.StringValue("main")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := refreshString(tt.known, tt.remote, tt.ignoreCase); !got.Equal(tt.want) {
+				t.Errorf("refreshString() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRefreshBool(t *testing.T) {
+	tests := []struct {
+		name   string
+		known  types.Bool
+		remote bool
+		want   types.Bool
+	}{
+		{name: "unset and disabled", known: types.BoolNull(), remote: false, want: types.BoolNull()},
+		{name: "unset and enabled", known: types.BoolNull(), remote: true, want: types.BoolValue(true)},
+		{name: "drifted", known: types.BoolValue(true), remote: false, want: types.BoolValue(false)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := refreshBool(tt.known, tt.remote); !got.Equal(tt.want) {
+				t.Errorf("refreshBool() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
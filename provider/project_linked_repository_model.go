@@ -9,17 +9,34 @@ import (
 )
 
 type ProjectLinkedRepositoryModel struct {
-	ID         types.String `tfsdk:"id"`
-	Name       types.String `tfsdk:"name"`
-	RssEnabled types.Bool   `tfsdk:"rss_enabled"`
+	ID            types.String `tfsdk:"id"`
+	Name          types.String `tfsdk:"name"`
+	RssEnabled    types.Bool   `tfsdk:"rss_enabled"`
+	AdoptExisting types.Bool   `tfsdk:"adopt_existing"`
+
+	RetainOnDelete types.Bool `tfsdk:"retain_on_delete"`
+	ForceDelete    types.Bool `tfsdk:"force_delete"`
+
+	WaitForSpecsScan     types.Bool   `tfsdk:"wait_for_specs_scan"`
+	SpecsScanTimeout     types.Int64  `tfsdk:"specs_scan_timeout"`
+	FailOnSpecsScanError types.Bool   `tfsdk:"fail_on_specs_scan_error"`
+	LastSpecsScanResult  types.String `tfsdk:"last_specs_scan_result"`
+	LastSpecsScanTime    types.String `tfsdk:"last_specs_scan_time"`
 
 	Key     types.String `tfsdk:"key"`
 	Project types.String `tfsdk:"project"`
 	Slug    types.String `tfsdk:"slug"`
 	Branch  types.String `tfsdk:"branch"`
 
+	ChangeDetection *ChangeDetectionModel `tfsdk:"change_detection"`
+
 	AssignmentVersion types.String `tfsdk:"assignment_version"`
+	Authoritative     types.Bool   `tfsdk:"authoritative"`
+	IgnorePrincipals  types.List   `tfsdk:"ignore_principals"`
+	MergeStrategy     types.String `tfsdk:"merge_strategy"`
+	StrictPrincipals  types.Bool   `tfsdk:"strict_principals"`
 	Assignments       types.List   `tfsdk:"assignments"`
+	Roles             *RolesModel  `tfsdk:"roles"`
 	ComputedUsers     types.List   `tfsdk:"computed_users"`
 	ComputedGroups    types.List   `tfsdk:"computed_groups"`
 }
@@ -33,6 +50,18 @@ func (d ProjectLinkedRepositoryModel) getAssignment(ctx context.Context) (Assign
 	return assignments, diags
 }
 
+func (d ProjectLinkedRepositoryModel) getAssignmentOptions(ctx context.Context) (AssignmentOptions, diag.Diagnostics) {
+	return NewAssignmentOptions(ctx, d.Authoritative, d.IgnorePrincipals, d.MergeStrategy, d.StrictPrincipals)
+}
+
+func (d ProjectLinkedRepositoryModel) getRoles(ctx context.Context) *RolesModel {
+	return d.Roles
+}
+
+func (d ProjectLinkedRepositoryModel) specsScanSettings() SpecsScanSettings {
+	return NewSpecsScanSettings(d.RssEnabled, d.WaitForSpecsScan, d.SpecsScanTimeout, d.FailOnSpecsScanError)
+}
+
 func (d ProjectLinkedRepositoryModel) getLinkedRepositoryId(ctx context.Context) int {
 	deploymentId, _ := strconv.Atoi(d.ID.ValueString())
 	return deploymentId
@@ -40,16 +69,30 @@ func (d ProjectLinkedRepositoryModel) getLinkedRepositoryId(ctx context.Context)
 
 func NewProjectLinkedRepositoryModel(plan ProjectLinkedRepositoryModel, repositoryId int, assignmentResult *AssignmentResult) *ProjectLinkedRepositoryModel {
 	return &ProjectLinkedRepositoryModel{
-		ID:                types.StringValue(fmt.Sprintf("%v", repositoryId)),
-		Key:               plan.Key,
-		Name:              plan.Name,
-		RssEnabled:        plan.RssEnabled,
-		Project:           plan.Project,
-		Slug:              plan.Slug,
-		Branch:            plan.Branch,
-		AssignmentVersion: plan.AssignmentVersion,
-		Assignments:       plan.Assignments,
-		ComputedUsers:     assignmentResult.ComputedUsers,
-		ComputedGroups:    assignmentResult.ComputedGroups,
+		ID:                   types.StringValue(fmt.Sprintf("%v", repositoryId)),
+		Key:                  plan.Key,
+		Name:                 plan.Name,
+		AdoptExisting:        plan.AdoptExisting,
+		RetainOnDelete:       plan.RetainOnDelete,
+		ForceDelete:          plan.ForceDelete,
+		WaitForSpecsScan:     plan.WaitForSpecsScan,
+		SpecsScanTimeout:     plan.SpecsScanTimeout,
+		FailOnSpecsScanError: plan.FailOnSpecsScanError,
+		LastSpecsScanResult:  plan.LastSpecsScanResult,
+		LastSpecsScanTime:    plan.LastSpecsScanTime,
+		RssEnabled:           plan.RssEnabled,
+		Project:              plan.Project,
+		Slug:                 plan.Slug,
+		Branch:               plan.Branch,
+		ChangeDetection:      plan.ChangeDetection,
+		AssignmentVersion:    plan.AssignmentVersion,
+		Authoritative:        assignmentAuthoritative(plan.Authoritative),
+		IgnorePrincipals:     plan.IgnorePrincipals,
+		MergeStrategy:        assignmentMergeStrategy(plan.MergeStrategy),
+		StrictPrincipals:     assignmentStrictPrincipals(plan.StrictPrincipals),
+		Assignments:          plan.Assignments,
+		Roles:                assignmentResult.Roles,
+		ComputedUsers:        assignmentResult.ComputedUsers,
+		ComputedGroups:       assignmentResult.ComputedGroups,
 	}
 }
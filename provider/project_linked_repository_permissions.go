@@ -2,6 +2,7 @@ package provider
 
 import (
 	"context"
+	"fmt"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/yunarta/terraform-atlassian-api-client/bamboo"
 )
@@ -12,6 +13,8 @@ type ProjectLinkedRepositoryPermissionReceiver interface {
 
 type ProjectLinkedRepositoryPermissionInterface interface {
 	getAssignment(ctx context.Context) (Assignments, diag.Diagnostics)
+	getAssignmentOptions(ctx context.Context) (AssignmentOptions, diag.Diagnostics)
+	getRoles(ctx context.Context) *RolesModel
 	getLinkedRepositoryId(ctx context.Context) int
 }
 
@@ -21,17 +24,29 @@ func CreateProjectLinkedRepositoryAssignments(ctx context.Context, receiver Proj
 		return nil, diags
 	}
 
-	assignmentOrder, diags := assignments.CreateAssignmentOrder(ctx)
+	options, diags := plan.getAssignmentOptions(ctx)
+	if diags != nil {
+		return nil, diags
+	}
+
+	assignmentOrder, diags := assignments.CreateAssignmentOrder(ctx, options.MergeStrategy)
 	if diags != nil {
 		return nil, diags
 	}
 
 	repositoryId := plan.getLinkedRepositoryId(ctx)
+	options.Target = fmt.Sprintf("linked repository %d", repositoryId)
 
-	_ = receiver.getClient().RepositoryService().UpdateRolePermissions(repositoryId, "LOGGED_IN", make([]string, 0))
+	diags = ApplyRoles(ctx, plan.getRoles(ctx), repositoryRoles, options.Target, func(role string, requestedPermissions []string) error {
+		return receiver.getClient().RepositoryService().UpdateRolePermissions(repositoryId, role, requestedPermissions)
+	})
+	if diags != nil {
+		return nil, diags
+	}
 
-	return ApplyNewAssignmentSet(ctx, receiver.getClient().UserService(),
+	result, diags := ApplyNewAssignmentSet(ctx, receiver.getClient().UserService(),
 		*assignmentOrder,
+		options,
 		func(user string) (*bamboo.UserPermission, error) {
 			return receiver.getClient().RepositoryService().FindAvailableUser(repositoryId, user)
 		},
@@ -45,6 +60,27 @@ func CreateProjectLinkedRepositoryAssignments(ctx context.Context, receiver Proj
 			return receiver.getClient().RepositoryService().UpdateGroupPermissions(repositoryId, group, requestedPermissions)
 		},
 	)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	revokeDiags := RevokeUnmanagedAssignment(*assignmentOrder, options,
+		func() (*bamboo.ObjectPermission, error) {
+			return receiver.getClient().RepositoryService().ReadPermissions(repositoryId)
+		},
+		func(user string, requestedPermissions []string) error {
+			return receiver.getClient().RepositoryService().UpdateUserPermissions(repositoryId, user, requestedPermissions)
+		},
+		func(group string, requestedPermissions []string) error {
+			return receiver.getClient().RepositoryService().UpdateGroupPermissions(repositoryId, group, requestedPermissions)
+		},
+	)
+	if revokeDiags != nil {
+		return nil, append(diags, revokeDiags...)
+	}
+
+	result.Roles = plan.getRoles(ctx)
+	return result, diags
 }
 
 func ComputeProjectLinkedRepositoryAssignments(ctx context.Context, receiver ProjectLinkedRepositoryPermissionReceiver, state ProjectLinkedRepositoryPermissionInterface) (*AssignmentResult, diag.Diagnostics) {
@@ -53,7 +89,12 @@ func ComputeProjectLinkedRepositoryAssignments(ctx context.Context, receiver Pro
 		return nil, diags
 	}
 
-	assignmentOrder, diags := assignments.CreateAssignmentOrder(ctx)
+	options, diags := state.getAssignmentOptions(ctx)
+	if diags != nil {
+		return nil, diags
+	}
+
+	assignmentOrder, diags := assignments.CreateAssignmentOrder(ctx, options.MergeStrategy)
 	if diags != nil {
 		return nil, diags
 	}
@@ -64,7 +105,24 @@ func ComputeProjectLinkedRepositoryAssignments(ctx context.Context, receiver Pro
 		return nil, []diag.Diagnostic{diag.NewErrorDiagnostic("Failed to read deployment permissions", err.Error())}
 	}
 
-	return ComputeAssignment(ctx, assignedPermissions, *assignmentOrder)
+	result, diags := ComputeAssignment(ctx, assignedPermissions, *assignmentOrder, options,
+		func(user string) (*bamboo.UserPermission, error) {
+			return receiver.getClient().RepositoryService().FindAvailableUser(repositoryId, user)
+		},
+		func(group string) (*bamboo.GroupPermission, error) {
+			return receiver.getClient().RepositoryService().FindAvailableGroup(repositoryId, group)
+		},
+	)
+	if diags != nil {
+		return nil, diags
+	}
+
+	result.Roles, diags = ComputeRoles(ctx, state.getRoles(ctx), assignedPermissions.Roles, repositoryRoles)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	return result, nil
 }
 
 func UpdateProjectLinkedRepositoryAssignments(ctx context.Context, receiver ProjectLinkedRepositoryPermissionReceiver,
@@ -77,28 +135,40 @@ func UpdateProjectLinkedRepositoryAssignments(ctx context.Context, receiver Proj
 		return nil, diags
 	}
 
+	options, diags := plan.getAssignmentOptions(ctx)
+	if diags != nil {
+		return nil, diags
+	}
+
 	inStateAssignments, diags := state.getAssignment(ctx)
 	if diags != nil {
 		return nil, diags
 	}
 
-	plannedAssignmentOrder, diags := plannedAssignments.CreateAssignmentOrder(ctx)
+	inStateOptions, diags := state.getAssignmentOptions(ctx)
+	if diags != nil {
+		return nil, diags
+	}
+
+	plannedAssignmentOrder, diags := plannedAssignments.CreateAssignmentOrder(ctx, options.MergeStrategy)
 	if diags != nil {
 		return nil, diags
 	}
 
-	inStateAssignmentOrder, diags := inStateAssignments.CreateAssignmentOrder(ctx)
+	inStateAssignmentOrder, diags := inStateAssignments.CreateAssignmentOrder(ctx, inStateOptions.MergeStrategy)
 	if diags != nil {
 		return nil, diags
 	}
 
 	// the plan does not have computed value deployment ID
 	repositoryId := state.getLinkedRepositoryId(ctx)
+	options.Target = fmt.Sprintf("linked repository %d", repositoryId)
 
-	return UpdateAssignment(ctx, receiver.getClient().UserService(),
+	result, diags := UpdateAssignment(ctx, receiver.getClient().UserService(),
 		*inStateAssignmentOrder,
 		*plannedAssignmentOrder,
 		forceUpdate,
+		options,
 		func(user string) (*bamboo.UserPermission, error) {
 			return receiver.getClient().RepositoryService().FindAvailableUser(repositoryId, user)
 		},
@@ -112,6 +182,34 @@ func UpdateProjectLinkedRepositoryAssignments(ctx context.Context, receiver Proj
 			return receiver.getClient().RepositoryService().UpdateGroupPermissions(repositoryId, group, requestedPermissions)
 		},
 	)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	revokeDiags := RevokeUnmanagedAssignment(*plannedAssignmentOrder, options,
+		func() (*bamboo.ObjectPermission, error) {
+			return receiver.getClient().RepositoryService().ReadPermissions(repositoryId)
+		},
+		func(user string, requestedPermissions []string) error {
+			return receiver.getClient().RepositoryService().UpdateUserPermissions(repositoryId, user, requestedPermissions)
+		},
+		func(group string, requestedPermissions []string) error {
+			return receiver.getClient().RepositoryService().UpdateGroupPermissions(repositoryId, group, requestedPermissions)
+		},
+	)
+	if revokeDiags != nil {
+		return nil, append(diags, revokeDiags...)
+	}
+
+	roleDiags := ApplyRoles(ctx, plan.getRoles(ctx), repositoryRoles, options.Target, func(role string, requestedPermissions []string) error {
+		return receiver.getClient().RepositoryService().UpdateRolePermissions(repositoryId, role, requestedPermissions)
+	})
+	if roleDiags != nil {
+		return nil, append(diags, roleDiags...)
+	}
+
+	result.Roles = plan.getRoles(ctx)
+	return result, diags
 }
 
 func DeleteProjectLinkedRepositoryAssignments(ctx context.Context, receiver ProjectLinkedRepositoryPermissionReceiver, state ProjectLinkedRepositoryPermissionInterface) diag.Diagnostics {
@@ -120,7 +218,12 @@ func DeleteProjectLinkedRepositoryAssignments(ctx context.Context, receiver Proj
 		return diags
 	}
 
-	assignmentOrder, diags := assignments.CreateAssignmentOrder(ctx)
+	options, diags := state.getAssignmentOptions(ctx)
+	if diags != nil {
+		return diags
+	}
+
+	assignmentOrder, diags := assignments.CreateAssignmentOrder(ctx, options.MergeStrategy)
 	if diags != nil {
 		return diags
 	}
@@ -13,7 +13,12 @@ type ProjectModel struct {
 	Name              types.String `tfsdk:"name"`
 	Description       types.String `tfsdk:"description"`
 	AssignmentVersion types.String `tfsdk:"assignment_version"`
+	Authoritative     types.Bool   `tfsdk:"authoritative"`
+	IgnorePrincipals  types.List   `tfsdk:"ignore_principals"`
+	MergeStrategy     types.String `tfsdk:"merge_strategy"`
+	StrictPrincipals  types.Bool   `tfsdk:"strict_principals"`
 	Assignments       types.List   `tfsdk:"assignments"`
+	Roles             *RolesModel  `tfsdk:"roles"`
 	ComputedUsers     types.List   `tfsdk:"computed_users"`
 	ComputedGroups    types.List   `tfsdk:"computed_groups"`
 }
@@ -27,6 +32,14 @@ func (d ProjectModel) getAssignment(ctx context.Context) (Assignments, diag.Diag
 	return assignments, diags
 }
 
+func (d ProjectModel) getAssignmentOptions(ctx context.Context) (AssignmentOptions, diag.Diagnostics) {
+	return NewAssignmentOptions(ctx, d.Authoritative, d.IgnorePrincipals, d.MergeStrategy, d.StrictPrincipals)
+}
+
+func (d ProjectModel) getRoles(ctx context.Context) *RolesModel {
+	return d.Roles
+}
+
 func (d ProjectModel) getProjectKey(ctx context.Context) string {
 	return d.Key.ValueString()
 }
@@ -38,7 +51,12 @@ func NewProjectModel(plan ProjectModel, project *bamboo.Project, assignmentResul
 		Name:              types.StringValue(project.Name),
 		Description:       types.StringValue(project.Description),
 		AssignmentVersion: plan.AssignmentVersion,
+		Authoritative:     assignmentAuthoritative(plan.Authoritative),
+		IgnorePrincipals:  plan.IgnorePrincipals,
+		MergeStrategy:     assignmentMergeStrategy(plan.MergeStrategy),
+		StrictPrincipals:  assignmentStrictPrincipals(plan.StrictPrincipals),
 		Assignments:       plan.Assignments,
+		Roles:             assignmentResult.Roles,
 		ComputedUsers:     assignmentResult.ComputedUsers,
 		ComputedGroups:    assignmentResult.ComputedGroups,
 	}
@@ -2,16 +2,20 @@ package provider
 
 import (
 	"context"
+	"fmt"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/yunarta/terraform-atlassian-api-client/bamboo"
 )
 
 type ProjectPermissionsReceiver interface {
 	getClient() *bamboo.Client
+	getExtendedClient() *ExtendedClient
 }
 
 type ProjectPermissionInterface interface {
 	getAssignment(ctx context.Context) (Assignments, diag.Diagnostics)
+	getAssignmentOptions(ctx context.Context) (AssignmentOptions, diag.Diagnostics)
+	getRoles(ctx context.Context) *RolesModel
 	getProjectKey(ctx context.Context) string
 }
 
@@ -21,18 +25,29 @@ func CreateProjectAssignments(ctx context.Context, receiver ProjectPermissionsRe
 		return nil, diags
 	}
 
-	assignmentOrder, diags := assignments.CreateAssignmentOrder(ctx)
+	options, diags := plan.getAssignmentOptions(ctx)
+	if diags != nil {
+		return nil, diags
+	}
+
+	assignmentOrder, diags := assignments.CreateAssignmentOrder(ctx, options.MergeStrategy)
 	if diags != nil {
 		return nil, diags
 	}
 
 	projectKey := plan.getProjectKey(ctx)
+	options.Target = fmt.Sprintf("project %q", projectKey)
 
-	_ = receiver.getClient().ProjectService().UpdateRolePermissions(projectKey, "LOGGED_IN", make([]string, 0))
-	_ = receiver.getClient().ProjectService().UpdateRolePermissions(projectKey, "ANONYMOUS", make([]string, 0))
+	diags = ApplyRoles(ctx, plan.getRoles(ctx), projectRoles, options.Target, func(role string, requestedPermissions []string) error {
+		return receiver.getClient().ProjectService().UpdateRolePermissions(projectKey, role, requestedPermissions)
+	})
+	if diags != nil {
+		return nil, diags
+	}
 
-	return ApplyNewAssignmentSet(ctx, receiver.getClient().UserService(),
+	result, diags := ApplyNewAssignmentSet(ctx, receiver.getClient().UserService(),
 		*assignmentOrder,
+		options,
 		func(user string) (*bamboo.UserPermission, error) {
 			return receiver.getClient().ProjectService().FindAvailableUser(projectKey, user)
 		},
@@ -46,6 +61,27 @@ func CreateProjectAssignments(ctx context.Context, receiver ProjectPermissionsRe
 			return receiver.getClient().ProjectService().UpdateGroupPermissions(projectKey, group, requestedPermissions)
 		},
 	)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	revokeDiags := RevokeUnmanagedAssignment(*assignmentOrder, options,
+		func() (*bamboo.ObjectPermission, error) {
+			return receiver.getClient().ProjectService().ReadPermissions(projectKey)
+		},
+		func(user string, requestedPermissions []string) error {
+			return receiver.getClient().ProjectService().UpdateUserPermissions(projectKey, user, requestedPermissions)
+		},
+		func(group string, requestedPermissions []string) error {
+			return receiver.getClient().ProjectService().UpdateGroupPermissions(projectKey, group, requestedPermissions)
+		},
+	)
+	if revokeDiags != nil {
+		return nil, append(diags, revokeDiags...)
+	}
+
+	result.Roles = plan.getRoles(ctx)
+	return result, diags
 }
 
 func ComputeProjectAssignments(ctx context.Context, receiver ProjectPermissionsReceiver, state ProjectPermissionInterface) (*AssignmentResult, diag.Diagnostics) {
@@ -54,7 +90,12 @@ func ComputeProjectAssignments(ctx context.Context, receiver ProjectPermissionsR
 		return nil, diags
 	}
 
-	assignmentOrder, diags := assignments.CreateAssignmentOrder(ctx)
+	options, diags := state.getAssignmentOptions(ctx)
+	if diags != nil {
+		return nil, diags
+	}
+
+	assignmentOrder, diags := assignments.CreateAssignmentOrder(ctx, options.MergeStrategy)
 	if diags != nil {
 		return nil, diags
 	}
@@ -65,7 +106,29 @@ func ComputeProjectAssignments(ctx context.Context, receiver ProjectPermissionsR
 		return nil, []diag.Diagnostic{diag.NewErrorDiagnostic("Failed to read Project permissions", err.Error())}
 	}
 
-	return ComputeAssignment(ctx, assignedPermissions, *assignmentOrder)
+	grantedRoles, err := receiver.getExtendedClient().ProjectService().ReadRolePermissions(projectKey)
+	if err != nil {
+		return nil, []diag.Diagnostic{diag.NewErrorDiagnostic(errorFailedToReadRolePermissions, err.Error())}
+	}
+
+	result, diags := ComputeAssignment(ctx, assignedPermissions, *assignmentOrder, options,
+		func(user string) (*bamboo.UserPermission, error) {
+			return receiver.getClient().ProjectService().FindAvailableUser(projectKey, user)
+		},
+		func(group string) (*bamboo.GroupPermission, error) {
+			return receiver.getClient().ProjectService().FindAvailableGroup(projectKey, group)
+		},
+	)
+	if diags != nil {
+		return nil, diags
+	}
+
+	result.Roles, diags = ComputeRoles(ctx, state.getRoles(ctx), grantedRoles, projectRoles)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	return result, nil
 }
 
 func UpdateProjectAssignments(ctx context.Context, receiver ProjectPermissionsReceiver,
@@ -78,28 +141,40 @@ func UpdateProjectAssignments(ctx context.Context, receiver ProjectPermissionsRe
 		return nil, diags
 	}
 
+	options, diags := plan.getAssignmentOptions(ctx)
+	if diags != nil {
+		return nil, diags
+	}
+
 	inStateAssignments, diags := state.getAssignment(ctx)
 	if diags != nil {
 		return nil, diags
 	}
 
-	plannedAssignmentOrder, diags := plannedAssignments.CreateAssignmentOrder(ctx)
+	inStateOptions, diags := state.getAssignmentOptions(ctx)
+	if diags != nil {
+		return nil, diags
+	}
+
+	plannedAssignmentOrder, diags := plannedAssignments.CreateAssignmentOrder(ctx, options.MergeStrategy)
 	if diags != nil {
 		return nil, diags
 	}
 
-	inStateAssignmentOrder, diags := inStateAssignments.CreateAssignmentOrder(ctx)
+	inStateAssignmentOrder, diags := inStateAssignments.CreateAssignmentOrder(ctx, inStateOptions.MergeStrategy)
 	if diags != nil {
 		return nil, diags
 	}
 
 	// the plan does not have computed value Project ID
 	projectKey := state.getProjectKey(ctx)
+	options.Target = fmt.Sprintf("project %q", projectKey)
 
-	return UpdateAssignment(ctx, receiver.getClient().UserService(),
+	result, diags := UpdateAssignment(ctx, receiver.getClient().UserService(),
 		*inStateAssignmentOrder,
 		*plannedAssignmentOrder,
 		forceUpdate,
+		options,
 		func(user string) (*bamboo.UserPermission, error) {
 			return receiver.getClient().ProjectService().FindAvailableUser(projectKey, user)
 		},
@@ -113,6 +188,34 @@ func UpdateProjectAssignments(ctx context.Context, receiver ProjectPermissionsRe
 			return receiver.getClient().ProjectService().UpdateGroupPermissions(projectKey, group, requestedPermissions)
 		},
 	)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	revokeDiags := RevokeUnmanagedAssignment(*plannedAssignmentOrder, options,
+		func() (*bamboo.ObjectPermission, error) {
+			return receiver.getClient().ProjectService().ReadPermissions(projectKey)
+		},
+		func(user string, requestedPermissions []string) error {
+			return receiver.getClient().ProjectService().UpdateUserPermissions(projectKey, user, requestedPermissions)
+		},
+		func(group string, requestedPermissions []string) error {
+			return receiver.getClient().ProjectService().UpdateGroupPermissions(projectKey, group, requestedPermissions)
+		},
+	)
+	if revokeDiags != nil {
+		return nil, append(diags, revokeDiags...)
+	}
+
+	roleDiags := ApplyRoles(ctx, plan.getRoles(ctx), projectRoles, options.Target, func(role string, requestedPermissions []string) error {
+		return receiver.getClient().ProjectService().UpdateRolePermissions(projectKey, role, requestedPermissions)
+	})
+	if roleDiags != nil {
+		return nil, append(diags, roleDiags...)
+	}
+
+	result.Roles = plan.getRoles(ctx)
+	return result, diags
 }
 
 func DeleteProjectAssignments(ctx context.Context, receiver ProjectPermissionsReceiver, state ProjectPermissionInterface) diag.Diagnostics {
@@ -121,7 +224,12 @@ func DeleteProjectAssignments(ctx context.Context, receiver ProjectPermissionsRe
 		return diags
 	}
 
-	assignmentOrder, diags := assignments.CreateAssignmentOrder(ctx)
+	options, diags := state.getAssignmentOptions(ctx)
+	if diags != nil {
+		return diags
+	}
+
+	assignmentOrder, diags := assignments.CreateAssignmentOrder(ctx, options.MergeStrategy)
 	if diags != nil {
 		return diags
 	}
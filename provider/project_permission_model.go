@@ -10,7 +10,12 @@ type ProjectPermissionsModel struct {
 	RetainOnDelete    types.Bool   `tfsdk:"retain_on_delete"`
 	Key               types.String `tfsdk:"key"`
 	AssignmentVersion types.String `tfsdk:"assignment_version"`
+	Authoritative     types.Bool   `tfsdk:"authoritative"`
+	IgnorePrincipals  types.List   `tfsdk:"ignore_principals"`
+	MergeStrategy     types.String `tfsdk:"merge_strategy"`
+	StrictPrincipals  types.Bool   `tfsdk:"strict_principals"`
 	Assignments       types.List   `tfsdk:"assignments"`
+	Roles             *RolesModel  `tfsdk:"roles"`
 	ComputedUsers     types.List   `tfsdk:"computed_users"`
 	ComputedGroups    types.List   `tfsdk:"computed_groups"`
 }
@@ -24,6 +29,14 @@ func (d ProjectPermissionsModel) getAssignment(ctx context.Context) (Assignments
 	return assignments, diags
 }
 
+func (d ProjectPermissionsModel) getAssignmentOptions(ctx context.Context) (AssignmentOptions, diag.Diagnostics) {
+	return NewAssignmentOptions(ctx, d.Authoritative, d.IgnorePrincipals, d.MergeStrategy, d.StrictPrincipals)
+}
+
+func (d ProjectPermissionsModel) getRoles(ctx context.Context) *RolesModel {
+	return d.Roles
+}
+
 func (d ProjectPermissionsModel) getProjectKey(ctx context.Context) string {
 	return d.Key.ValueString()
 }
@@ -33,7 +46,12 @@ func NewProjectPermissionsModel(plan ProjectPermissionsModel, assignmentResult *
 		RetainOnDelete:    plan.RetainOnDelete,
 		Key:               plan.Key,
 		AssignmentVersion: plan.AssignmentVersion,
+		Authoritative:     assignmentAuthoritative(plan.Authoritative),
+		IgnorePrincipals:  plan.IgnorePrincipals,
+		MergeStrategy:     assignmentMergeStrategy(plan.MergeStrategy),
+		StrictPrincipals:  assignmentStrictPrincipals(plan.StrictPrincipals),
 		Assignments:       plan.Assignments,
+		Roles:             assignmentResult.Roles,
 		ComputedUsers:     assignmentResult.ComputedUsers,
 		ComputedGroups:    assignmentResult.ComputedGroups,
 	}
@@ -66,6 +66,12 @@ Must be in following format ssh://git@[bitbucket-hostname]:[bitbucket-ssh-port-n
 
 Example ssh://git@bitbucket.mobilesolutionworks.com:7999/%s/%s.git`,
 					},
+					"lowercase": schema.BoolAttribute{
+						Optional: true,
+						MarkdownDescription: `Normalise the Bitbucket project key, repository slug and clone URL path to lower case when linked repositories are created or updated.
+
+Branches are always sent as written. Default value is ` + "`false`" + `.`,
+					},
 				},
 			},
 		},
@@ -83,15 +89,16 @@ func (p *BambooProvider) Configure(ctx context.Context, request provider.Configu
 
 	_ = os.RemoveAll(filepath.Join(".cache"))
 
+	payloadTransport := transport.NewHttpPayloadTransport(config.Bamboo.EndPoint.ValueString(),
+		transport.BearerAuthentication{
+			Token: config.Bamboo.Token.ValueString(),
+		},
+	)
+
 	providerData := &BambooProviderData{
-		config: config,
-		client: bamboo.NewBambooClient(
-			transport.NewHttpPayloadTransport(config.Bamboo.EndPoint.ValueString(),
-				transport.BearerAuthentication{
-					Token: config.Bamboo.Token.ValueString(),
-				},
-			),
-		),
+		config:         config,
+		client:         bamboo.NewBambooClient(payloadTransport),
+		extendedClient: NewExtendedClient(payloadTransport),
 	}
 
 	response.DataSourceData = providerData
@@ -101,7 +108,11 @@ func (p *BambooProvider) Configure(ctx context.Context, request provider.Configu
 func (p *BambooProvider) DataSources(ctx context.Context) []func() datasource.DataSource {
 	return []func() datasource.DataSource{
 		NewLinkedRepositoryDataSource,
+		NewLinkedRepositoryUsageDataSource,
+		NewLinkedRepositoriesDataSource,
+		NewRepositoryAccessGraphDataSource,
 		NewDeploymentDataSource,
+		NewDeploymentReleasesDataSource,
 		NewProjectDataSource,
 		NewProjectPermissionsDataSource,
 	}
@@ -117,9 +128,11 @@ func (p *BambooProvider) Resources(ctx context.Context) []func() resource.Resour
 		NewProjectRepositoriesResource,
 		NewDeploymentResource,
 		NewDeploymentRepositoryResource,
+		NewDeploymentReleaseResource,
 		NewProjectLinkedRepositoryResource,
 		NewLinkedRepositoryResource,
 		NewLinkedRepositoryAccessorResource,
+		NewLinkedRepositoryAccessResource,
 		NewLinkedRepositoryDependencyResource,
 	}
 }
@@ -11,9 +11,10 @@ type EndPoint struct {
 }
 
 type BambooRss struct {
-	Server   types.String `tfsdk:"server"`
-	Name     types.String `tfsdk:"name"`
-	CloneUrl types.String `tfsdk:"clone_url"`
+	Server    types.String `tfsdk:"server"`
+	Name      types.String `tfsdk:"name"`
+	CloneUrl  types.String `tfsdk:"clone_url"`
+	Lowercase types.Bool   `tfsdk:"lowercase"`
 }
 
 type BambooProviderConfig struct {
@@ -22,6 +23,7 @@ type BambooProviderConfig struct {
 }
 
 type BambooProviderData struct {
-	config BambooProviderConfig
-	client *bamboo.Client
+	config         BambooProviderConfig
+	client         *bamboo.Client
+	extendedClient *ExtendedClient
 }
@@ -16,14 +16,16 @@ import (
 func testAccProvider(transport transport.PayloadTransport) map[string]func() (tfprotov6.ProviderServer, error) {
 	return map[string]func() (tfprotov6.ProviderServer, error){
 		"bamboo": providerserver.NewProtocol6WithError(&RecordingBambooProvider{
-			client: bamboo.NewBambooClient(transport),
+			client:         bamboo.NewBambooClient(transport),
+			extendedClient: NewExtendedClient(transport),
 		}),
 	}
 }
 
 type RecordingBambooProvider struct {
-	provider BambooProvider
-	client   *bamboo.Client
+	provider       BambooProvider
+	client         *bamboo.Client
+	extendedClient *ExtendedClient
 }
 
 func (p *RecordingBambooProvider) Metadata(ctx context.Context, request provider.MetadataRequest, response *provider.MetadataResponse) {
@@ -45,7 +47,8 @@ func (p *RecordingBambooProvider) Configure(ctx context.Context, request provide
 				CloneUrl: types.StringValue(os.Getenv("TF_BAMBOORSS_CLONEURL")),
 			},
 		},
-		client: p.client,
+		client:         p.client,
+		extendedClient: p.extendedClient,
 	}
 
 	response.DataSourceData = providerData
@@ -13,6 +13,18 @@ type ConfigurableReceiver interface {
 	setConfig(config BambooProviderConfig, client *bamboo.Client)
 }
 
+// ExtendedConfigurableReceiver is implemented by receivers that require endpoints only available in ExtendedClient.
+type ExtendedConfigurableReceiver interface {
+	setExtendedClient(extendedClient *ExtendedClient)
+}
+
+func configureReceiver(receiver ConfigurableReceiver, data *BambooProviderData) {
+	receiver.setConfig(data.config, data.client)
+	if extended, ok := receiver.(ExtendedConfigurableReceiver); ok {
+		extended.setExtendedClient(data.extendedClient)
+	}
+}
+
 func ConfigureDataSource(receiver ConfigurableReceiver, ctx context.Context, request datasource.ConfigureRequest, response *datasource.ConfigureResponse) {
 	if request.ProviderData == nil {
 		return
@@ -27,7 +39,7 @@ func ConfigureDataSource(receiver ConfigurableReceiver, ctx context.Context, req
 		return
 	}
 
-	receiver.setConfig(data.config, data.client)
+	configureReceiver(receiver, data)
 }
 
 func ConfigureResource(receiver ConfigurableReceiver, ctx context.Context, request resource.ConfigureRequest, response *resource.ConfigureResponse) {
@@ -44,7 +56,7 @@ func ConfigureResource(receiver ConfigurableReceiver, ctx context.Context, reque
 		return
 	}
 
-	receiver.setConfig(data.config, data.client)
+	configureReceiver(receiver, data)
 }
 
 func ConfigureEphemeral(receiver ConfigurableReceiver, ctx context.Context, request ephemeral.ConfigureRequest, response *ephemeral.ConfigureResponse) {
@@ -61,5 +73,5 @@ func ConfigureEphemeral(receiver ConfigurableReceiver, ctx context.Context, requ
 		return
 	}
 
-	receiver.setConfig(data.config, data.client)
+	configureReceiver(receiver, data)
 }
@@ -0,0 +1,416 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/yunarta/terraform-atlassian-api-client/bamboo"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+var numericPattern = regexp.MustCompile(`^\d+$`)
+
+var resolvedRepositoryType = types.ObjectType{
+	AttrTypes: map[string]attr.Type{
+		"id":   types.StringType,
+		"name": types.StringType,
+	},
+}
+
+// RepositoryReferenceModel is a linked repository referenced by id, by name, or by owning project key and name.
+type RepositoryReferenceModel struct {
+	ID         types.String `tfsdk:"id"`
+	Name       types.String `tfsdk:"name"`
+	ProjectKey types.String `tfsdk:"project_key"`
+}
+
+func newRepositoryReferenceModel(reference string) RepositoryReferenceModel {
+	if numericPattern.MatchString(reference) {
+		return RepositoryReferenceModel{ID: types.StringValue(reference), Name: types.StringNull(), ProjectKey: types.StringNull()}
+	}
+
+	return RepositoryReferenceModel{ID: types.StringNull(), Name: types.StringValue(reference), ProjectKey: types.StringNull()}
+}
+
+func (model RepositoryReferenceModel) key() string {
+	if !model.ID.IsNull() {
+		return model.ID.ValueString()
+	}
+
+	if !model.ProjectKey.IsNull() {
+		return fmt.Sprintf("%s/%s", strings.ToUpper(model.ProjectKey.ValueString()), strings.ToLower(model.Name.ValueString()))
+	}
+
+	return strings.ToLower(model.Name.ValueString())
+}
+
+// RepositoryReferenceBlock is the block referencing a linked repository with one of its alternatives.
+func RepositoryReferenceBlock(description string) schema.ListNestedBlock {
+	return schema.ListNestedBlock{
+		MarkdownDescription: description,
+		NestedObject: schema.NestedBlockObject{
+			Attributes: map[string]schema.Attribute{
+				"id": schema.StringAttribute{
+					Optional: true,
+					Validators: []validator.String{
+						stringvalidator.RegexMatches(numericPattern, "value must be a numeric"),
+						stringvalidator.ExactlyOneOf(path.MatchRelative().AtParent().AtName("name")),
+					},
+					MarkdownDescription: "Numeric id of the linked repository.",
+				},
+				"name": schema.StringAttribute{
+					Optional:            true,
+					MarkdownDescription: "Name of the linked repository, survives the repository being re-created.",
+				},
+				"project_key": schema.StringAttribute{
+					Optional: true,
+					Validators: []validator.String{
+						stringvalidator.AlsoRequires(path.MatchRelative().AtParent().AtName("name")),
+					},
+					MarkdownDescription: "Bamboo project key owning the linked repository, when `name` refers to a project linked repository.",
+				},
+			},
+		},
+	}
+}
+
+// ResolvedRepositoriesSchema is the attribute reporting the repositories the references resolved to, the references are
+// the names of the list attribute and block referencing the repositories.
+func ResolvedRepositoriesSchema(references ...string) schema.ListNestedAttribute {
+	return schema.ListNestedAttribute{
+		Computed: true,
+		PlanModifiers: []planmodifier.List{
+			listplanmodifier.UseStateForUnknown(),
+			resolvedRepositoriesModifier{references: references},
+		},
+		MarkdownDescription: "Linked repositories the references resolved to, with their id and name.",
+		NestedObject: schema.NestedAttributeObject{
+			Attributes: map[string]schema.Attribute{
+				"id": schema.StringAttribute{
+					Computed:            true,
+					MarkdownDescription: "Numeric id of the linked repository.",
+				},
+				"name": schema.StringAttribute{
+					Computed:            true,
+					MarkdownDescription: "Name of the linked repository.",
+				},
+			},
+		},
+	}
+}
+
+// resolvedRepositoriesModifier shows the resolved repositories as known after apply again when the references change,
+// as the repositories kept from the state would no longer match.
+type resolvedRepositoriesModifier struct {
+	references []string
+}
+
+func (m resolvedRepositoriesModifier) Description(ctx context.Context) string {
+	return "Resolves the repositories again when the repository references change."
+}
+
+func (m resolvedRepositoriesModifier) MarkdownDescription(ctx context.Context) string {
+	return m.Description(ctx)
+}
+
+func (m resolvedRepositoriesModifier) PlanModifyList(ctx context.Context, request planmodifier.ListRequest, response *planmodifier.ListResponse) {
+	if request.State.Raw.IsNull() || request.Plan.Raw.IsNull() {
+		return
+	}
+
+	for _, name := range m.references {
+		var planned, known types.List
+
+		diags := request.Plan.GetAttribute(ctx, path.Root(name), &planned)
+		diags.Append(request.State.GetAttribute(ctx, path.Root(name), &known)...)
+		if diags.HasError() {
+			response.Diagnostics.Append(diags...)
+			return
+		}
+
+		if !planned.Equal(known) {
+			response.PlanValue = types.ListUnknown(resolvedRepositoryType)
+			return
+		}
+	}
+}
+
+// RepositoryReferences holds the repositories referenced by the list attribute, as id or name, and by the nested blocks.
+type RepositoryReferences struct {
+	Repositories []string
+	Blocks       []RepositoryReferenceModel
+
+	null bool
+}
+
+func NewRepositoryReferences(ctx context.Context, repositories types.List, blocks []RepositoryReferenceModel) (RepositoryReferences, diag.Diagnostics) {
+	references := RepositoryReferences{
+		Repositories: make([]string, 0),
+		Blocks:       make([]RepositoryReferenceModel, 0),
+		null:         repositories.IsNull(),
+	}
+
+	references.Blocks = append(references.Blocks, blocks...)
+	if repositories.IsNull() || repositories.IsUnknown() {
+		return references, nil
+	}
+
+	diags := repositories.ElementsAs(ctx, &references.Repositories, true)
+	return references, diags
+}
+
+func (references RepositoryReferences) models() []RepositoryReferenceModel {
+	var models []RepositoryReferenceModel
+	for _, repository := range references.Repositories {
+		models = append(models, newRepositoryReferenceModel(repository))
+	}
+
+	return append(models, references.Blocks...)
+}
+
+// List returns the list attribute, kept null when it was not configured.
+func (references RepositoryReferences) List(ctx context.Context) (types.List, diag.Diagnostics) {
+	if references.null && len(references.Repositories) == 0 {
+		return types.ListNull(types.StringType), nil
+	}
+
+	return types.ListValueFrom(ctx, types.StringType, references.Repositories)
+}
+
+// RepositoryResolver resolves repository references, remembering the resolutions of the current operation.
+type RepositoryResolver struct {
+	client         *bamboo.Client
+	extendedClient *ExtendedClient
+
+	resolved map[string]*RepositoryReferenceData
+}
+
+func NewRepositoryResolver(client *bamboo.Client, extendedClient *ExtendedClient) *RepositoryResolver {
+	return &RepositoryResolver{
+		client:         client,
+		extendedClient: extendedClient,
+		resolved:       make(map[string]*RepositoryReferenceData),
+	}
+}
+
+// Resolve returns the referenced repository, or nil when it does not exist.
+func (resolver *RepositoryResolver) Resolve(reference RepositoryReferenceModel) (*RepositoryReferenceData, error) {
+	key := reference.key()
+	if data, ok := resolver.resolved[key]; ok {
+		return data, nil
+	}
+
+	var data *RepositoryReferenceData
+	switch {
+	case !reference.ID.IsNull():
+		repositoryId, err := strconv.Atoi(reference.ID.ValueString())
+		if err != nil {
+			return nil, err
+		}
+
+		detail, err := resolver.extendedClient.RepositoryService().ReadDetail(repositoryId)
+		if err != nil {
+			return nil, err
+		}
+
+		if detail != nil {
+			data = &RepositoryReferenceData{Id: strconv.Itoa(repositoryId), Name: detail.Name}
+		}
+	case !reference.ProjectKey.IsNull():
+		repositories, err := resolver.extendedClient.RepositoryService().SearchProject(reference.ProjectKey.ValueString(), reference.Name.ValueString())
+		if err != nil {
+			return nil, err
+		}
+
+		for _, repository := range repositories {
+			if strings.EqualFold(repository.Name, reference.Name.ValueString()) {
+				data = &RepositoryReferenceData{Id: strconv.Itoa(repository.ID), Name: repository.Name}
+				break
+			}
+		}
+	default:
+		repository, err := resolver.client.RepositoryService().Read(reference.Name.ValueString())
+		if err != nil {
+			return nil, err
+		}
+
+		if repository != nil {
+			data = &RepositoryReferenceData{Id: strconv.Itoa(repository.ID), Name: repository.Name}
+		}
+	}
+
+	resolver.resolved[key] = data
+	return data, nil
+}
+
+// ResolveAll resolves the references before they are applied, a missing repository is an error.
+func (resolver *RepositoryResolver) ResolveAll(references RepositoryReferences) ([]RepositoryReferenceData, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	var resolved = make([]RepositoryReferenceData, 0)
+	for _, reference := range references.models() {
+		data, err := resolver.Resolve(reference)
+		if err != nil {
+			diags.AddError(errorFailedToReadRepository, err.Error())
+			return nil, diags
+		}
+
+		if data == nil {
+			diags.AddError("Missing linked repository", fmt.Sprintf("Unable to find linked repository '%s'", reference.key()))
+			return nil, diags
+		}
+
+		resolved = append(resolved, *data)
+	}
+
+	return resolved, diags
+}
+
+// Owned returns the ids of the repositories the resource granted in its last apply. States written before the
+// resolution was recorded fall back to resolving their references.
+func (resolver *RepositoryResolver) Owned(ctx context.Context, resolved types.List, references RepositoryReferences) ([]string, diag.Diagnostics) {
+	if !resolved.IsNull() && !resolved.IsUnknown() {
+		var repositories []RepositoryReferenceData
+		diags := resolved.ElementsAs(ctx, &repositories, true)
+		return resolvedIds(repositories), diags
+	}
+
+	return resolver.Existing(references)
+}
+
+// Existing returns the ids of the referenced repositories that still exist.
+func (resolver *RepositoryResolver) Existing(references RepositoryReferences) ([]string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	var repositories []RepositoryReferenceData
+	for _, reference := range references.models() {
+		data, err := resolver.Resolve(reference)
+		if err != nil {
+			diags.AddError(errorFailedToReadRepository, err.Error())
+			return nil, diags
+		}
+
+		if data != nil {
+			repositories = append(repositories, *data)
+		}
+	}
+
+	return resolvedIds(repositories), diags
+}
+
+// Refresh keeps the references resolving to a granted repository, so a missing or re-created repository shows up as
+// drift. When reportExtras is set, the repositories granted without a reference are added by id.
+func (resolver *RepositoryResolver) Refresh(references RepositoryReferences, granted []bamboo.Repository, reportExtras bool) (RepositoryReferences, []RepositoryReferenceData, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	grantedIds := make(map[string]bool)
+	for _, repository := range granted {
+		grantedIds[strconv.Itoa(repository.ID)] = true
+	}
+
+	refreshed := RepositoryReferences{
+		Repositories: make([]string, 0),
+		Blocks:       make([]RepositoryReferenceModel, 0),
+		null:         references.null,
+	}
+
+	var resolved = make([]RepositoryReferenceData, 0)
+	var matched = make(map[string]bool)
+	keep := func(reference RepositoryReferenceModel) bool {
+		data, err := resolver.Resolve(reference)
+		if err != nil {
+			diags.AddError(errorFailedToReadRepository, err.Error())
+			return false
+		}
+
+		if data == nil || !grantedIds[data.Id] {
+			return false
+		}
+
+		matched[data.Id] = true
+		resolved = append(resolved, *data)
+		return true
+	}
+
+	for _, repository := range references.Repositories {
+		if keep(newRepositoryReferenceModel(repository)) {
+			refreshed.Repositories = append(refreshed.Repositories, repository)
+		}
+	}
+
+	for _, block := range references.Blocks {
+		if keep(block) {
+			refreshed.Blocks = append(refreshed.Blocks, block)
+		}
+	}
+
+	if diags.HasError() {
+		return references, nil, diags
+	}
+
+	if reportExtras {
+		sorted := append([]bamboo.Repository{}, granted...)
+		sort.Slice(sorted, func(i, j int) bool {
+			return sorted[i].ID < sorted[j].ID
+		})
+
+		for _, repository := range sorted {
+			id := strconv.Itoa(repository.ID)
+			if !matched[id] {
+				refreshed.Repositories = append(refreshed.Repositories, id)
+				resolved = append(resolved, RepositoryReferenceData{Id: id, Name: repository.Name})
+			}
+		}
+	}
+
+	return refreshed, resolved, diags
+}
+
+func resolvedIds(repositories []RepositoryReferenceData) []string {
+	var ids = make([]string, 0)
+	for _, repository := range repositories {
+		ids = append(ids, repository.Id)
+	}
+
+	return ids
+}
+
+func resolvedList(ctx context.Context, repositories []RepositoryReferenceData) (types.List, diag.Diagnostics) {
+	return types.ListValueFrom(ctx, resolvedRepositoryType, repositories)
+}
+
+// importedReferences references every granted repository by id, as an imported resource has no configuration yet.
+func importedReferences(ctx context.Context, granted []bamboo.Repository) (types.List, types.List, diag.Diagnostics) {
+	sorted := append([]bamboo.Repository{}, granted...)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].ID < sorted[j].ID
+	})
+
+	references := RepositoryReferences{Repositories: make([]string, 0), null: len(sorted) == 0}
+	var resolved = make([]RepositoryReferenceData, 0)
+	for _, repository := range sorted {
+		id := strconv.Itoa(repository.ID)
+		references.Repositories = append(references.Repositories, id)
+		resolved = append(resolved, RepositoryReferenceData{Id: id, Name: repository.Name})
+	}
+
+	repositories, diags := references.List(ctx)
+	if diags.HasError() {
+		return repositories, types.ListNull(resolvedRepositoryType), diags
+	}
+
+	resolvedRepositories, resolvedDiags := resolvedList(ctx, resolved)
+	diags.Append(resolvedDiags...)
+	return repositories, resolvedRepositories, diags
+}
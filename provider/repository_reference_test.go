@@ -0,0 +1,230 @@
+package provider
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/yunarta/terraform-provider-bamboo/provider/test"
+)
+
+func resolvedAttribute(t *testing.T, state tfsdk.State) []RepositoryReferenceData {
+	t.Helper()
+
+	var resolved []RepositoryReferenceData
+	if diags := state.GetAttribute(context.Background(), path.Root("resolved_repositories"), &resolved); diags.HasError() {
+		t.Fatalf("GetAttribute() diagnostics = %v", diags)
+	}
+
+	return resolved
+}
+
+func TestLinkedRepositoryAccessorResource_References(t *testing.T) {
+	virtualization := newAccessVirtualization()
+	virtualization.AddProject("PROJ", "Project")
+	virtualization.AddRepository(test.Repository{ID: 5, Name: "project-app", Spec: map[string]any{"project": map[string]any{"key": "PROJ"}}})
+
+	harness := newResourceHarness(t, NewLinkedRepositoryAccessorResource(), virtualization, testBambooRss(false))
+	config := map[string]any{
+		"id":               "1",
+		"mode":             accessModeAdditive,
+		"retain_on_delete": true,
+		"repositories":     []string{"LIB", "3"},
+		"repository": []RepositoryReferenceModel{
+			newProjectReference("PROJ", "project-app"),
+		},
+	}
+
+	state, diags := harness.create(harness.plan(config))
+	harness.require(diags)
+
+	if got := sortedAccess(virtualization, "repository/1"); !reflect.DeepEqual(got, []int{2, 3, 5}) {
+		t.Errorf("Create() access = %v, want [2 3 5]", got)
+	}
+
+	want := []RepositoryReferenceData{{Id: "2", Name: "lib"}, {Id: "3", Name: "specs"}, {Id: "5", Name: "project-app"}}
+	if got := resolvedAttribute(t, state); !reflect.DeepEqual(got, want) {
+		t.Errorf("Create() resolved_repositories = %v, want %v", got, want)
+	}
+
+	// re-creating the repository gives it a new id, the reference by name no longer resolves to a granted repository
+	virtualization.RemoveRepository(2)
+	virtualization.AddRepository(test.Repository{ID: 6, Name: "lib"})
+
+	state, diags = harness.read(state)
+	harness.require(diags)
+
+	if got := listAttribute(t, state, "repositories"); !reflect.DeepEqual(got, []string{"3"}) {
+		t.Errorf("Read() repositories = %v, want [3]", got)
+	}
+
+	state, diags = harness.update(harness.planFrom(state, config), state)
+	harness.require(diags)
+
+	if got := sortedAccess(virtualization, "repository/1"); !reflect.DeepEqual(got, []int{3, 5, 6}) {
+		t.Errorf("Update() access = %v, want [3 5 6]", got)
+	}
+
+	want = []RepositoryReferenceData{{Id: "6", Name: "lib"}, {Id: "3", Name: "specs"}, {Id: "5", Name: "project-app"}}
+	if got := resolvedAttribute(t, state); !reflect.DeepEqual(got, want) {
+		t.Errorf("Update() resolved_repositories = %v, want %v", got, want)
+	}
+}
+
+func TestLinkedRepositoryAccessorResource_MissingReference(t *testing.T) {
+	virtualization := newAccessVirtualization()
+
+	harness := newResourceHarness(t, NewLinkedRepositoryAccessorResource(), virtualization, testBambooRss(false))
+	_, diags := harness.create(harness.plan(map[string]any{
+		"id":               "1",
+		"mode":             accessModeAdditive,
+		"retain_on_delete": true,
+		"repositories":     []string{"missing"},
+	}))
+	if !diags.HasError() {
+		t.Fatalf("Create() expected an error for a missing repository")
+	}
+}
+
+func TestProjectRepositoriesResource_References(t *testing.T) {
+	virtualization := newAccessVirtualization()
+	virtualization.AddProject("PROJ", "Project")
+
+	harness := newResourceHarness(t, NewProjectRepositoriesResource(), virtualization, testBambooRss(false))
+	state, diags := harness.create(harness.plan(map[string]any{
+		"key":              "PROJ",
+		"retain_on_delete": false,
+		"repositories":     []string{"app"},
+		"repository":       []RepositoryReferenceModel{newRepositoryReferenceModel("3")},
+	}))
+	harness.require(diags)
+
+	if got := sortedAccess(virtualization, "project/PROJ"); !reflect.DeepEqual(got, []int{1, 3}) {
+		t.Errorf("Create() access = %v, want [1 3]", got)
+	}
+
+	state, diags = harness.read(state)
+	harness.require(diags)
+
+	if got := listAttribute(t, state, "repositories"); !reflect.DeepEqual(got, []string{"app"}) {
+		t.Errorf("Read() repositories = %v, want [app]", got)
+	}
+
+	want := []RepositoryReferenceData{{Id: "1", Name: "app"}, {Id: "3", Name: "specs"}}
+	if got := resolvedAttribute(t, state); !reflect.DeepEqual(got, want) {
+		t.Errorf("Read() resolved_repositories = %v, want %v", got, want)
+	}
+
+	harness.require(harness.delete(state))
+	if got := virtualization.Access("project/PROJ"); len(got) != 0 {
+		t.Errorf("Delete() access = %v, want none", got)
+	}
+}
+
+func TestResolvedRepositoriesSchema_PlanModifiers(t *testing.T) {
+	resources := []struct {
+		name     string
+		resource func() resource.Resource
+		config   map[string]any
+		list     string
+		block    string
+	}{
+		{
+			name:     "project repositories",
+			resource: NewProjectRepositoriesResource,
+			config:   map[string]any{"key": "PROJ", "repositories": []string{"app"}, "repository": []RepositoryReferenceModel{newRepositoryReferenceModel("3")}},
+			list:     "repositories",
+			block:    "repository",
+		},
+		{
+			name:     "dependency",
+			resource: NewLinkedRepositoryDependencyResource,
+			config:   map[string]any{"id": "4", "mode": accessModeAdditive, "requires": []string{"app"}, "require": []RepositoryReferenceModel{newRepositoryReferenceModel("3")}},
+			list:     "requires",
+			block:    "require",
+		},
+	}
+
+	for _, rr := range resources {
+		tests := []struct {
+			name        string
+			attributes  map[string]any
+			wantUnknown bool
+		}{
+			// the resolved repositories do not show as known after apply on every plan
+			{name: "unchanged references", attributes: map[string]any{"retain_on_delete": true}},
+			{name: "changed list", attributes: map[string]any{rr.list: []string{"lib"}}, wantUnknown: true},
+			{name: "changed block", attributes: map[string]any{rr.block: []RepositoryReferenceModel{}}, wantUnknown: true},
+		}
+
+		for _, tt := range tests {
+			t.Run(rr.name+" "+tt.name, func(t *testing.T) {
+				ctx := context.Background()
+				harness := newResourceHarness(t, rr.resource(), newAccessVirtualization(), testBambooRss(false))
+				state, diags := harness.create(harness.plan(rr.config))
+				harness.require(diags)
+
+				// Terraform plans a computed attribute as unknown, before the plan modifiers run
+				plan := harness.planFrom(state, tt.attributes)
+				harness.require(plan.SetAttribute(ctx, path.Root("resolved_repositories"), types.ListUnknown(resolvedRepositoryType)))
+
+				var known types.List
+				harness.require(state.GetAttribute(ctx, path.Root("resolved_repositories"), &known))
+
+				attribute := harness.schema.Attributes["resolved_repositories"].(schema.ListNestedAttribute)
+				response := &planmodifier.ListResponse{PlanValue: types.ListUnknown(resolvedRepositoryType)}
+				for _, modifier := range attribute.PlanModifiers {
+					modifier.PlanModifyList(ctx, planmodifier.ListRequest{
+						State:       state,
+						Plan:        plan,
+						StateValue:  known,
+						PlanValue:   response.PlanValue,
+						ConfigValue: types.ListNull(resolvedRepositoryType),
+					}, response)
+					harness.require(response.Diagnostics)
+				}
+
+				if response.PlanValue.IsUnknown() != tt.wantUnknown {
+					t.Errorf("PlanModifyList() unknown = %v, want %v", response.PlanValue.IsUnknown(), tt.wantUnknown)
+				}
+
+				if !tt.wantUnknown && !response.PlanValue.Equal(known) {
+					t.Errorf("PlanModifyList() plan = %v, want the state %v", response.PlanValue, known)
+				}
+			})
+		}
+	}
+}
+
+func newProjectReference(projectKey string, name string) RepositoryReferenceModel {
+	reference := newRepositoryReferenceModel(name)
+	reference.ProjectKey = types.StringValue(projectKey)
+	return reference
+}
+
+// requireImportedReferences checks that an imported resource resolved the repositories, is retained on delete, and
+// plans no changes against a matching configuration.
+func requireImportedReferences(t *testing.T, harness *resourceHarness, state tfsdk.State, want []RepositoryReferenceData, config map[string]any) {
+	t.Helper()
+
+	if got := resolvedAttribute(t, state); !reflect.DeepEqual(got, want) {
+		t.Errorf("ImportState() resolved_repositories = %v, want %v", got, want)
+	}
+
+	var retainOnDelete bool
+	harness.require(state.GetAttribute(context.Background(), path.Root("retain_on_delete"), &retainOnDelete))
+	if !retainOnDelete {
+		t.Errorf("ImportState() retain_on_delete = false, want true")
+	}
+
+	config["retain_on_delete"] = true
+	if plan := harness.planFrom(state, config); !plan.Raw.Equal(state.Raw) {
+		t.Errorf("ImportState() state = %v, want it to match the plan %v", state.Raw, plan.Raw)
+	}
+}
@@ -2,6 +2,7 @@ package provider
 
 import (
 	"context"
+	"fmt"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
@@ -10,13 +11,17 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/yunarta/terraform-atlassian-api-client/bamboo"
 	"github.com/yunarta/terraform-provider-commons/util"
+	"strconv"
+	"strings"
 )
 
 var (
-	_ resource.Resource              = &AgentAssignmentResource{}
-	_ resource.ResourceWithConfigure = &AgentAssignmentResource{}
-	_ ProjectPermissionsReceiver     = &AgentAssignmentResource{}
-	_ ConfigurableReceiver           = &AgentAssignmentResource{}
+	_ resource.Resource                = &AgentAssignmentResource{}
+	_ resource.ResourceWithConfigure   = &AgentAssignmentResource{}
+	_ resource.ResourceWithImportState = &AgentAssignmentResource{}
+	_ ProjectPermissionsReceiver       = &AgentAssignmentResource{}
+	_ ConfigurableReceiver             = &AgentAssignmentResource{}
+	_ ExtendedConfigurableReceiver     = &AgentAssignmentResource{}
 )
 
 func NewAgentAssignmentResource() resource.Resource {
@@ -24,8 +29,9 @@ func NewAgentAssignmentResource() resource.Resource {
 }
 
 type AgentAssignmentResource struct {
-	config BambooProviderConfig
-	client *bamboo.Client
+	config         BambooProviderConfig
+	client         *bamboo.Client
+	extendedClient *ExtendedClient
 }
 
 func (receiver *AgentAssignmentResource) setConfig(config BambooProviderConfig, client *bamboo.Client) {
@@ -33,10 +39,18 @@ func (receiver *AgentAssignmentResource) setConfig(config BambooProviderConfig,
 	receiver.client = client
 }
 
+func (receiver *AgentAssignmentResource) setExtendedClient(extendedClient *ExtendedClient) {
+	receiver.extendedClient = extendedClient
+}
+
 func (receiver *AgentAssignmentResource) getClient() *bamboo.Client {
 	return receiver.client
 }
 
+func (receiver *AgentAssignmentResource) getExtendedClient() *ExtendedClient {
+	return receiver.extendedClient
+}
+
 func (receiver *AgentAssignmentResource) Metadata(ctx context.Context, request resource.MetadataRequest, response *resource.MetadataResponse) {
 	response.TypeName = request.ProviderTypeName + "_agent_assignment"
 }
@@ -44,6 +58,8 @@ func (receiver *AgentAssignmentResource) Metadata(ctx context.Context, request r
 func (receiver *AgentAssignmentResource) Schema(ctx context.Context, request resource.SchemaRequest, response *resource.SchemaResponse) {
 	response.Schema = schema.Schema{
 		MarkdownDescription: `This resource define assignment of executable (project, plan, job, deployment, environment) to a Bamboo agent.
+
+Import the assignment with ` + "`<type>/<agent>/<executable_type>/<executable_id>`" + `, for example ` + "`AGENT/131073/DEPLOYMENT_PROJECT/1277953`" + `.
 `,
 		Attributes: map[string]schema.Attribute{
 			//"id": schema.StringAttribute{
@@ -123,49 +139,34 @@ func (receiver *AgentAssignmentResource) Create(ctx context.Context, request res
 }
 
 func (receiver *AgentAssignmentResource) Read(ctx context.Context, request resource.ReadRequest, response *resource.ReadResponse) {
-	//var (
-	//	diags diag.Diagnostics
-	//	err error
-	//
-	//	state AgentAssignmentModel
-	//)
-	//
-	//diags = request.State.Get(ctx, &state)
-	//if util.TestDiagnostic(&response.Diagnostics, diags) {
-	//	return
-	//}
-	//
-	//diags = response.State.Set(ctx, state)
-	//if util.TestDiagnostic(&response.Diagnostics, diags) {
-	//	return
-	//}
-	//
-	//cacheDir := filepath.Join(".cache", "agent")
-	//_ = os.MkdirAll(cacheDir, 0755)
-	//filename := filepath.Join(cacheDir, fmt.Sprintf("%d.json", state.AgentId))
-	//
-	//var assignmentList *[]bamboo.AgentAssignment
-	//if _, err := os.Stat(filename); err == nil {
-	//	fileBytes, err := os.ReadFile(filename)
-	//	if err != nil {
-	//		err = json.Unmarshal(fileBytes, assignmentList)
-	//	}
-	//}
-	//
-	//if assignmentList == nil {
-	//	assignmentList, err = receiver.client.AgentAssignmentService().Read(bamboo.AgentQuery{
-	//		ExecutorType: state.Type,
-	//		ExecutorId:   state.AgentId,
-	//	})
-	//	if util.TestError(&response.Diagnostics, err, "Failed to retrieve agent assignments") {
-	//		return
-	//	}
-	//
-	//	marshal, _ := json.Marshal(assignmentList)
-	//	_ = os.WriteFile(filename, marshal, 0644)
-	//}
-	//
-	//assignmentList
+	var (
+		diags diag.Diagnostics
+
+		state AgentAssignmentModel
+	)
+
+	diags = request.State.Get(ctx, &state)
+	if util.TestDiagnostic(&response.Diagnostics, diags) {
+		return
+	}
+
+	assignments, err := receiver.extendedClient.AgentAssignmentService().Read(bamboo.AgentQuery{
+		ExecutorType: state.Type,
+		ExecutorId:   state.AgentId,
+	})
+	if util.TestError(&response.Diagnostics, err, "Failed to retrieve agent assignments") {
+		return
+	}
+
+	for _, assignment := range assignments {
+		if assignment.ExecutableId == state.ExecutableId && assignment.ExecutableType == state.ExecutableType {
+			diags = response.State.Set(ctx, state)
+			util.TestDiagnostic(&response.Diagnostics, diags)
+			return
+		}
+	}
+
+	response.State.RemoveResource(ctx)
 }
 
 func (receiver *AgentAssignmentResource) Update(ctx context.Context, request resource.UpdateRequest, response *resource.UpdateResponse) {
@@ -225,3 +226,29 @@ func (receiver *AgentAssignmentResource) Delete(ctx context.Context, request res
 
 	response.State.RemoveResource(ctx)
 }
+
+func (receiver *AgentAssignmentResource) ImportState(ctx context.Context, request resource.ImportStateRequest, response *resource.ImportStateResponse) {
+	parts := strings.Split(request.ID, "/")
+	if len(parts) != 4 {
+		response.Diagnostics.AddError("Invalid import id", fmt.Sprintf("Expected <type>/<agent>/<executable_type>/<executable_id>, got '%s'", request.ID))
+		return
+	}
+
+	agentId, err := strconv.ParseInt(parts[1], 10, 64)
+	if util.TestError(&response.Diagnostics, err, "Agent id must be a number") {
+		return
+	}
+
+	executableId, err := strconv.ParseInt(parts[3], 10, 64)
+	if util.TestError(&response.Diagnostics, err, "Executable id must be a number") {
+		return
+	}
+
+	diags := response.State.Set(ctx, &AgentAssignmentModel{
+		Type:           parts[0],
+		AgentId:        agentId,
+		ExecutableId:   executableId,
+		ExecutableType: parts[2],
+	})
+	util.TestDiagnostic(&response.Diagnostics, diags)
+}
@@ -0,0 +1,48 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/yunarta/terraform-atlassian-api-client/bamboo"
+	"github.com/yunarta/terraform-provider-bamboo/provider/test"
+)
+
+func TestAgentAssignmentResource_ImportState(t *testing.T) {
+	virtualization := test.NewServiceVirtualization()
+	virtualization.AddAgentAssignment(bamboo.AgentAssignment{ExecutorType: "AGENT", ExecutorId: 131073, ExecutableId: 7, ExecutableType: "DEPLOYMENT_PROJECT"})
+
+	harness := newResourceHarness(t, NewAgentAssignmentResource(), virtualization, testBambooRss(false))
+	state, diags := harness.importState("AGENT/131073/DEPLOYMENT_PROJECT/7")
+	harness.require(diags)
+
+	plan := harness.plan(map[string]any{
+		"type":            "AGENT",
+		"agent":           int64(131073),
+		"executable_id":   int64(7),
+		"executable_type": "DEPLOYMENT_PROJECT",
+	})
+	if !plan.Raw.Equal(state.Raw) {
+		t.Errorf("ImportState() state = %v, want %v", state.Raw, plan.Raw)
+	}
+
+	harness.require(harness.delete(state))
+	if got := virtualization.AgentAssignments(); len(got) != 0 {
+		t.Errorf("Delete() assignments = %v, want none", got)
+	}
+
+	state, diags = harness.read(state)
+	harness.require(diags)
+	if !state.Raw.IsNull() {
+		t.Errorf("Read() state = %v, want it removed once the assignment is gone", state.Raw)
+	}
+}
+
+func TestAgentAssignmentResource_ImportStateInvalidId(t *testing.T) {
+	harness := newResourceHarness(t, NewAgentAssignmentResource(), test.NewServiceVirtualization(), testBambooRss(false))
+
+	for _, id := range []string{"AGENT/131073/DEPLOYMENT_PROJECT", "AGENT/agent/DEPLOYMENT_PROJECT/7", "AGENT/131073/DEPLOYMENT_PROJECT/deployment"} {
+		if _, diags := harness.importState(id); !diags.HasError() {
+			t.Errorf("ImportState(%q) expected an error", id)
+		}
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"regexp"
 	"sort"
 	"strconv"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
@@ -15,6 +16,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/yunarta/golang-quality-of-life-pack/collections"
@@ -26,8 +28,10 @@ var (
 	_ resource.Resource                = &DeploymentResource{}
 	_ resource.ResourceWithConfigure   = &DeploymentResource{}
 	_ resource.ResourceWithImportState = &DeploymentResource{}
+	_ resource.ResourceWithModifyPlan  = &DeploymentResource{}
 	_ DeploymentPermissionsReceiver    = &DeploymentResource{}
 	_ ConfigurableReceiver             = &DeploymentResource{}
+	_ ExtendedConfigurableReceiver     = &DeploymentResource{}
 )
 
 func NewDeploymentResource() resource.Resource {
@@ -39,8 +43,9 @@ func (receiver *DeploymentResource) getClient() *bamboo.Client {
 }
 
 type DeploymentResource struct {
-	config BambooProviderConfig
-	client *bamboo.Client
+	config         BambooProviderConfig
+	client         *bamboo.Client
+	extendedClient *ExtendedClient
 }
 
 func (receiver *DeploymentResource) setConfig(config BambooProviderConfig, client *bamboo.Client) {
@@ -48,6 +53,10 @@ func (receiver *DeploymentResource) setConfig(config BambooProviderConfig, clien
 	receiver.client = client
 }
 
+func (receiver *DeploymentResource) setExtendedClient(extendedClient *ExtendedClient) {
+	receiver.extendedClient = extendedClient
+}
+
 func (receiver *DeploymentResource) Metadata(ctx context.Context, request resource.MetadataRequest, response *resource.MetadataResponse) {
 	response.TypeName = request.ProviderTypeName + "_deployment"
 }
@@ -65,15 +74,15 @@ In order for the execution to be successful, the user must have user access to a
 				MarkdownDescription: "Default value is `true`, and if the value set to `false` when the resource destroyed, the deployment will be removed.",
 			},
 			"id": schema.StringAttribute{
-				Computed:            true,
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
 				MarkdownDescription: "Numeric id of the deployment.",
 			},
 			"name": schema.StringAttribute{
-				Required: true,
-				PlanModifiers: []planmodifier.String{
-					util.ReplaceIfStringDiff(),
-				},
-				MarkdownDescription: "Name of the deployment.",
+				Required:            true,
+				MarkdownDescription: "Name of the deployment. Changing the name renames the deployment in place.",
 			},
 			"plan_key": schema.StringAttribute{
 				Required:            true,
@@ -85,7 +94,7 @@ In order for the execution to be successful, the user must have user access to a
 			},
 			"repository_specs_managed": schema.BoolAttribute{
 				Computed:            true,
-				MarkdownDescription: "Computer value that defines the repository is managed by spec.",
+				MarkdownDescription: "Computer value that defines the repository is managed by spec. When `true`, only `repositories` and `retain_on_delete` can be changed, other changes are rejected during plan.",
 			},
 			"repositories": schema.ListAttribute{
 				Optional:    true,
@@ -98,12 +107,13 @@ In order for the execution to be successful, the user must have user access to a
 				},
 				MarkdownDescription: "This deployment will add this list of linked repositories into its permission.",
 			},
-			"assignment_version": schema.StringAttribute{
-				Optional:            true,
-				MarkdownDescription: "Assignment version, used to force update the permission.",
-			},
-			"computed_users":  ComputedAssignmentSchema,
-			"computed_groups": ComputedAssignmentSchema,
+			"assignment_version": AssignmentVersionSchema,
+			"authoritative":      AuthoritativeSchema,
+			"ignore_principals":  IgnorePrincipalsSchema,
+			"merge_strategy":     MergeStrategySchema,
+			"strict_principals":  StrictPrincipalsSchema,
+			"computed_users":     ComputedAssignmentSchema,
+			"computed_groups":    ComputedAssignmentSchema,
 		},
 		Blocks: map[string]schema.Block{
 			"assignments": AssignmentSchema(
@@ -111,6 +121,15 @@ In order for the execution to be successful, the user must have user access to a
 				"READ",
 				"WRITE",
 				"VIEWCONFIGURATION"),
+			"roles": RolesSchema(
+				[]string{
+					"APPROVERELEASE",
+					"READ",
+					"WRITE",
+					"VIEWCONFIGURATION",
+				},
+				[]string{"READ"},
+			),
 		},
 	}
 }
@@ -196,8 +215,8 @@ func (receiver *DeploymentResource) Read(ctx context.Context, request resource.R
 	}
 
 	if state.ID.IsNull() {
-		deployment, err = receiver.client.DeploymentService().Read(state.Name.ValueString())
-		if util.TestError(&response.Diagnostics, err, errorFailedToReadDeployment) {
+		deployment, diags = ReadDeploymentByName(receiver.client, receiver.extendedClient, state.Name.ValueString())
+		if util.TestDiagnostic(&response.Diagnostics, diags) {
 			return
 		}
 
@@ -295,7 +314,13 @@ func (receiver *DeploymentResource) Update(ctx context.Context, request resource
 			return
 		}
 
-		forceUpdate := !plan.AssignmentVersion.Equal(state.AssignmentVersion)
+		// permissions changed outside of Terraform are pushed again
+		drifted, diags := AssignmentDrift(ctx, request.State)
+		if util.TestDiagnostic(&response.Diagnostics, diags) {
+			return
+		}
+
+		forceUpdate := drifted || !plan.AssignmentVersion.Equal(state.AssignmentVersion)
 		computation, diags = UpdateDeploymentAssignments(ctx, receiver, plan, state, forceUpdate)
 		if util.TestDiagnostic(&response.Diagnostics, diags) {
 			return
@@ -305,6 +330,15 @@ func (receiver *DeploymentResource) Update(ctx context.Context, request resource
 		if util.TestDiagnostic(&response.Diagnostics, diags) {
 			return
 		}
+
+		// nothing was sent to Bamboo, so keep the assignments as they are known in state
+		plan.AssignmentVersion = state.AssignmentVersion
+		plan.Authoritative = state.Authoritative
+		plan.IgnorePrincipals = state.IgnorePrincipals
+		plan.MergeStrategy = state.MergeStrategy
+		plan.StrictPrincipals = state.StrictPrincipals
+		plan.Assignments = state.Assignments
+		plan.Roles = state.Roles
 	}
 
 	diags = receiver.UpdateLinkedRepositories(ctx, deploymentId, plan, state)
@@ -320,6 +354,90 @@ func (receiver *DeploymentResource) Update(ctx context.Context, request resource
 	}
 }
 
+func (receiver *DeploymentResource) ModifyPlan(ctx context.Context, request resource.ModifyPlanRequest, response *resource.ModifyPlanResponse) {
+	var (
+		diags diag.Diagnostics
+
+		plan, state DeploymentModel
+	)
+
+	// nothing to check on create and destroy
+	if request.State.Raw.IsNull() || request.Plan.Raw.IsNull() {
+		return
+	}
+
+	diags = request.Plan.Get(ctx, &plan)
+	if util.TestDiagnostic(&response.Diagnostics, diags) {
+		return
+	}
+
+	diags = request.State.Get(ctx, &state)
+	if util.TestDiagnostic(&response.Diagnostics, diags) {
+		return
+	}
+
+	if !state.RepositorySpecsManaged.ValueBool() {
+		diags = ModifyAssignmentPlan(ctx, request.State, &response.Plan)
+		if util.TestDiagnostic(&response.Diagnostics, diags) {
+			return
+		}
+
+		return
+	}
+
+	// a deployment managed by repository specs only accepts changes to its linked repositories
+	var changes = make([]string, 0)
+	if !plan.Name.Equal(state.Name) {
+		changes = append(changes, "name")
+	}
+
+	if !plan.PlanKey.Equal(state.PlanKey) {
+		changes = append(changes, "plan_key")
+	}
+
+	if !plan.Description.Equal(state.Description) {
+		changes = append(changes, "description")
+	}
+
+	if !plan.AssignmentVersion.Equal(state.AssignmentVersion) {
+		changes = append(changes, "assignment_version")
+	}
+
+	if !assignmentAuthoritative(plan.Authoritative).Equal(assignmentAuthoritative(state.Authoritative)) {
+		changes = append(changes, "authoritative")
+	}
+
+	if !plan.IgnorePrincipals.Equal(state.IgnorePrincipals) {
+		changes = append(changes, "ignore_principals")
+	}
+
+	if !assignmentMergeStrategy(plan.MergeStrategy).Equal(assignmentMergeStrategy(state.MergeStrategy)) {
+		changes = append(changes, "merge_strategy")
+	}
+
+	if !assignmentStrictPrincipals(plan.StrictPrincipals).Equal(assignmentStrictPrincipals(state.StrictPrincipals)) {
+		changes = append(changes, "strict_principals")
+	}
+
+	if !plan.Assignments.Equal(state.Assignments) {
+		changes = append(changes, "assignments")
+	}
+
+	if !rolesEqual(plan.Roles, state.Roles) {
+		changes = append(changes, "roles")
+	}
+
+	if len(changes) > 0 {
+		response.Diagnostics.AddError(
+			errorDeploymentManagedBySpecs,
+			fmt.Sprintf("Deployment %s is managed by repository specs, Bamboo will not accept changes to %s. Update the specs instead.",
+				state.Name.ValueString(),
+				strings.Join(changes, ", "),
+			),
+		)
+	}
+}
+
 func (receiver *DeploymentResource) UpdateLinkedRepositories(ctx context.Context, deploymentId int, plan DeploymentModel, state DeploymentModel) diag.Diagnostics {
 	var (
 		diags diag.Diagnostics
@@ -390,5 +508,22 @@ func (receiver *DeploymentResource) Delete(ctx context.Context, request resource
 }
 
 func (receiver *DeploymentResource) ImportState(ctx context.Context, request resource.ImportStateRequest, response *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("name"), request, response)
+	// the deployment can be imported either by its numeric id, or by its name using name:<deployment name>
+	if name, found := strings.CutPrefix(request.ID, "name:"); found {
+		diags := response.State.SetAttribute(ctx, path.Root("name"), name)
+		if util.TestDiagnostic(&response.Diagnostics, diags) {
+			return
+		}
+
+		return
+	}
+
+	_, err := strconv.Atoi(request.ID)
+	if err != nil {
+		response.Diagnostics.AddError(errorProvidedDeploymentIdMustBeNumber,
+			fmt.Sprintf("Import ID must be a numeric deployment id or name:<deployment name>, got %s", request.ID))
+		return
+	}
+
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), request, response)
 }
@@ -0,0 +1,264 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/yunarta/terraform-atlassian-api-client/bamboo"
+	"github.com/yunarta/terraform-provider-commons/util"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+var (
+	_ resource.Resource                = &DeploymentReleaseResource{}
+	_ resource.ResourceWithConfigure   = &DeploymentReleaseResource{}
+	_ resource.ResourceWithImportState = &DeploymentReleaseResource{}
+	_ ConfigurableReceiver             = &DeploymentReleaseResource{}
+	_ ExtendedConfigurableReceiver     = &DeploymentReleaseResource{}
+)
+
+func NewDeploymentReleaseResource() resource.Resource {
+	return &DeploymentReleaseResource{}
+}
+
+type DeploymentReleaseResource struct {
+	config         BambooProviderConfig
+	client         *bamboo.Client
+	extendedClient *ExtendedClient
+}
+
+func (receiver *DeploymentReleaseResource) setConfig(config BambooProviderConfig, client *bamboo.Client) {
+	receiver.config = config
+	receiver.client = client
+}
+
+func (receiver *DeploymentReleaseResource) setExtendedClient(extendedClient *ExtendedClient) {
+	receiver.extendedClient = extendedClient
+}
+
+func (receiver *DeploymentReleaseResource) Metadata(ctx context.Context, request resource.MetadataRequest, response *resource.MetadataResponse) {
+	response.TypeName = request.ProviderTypeName + "_deployment_release"
+}
+
+func (receiver *DeploymentReleaseResource) Schema(ctx context.Context, request resource.SchemaRequest, response *resource.SchemaResponse) {
+	response.Schema = schema.Schema{
+		MarkdownDescription: `This resource define a release of a deployment, created from a plan result.
+
+Changing the deployment, name or plan result key will create a new release.`,
+		Attributes: map[string]schema.Attribute{
+			"retain_on_delete": schema.BoolAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(true),
+				MarkdownDescription: "Default value is `true`, and if the value set to `false` when the resource destroyed, the release will be removed.",
+			},
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				MarkdownDescription: "Numeric id of the release.",
+			},
+			"deployment_id": schema.StringAttribute{
+				Required: true,
+				PlanModifiers: []planmodifier.String{
+					util.ReplaceIfStringDiff(),
+				},
+				MarkdownDescription: "Numeric id of the deployment.",
+			},
+			"name": schema.StringAttribute{
+				Required: true,
+				PlanModifiers: []planmodifier.String{
+					util.ReplaceIfStringDiff(),
+				},
+				MarkdownDescription: "Name of the release.",
+			},
+			"plan_result_key": schema.StringAttribute{
+				Required: true,
+				PlanModifiers: []planmodifier.String{
+					util.ReplaceIfStringDiff(),
+				},
+				MarkdownDescription: "Plan result key the release will be created from, for example `PROJ-PLAN-12`.",
+			},
+			"creation_date": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				MarkdownDescription: "Creation date of the release in RFC 3339 format.",
+			},
+		},
+	}
+}
+
+func (receiver *DeploymentReleaseResource) Configure(ctx context.Context, request resource.ConfigureRequest, response *resource.ConfigureResponse) {
+	ConfigureResource(receiver, ctx, request, response)
+}
+
+func (receiver *DeploymentReleaseResource) Create(ctx context.Context, request resource.CreateRequest, response *resource.CreateResponse) {
+	var (
+		diags diag.Diagnostics
+
+		plan DeploymentReleaseModel
+	)
+
+	diags = request.Plan.Get(ctx, &plan)
+	if util.TestDiagnostic(&response.Diagnostics, diags) {
+		return
+	}
+
+	deploymentId, err := strconv.Atoi(plan.DeploymentId.ValueString())
+	if util.TestError(&response.Diagnostics, err, errorProvidedDeploymentIdMustBeNumber) {
+		return
+	}
+
+	version, err := receiver.extendedClient.DeploymentService().CreateVersion(deploymentId, CreateDeploymentVersion{
+		Name:          plan.Name.ValueString(),
+		PlanResultKey: plan.PlanResultKey.ValueString(),
+	})
+	if util.TestError(&response.Diagnostics, err, errorFailedToCreateDeploymentRelease) {
+		return
+	}
+
+	diags = response.State.Set(ctx, NewDeploymentReleaseModel(plan, version))
+	if util.TestDiagnostic(&response.Diagnostics, diags) {
+		return
+	}
+}
+
+func (receiver *DeploymentReleaseResource) Read(ctx context.Context, request resource.ReadRequest, response *resource.ReadResponse) {
+	var (
+		diags diag.Diagnostics
+
+		state DeploymentReleaseModel
+	)
+
+	diags = request.State.Get(ctx, &state)
+	if util.TestDiagnostic(&response.Diagnostics, diags) {
+		return
+	}
+
+	versionId, err := strconv.Atoi(state.ID.ValueString())
+	if util.TestError(&response.Diagnostics, err, errorProvidedReleaseIdMustBeNumber) {
+		return
+	}
+
+	version, err := receiver.extendedClient.DeploymentService().ReadVersion(versionId)
+	if util.TestError(&response.Diagnostics, err, errorFailedToReadDeploymentRelease) {
+		return
+	}
+
+	if version == nil {
+		response.State.RemoveResource(ctx)
+		return
+	}
+
+	diags = response.State.Set(ctx, NewDeploymentReleaseModel(state, version))
+	if util.TestDiagnostic(&response.Diagnostics, diags) {
+		return
+	}
+}
+
+func (receiver *DeploymentReleaseResource) Update(ctx context.Context, request resource.UpdateRequest, response *resource.UpdateResponse) {
+	var (
+		diags diag.Diagnostics
+
+		plan, state DeploymentReleaseModel
+	)
+
+	diags = request.Plan.Get(ctx, &plan)
+	if util.TestDiagnostic(&response.Diagnostics, diags) {
+		return
+	}
+
+	diags = request.State.Get(ctx, &state)
+	if util.TestDiagnostic(&response.Diagnostics, diags) {
+		return
+	}
+
+	// only retain_on_delete can change in place, everything else replaces the release
+	state.RetainOnDelete = plan.RetainOnDelete
+
+	diags = response.State.Set(ctx, state)
+	if util.TestDiagnostic(&response.Diagnostics, diags) {
+		return
+	}
+}
+
+func (receiver *DeploymentReleaseResource) Delete(ctx context.Context, request resource.DeleteRequest, response *resource.DeleteResponse) {
+	var (
+		diags diag.Diagnostics
+
+		state DeploymentReleaseModel
+	)
+
+	diags = request.State.Get(ctx, &state)
+	if util.TestDiagnostic(&response.Diagnostics, diags) {
+		return
+	}
+
+	if !state.RetainOnDelete.ValueBool() {
+		versionId, err := strconv.Atoi(state.ID.ValueString())
+		if util.TestError(&response.Diagnostics, err, errorProvidedReleaseIdMustBeNumber) {
+			return
+		}
+
+		err = receiver.extendedClient.DeploymentService().DeleteVersion(versionId)
+		if util.TestError(&response.Diagnostics, err, errorFailedToDeleteDeploymentRelease) {
+			return
+		}
+	}
+
+	response.State.RemoveResource(ctx)
+}
+
+func (receiver *DeploymentReleaseResource) ImportState(ctx context.Context, request resource.ImportStateRequest, response *resource.ImportStateResponse) {
+	tokens := strings.Split(request.ID, "/")
+	if len(tokens) != 2 {
+		response.Diagnostics.AddError("Invalid import id", fmt.Sprintf("Expected <deployment id>/<release id>, got '%s'", request.ID))
+		return
+	}
+
+	deploymentId, err := strconv.Atoi(tokens[0])
+	if err != nil {
+		response.Diagnostics.AddError(errorProvidedDeploymentIdMustBeNumber, fmt.Sprintf("Expected <deployment id>/<release id>, got '%s'", request.ID))
+		return
+	}
+
+	versionId, err := strconv.Atoi(tokens[1])
+	if err != nil {
+		response.Diagnostics.AddError(errorProvidedReleaseIdMustBeNumber, fmt.Sprintf("Expected <deployment id>/<release id>, got '%s'", request.ID))
+		return
+	}
+
+	// Read finds the release by its id alone, so the deployment it is imported under is checked here
+	versions, err := receiver.extendedClient.DeploymentService().ReadVersions(deploymentId)
+	if util.TestError(&response.Diagnostics, err, errorFailedToReadDeploymentReleases) {
+		return
+	}
+
+	if !slices.ContainsFunc(versions, func(version DeploymentVersion) bool { return version.ID == versionId }) {
+		response.Diagnostics.AddError("Unable to find release", fmt.Sprintf("no release %d in deployment %d", versionId, deploymentId))
+		return
+	}
+
+	diags := response.State.Set(ctx, &DeploymentReleaseModel{
+		RetainOnDelete: types.BoolValue(true),
+		ID:             types.StringValue(tokens[1]),
+		DeploymentId:   types.StringValue(tokens[0]),
+		Name:           types.StringNull(),
+		PlanResultKey:  types.StringNull(),
+		CreationDate:   types.StringNull(),
+	})
+	if util.TestDiagnostic(&response.Diagnostics, diags) {
+		return
+	}
+}
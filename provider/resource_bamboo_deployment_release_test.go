@@ -0,0 +1,124 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/yunarta/terraform-atlassian-api-client/bamboo"
+	"github.com/yunarta/terraform-provider-bamboo/provider/test"
+)
+
+func newDeploymentReleaseVirtualization() *test.ServiceVirtualization {
+	virtualization := test.NewServiceVirtualization()
+	virtualization.AddDeployment(bamboo.Deployment{ID: 7, Name: "app-deploy", PlanKey: bamboo.Key{Key: "PROJ-APP"}})
+	virtualization.AddDeployment(bamboo.Deployment{ID: 8, Name: "other-deploy", PlanKey: bamboo.Key{Key: "PROJ-OTHER"}})
+	virtualization.AddRelease(test.Release{ID: 100, DeploymentId: 7, Name: "release-1", CreationDate: 1700000000000, PlanResultKey: "PROJ-APP-1"})
+	return virtualization
+}
+
+func TestDeploymentReleaseResource_CreateReadDelete(t *testing.T) {
+	tests := []struct {
+		name           string
+		retainOnDelete bool
+		wantRetained   bool
+	}{
+		{name: "retained on delete", retainOnDelete: true, wantRetained: true},
+		{name: "removed on delete", retainOnDelete: false, wantRetained: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			virtualization := newDeploymentReleaseVirtualization()
+			harness := newResourceHarness(t, NewDeploymentReleaseResource(), virtualization, testBambooRss(false))
+
+			state, diags := harness.create(harness.plan(map[string]any{
+				"retain_on_delete": tt.retainOnDelete,
+				"deployment_id":    "7",
+				"name":             "release-2",
+				"plan_result_key":  "PROJ-APP-2",
+			}))
+			harness.require(diags)
+
+			if got := harness.attribute(state, "id"); got != "1" {
+				t.Fatalf("Create() id = %q, want 1", got)
+			}
+
+			if got := harness.attribute(state, "creation_date"); got != "1970-01-01T00:00:01Z" {
+				t.Errorf("Create() creation_date = %q, want 1970-01-01T00:00:01Z", got)
+			}
+
+			state, diags = harness.read(state)
+			harness.require(diags)
+
+			if got := harness.attribute(state, "plan_result_key"); got != "PROJ-APP-2" {
+				t.Errorf("Read() plan_result_key = %q, want PROJ-APP-2", got)
+			}
+
+			harness.require(harness.delete(state))
+
+			if _, ok := virtualization.Release(1); ok != tt.wantRetained {
+				t.Errorf("Delete() release retained = %v, want %v", ok, tt.wantRetained)
+			}
+		})
+	}
+}
+
+func TestDeploymentReleaseResource_ReadRemoved(t *testing.T) {
+	virtualization := newDeploymentReleaseVirtualization()
+	harness := newResourceHarness(t, NewDeploymentReleaseResource(), virtualization, testBambooRss(false))
+
+	state, diags := harness.importState("7/100")
+	harness.require(diags)
+
+	// the release was removed outside of Terraform
+	virtualization.RemoveRelease(100)
+	state, diags = harness.read(state)
+	harness.require(diags)
+
+	if !state.Raw.IsNull() {
+		t.Errorf("Read() state = %v, want removed", state.Raw)
+	}
+}
+
+func TestDeploymentReleaseResource_ImportState(t *testing.T) {
+	tests := []struct {
+		name    string
+		id      string
+		wantErr string
+	}{
+		{name: "deployment and release id", id: "7/100"},
+		{name: "missing release id", id: "7", wantErr: "Invalid import id"},
+		{name: "deployment name", id: "app-deploy/100", wantErr: errorProvidedDeploymentIdMustBeNumber},
+		{name: "release name", id: "7/release-1", wantErr: errorProvidedReleaseIdMustBeNumber},
+		{name: "unknown release", id: "7/404", wantErr: "Unable to find release"},
+		// Read finds the release by its id alone, it would be recorded under the wrong deployment
+		{name: "release of another deployment", id: "8/100", wantErr: "Unable to find release"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			harness := newResourceHarness(t, NewDeploymentReleaseResource(), newDeploymentReleaseVirtualization(), testBambooRss(false))
+
+			state, diags := harness.importState(tt.id)
+			if tt.wantErr != "" {
+				if !diags.HasError() || diags.Errors()[0].Summary() != tt.wantErr {
+					t.Fatalf("ImportState() diagnostics = %v, want %q", diags, tt.wantErr)
+				}
+				return
+			}
+
+			harness.require(diags)
+
+			for name, want := range map[string]string{
+				"id":              "100",
+				"deployment_id":   "7",
+				"name":            "release-1",
+				"plan_result_key": "PROJ-APP-1",
+				"creation_date":   "2023-11-14T22:13:20Z",
+			} {
+				if got := harness.attribute(state, name); got != want {
+					t.Errorf("ImportState() %s = %q, want %q", name, got, want)
+				}
+			}
+		})
+	}
+}
@@ -4,7 +4,6 @@ import (
 	"context"
 	"fmt"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
-	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
@@ -12,7 +11,6 @@ import (
 	"github.com/yunarta/golang-quality-of-life-pack/collections"
 	"github.com/yunarta/terraform-atlassian-api-client/bamboo"
 	"github.com/yunarta/terraform-provider-commons/util"
-	"sort"
 	"strconv"
 )
 
@@ -20,6 +18,9 @@ type DeploymentRepositoriesModel struct {
 	RetainOnDelete types.Bool   `tfsdk:"retain_on_delete"`
 	ID             types.String `tfsdk:"id"`
 	Repositories   types.List   `tfsdk:"repositories"`
+
+	Repository           []RepositoryReferenceModel `tfsdk:"repository"`
+	ResolvedRepositories types.List                 `tfsdk:"resolved_repositories"`
 }
 
 var (
@@ -27,6 +28,7 @@ var (
 	_ resource.ResourceWithConfigure   = &DeploymentRepositoriesResource{}
 	_ resource.ResourceWithImportState = &DeploymentRepositoriesResource{}
 	_ ConfigurableReceiver             = &DeploymentRepositoriesResource{}
+	_ ExtendedConfigurableReceiver     = &DeploymentRepositoriesResource{}
 )
 
 func NewDeploymentRepositoryResource() resource.Resource {
@@ -34,8 +36,9 @@ func NewDeploymentRepositoryResource() resource.Resource {
 }
 
 type DeploymentRepositoriesResource struct {
-	config BambooProviderConfig
-	client *bamboo.Client
+	config         BambooProviderConfig
+	client         *bamboo.Client
+	extendedClient *ExtendedClient
 }
 
 func (receiver *DeploymentRepositoriesResource) setConfig(config BambooProviderConfig, client *bamboo.Client) {
@@ -43,6 +46,10 @@ func (receiver *DeploymentRepositoriesResource) setConfig(config BambooProviderC
 	receiver.client = client
 }
 
+func (receiver *DeploymentRepositoriesResource) setExtendedClient(extendedClient *ExtendedClient) {
+	receiver.extendedClient = extendedClient
+}
+
 func (receiver *DeploymentRepositoriesResource) Metadata(ctx context.Context, request resource.MetadataRequest, response *resource.MetadataResponse) {
 	response.TypeName = request.ProviderTypeName + "_deployment_repositories"
 }
@@ -51,7 +58,9 @@ func (receiver *DeploymentRepositoriesResource) Schema(ctx context.Context, requ
 	response.Schema = schema.Schema{
 		MarkdownDescription: `This resource define deployment repository spec permissions.
 
-In order for the execution to be successful, the user must have user access to all the specified repositories.`,
+In order for the execution to be successful, the user must have user access to all the specified repositories.
+
+Import with the numeric id of the deployment, the repositories currently granted access are listed by id in ` + "`repositories`" + `.`,
 		Attributes: map[string]schema.Attribute{
 			"retain_on_delete": schema.BoolAttribute{
 				Optional:            true,
@@ -64,10 +73,14 @@ In order for the execution to be successful, the user must have user access to a
 				MarkdownDescription: "Numeric id of the deployment.",
 			},
 			"repositories": schema.ListAttribute{
-				Required:            true,
+				Optional:            true,
 				ElementType:         types.StringType,
-				MarkdownDescription: "This deployment will add this list of linked repositories into its permission.",
+				MarkdownDescription: "This deployment will add this list of linked repositories, by numeric id or name, into its permission.",
 			},
+			"resolved_repositories": ResolvedRepositoriesSchema("repositories", "repository"),
+		},
+		Blocks: map[string]schema.Block{
+			"repository": RepositoryReferenceBlock("Linked repository to add into the permission, referenced by `id`, `name`, or `project_key` and `name`."),
 		},
 	}
 }
@@ -78,10 +91,9 @@ func (receiver *DeploymentRepositoriesResource) Configure(ctx context.Context, r
 
 func (receiver *DeploymentRepositoriesResource) Create(ctx context.Context, request resource.CreateRequest, response *resource.CreateResponse) {
 	var (
-		plan                    DeploymentRepositoriesModel
-		diags                   diag.Diagnostics
-		err                     error
-		deploymentRepositoryIDs = make([]string, 0)
+		plan  DeploymentRepositoriesModel
+		diags diag.Diagnostics
+		err   error
 	)
 
 	diags = request.Plan.Get(ctx, &plan)
@@ -94,27 +106,35 @@ func (receiver *DeploymentRepositoriesResource) Create(ctx context.Context, requ
 		return
 	}
 
-	diags = plan.Repositories.ElementsAs(ctx, &deploymentRepositoryIDs, true)
+	references, diags := NewRepositoryReferences(ctx, plan.Repositories, plan.Repository)
 	if util.TestDiagnostic(&response.Diagnostics, diags) {
 		return
 	}
 
-	for _, repository := range deploymentRepositoryIDs {
-		repositoryId, err := strconv.Atoi(repository)
-		if util.TestError(&response.Diagnostics, err, errorProvidedRepositoryMustBeNumber) {
-			return
-		}
+	resolved, diags := NewRepositoryResolver(receiver.client, receiver.extendedClient).ResolveAll(references)
+	if util.TestDiagnostic(&response.Diagnostics, diags) {
+		return
+	}
 
+	for _, repository := range resolvedIds(resolved) {
+		repositoryId, _ := strconv.Atoi(repository)
 		_, err = receiver.client.DeploymentService().AddSpecRepositories(deploymentId, repositoryId)
 		if util.TestError(&response.Diagnostics, err, "Failed to add deployment repository") {
 			return
 		}
 	}
 
+	resolvedRepositories, diags := resolvedList(ctx, resolved)
+	if util.TestDiagnostic(&response.Diagnostics, diags) {
+		return
+	}
+
 	diags = response.State.Set(ctx, &DeploymentRepositoriesModel{
-		RetainOnDelete: plan.RetainOnDelete,
-		ID:             types.StringValue(strconv.Itoa(deploymentId)),
-		Repositories:   plan.Repositories,
+		RetainOnDelete:       plan.RetainOnDelete,
+		ID:                   types.StringValue(strconv.Itoa(deploymentId)),
+		Repositories:         plan.Repositories,
+		Repository:           references.Blocks,
+		ResolvedRepositories: resolvedRepositories,
 	})
 	if util.TestDiagnostic(&response.Diagnostics, diags) {
 		return
@@ -123,10 +143,9 @@ func (receiver *DeploymentRepositoriesResource) Create(ctx context.Context, requ
 
 func (receiver *DeploymentRepositoriesResource) Read(ctx context.Context, request resource.ReadRequest, response *resource.ReadResponse) {
 	var (
-		state                   DeploymentRepositoriesModel
-		diags                   diag.Diagnostics
-		err                     error
-		deploymentRepositoryIDs = make([]string, 0)
+		state DeploymentRepositoriesModel
+		diags diag.Diagnostics
+		err   error
 	)
 
 	diags = request.State.Get(ctx, &state)
@@ -145,20 +164,33 @@ func (receiver *DeploymentRepositoriesResource) Read(ctx context.Context, reques
 		return
 	}
 
-	for _, repository := range repositories {
-		deploymentRepositoryIDs = append(deploymentRepositoryIDs, strconv.Itoa(repository.ID))
+	references, diags := NewRepositoryReferences(ctx, state.Repositories, state.Repository)
+	if util.TestDiagnostic(&response.Diagnostics, diags) {
+		return
+	}
+
+	// the repositories granted outside of this resource are reported by id
+	refreshed, resolved, diags := NewRepositoryResolver(receiver.client, receiver.extendedClient).Refresh(references, repositories, true)
+	if util.TestDiagnostic(&response.Diagnostics, diags) {
+		return
 	}
 
-	sort.Strings(deploymentRepositoryIDs)
-	listValue, diags := types.ListValueFrom(ctx, types.StringType, deploymentRepositoryIDs)
+	listValue, diags := refreshed.List(ctx)
+	if util.TestDiagnostic(&response.Diagnostics, diags) {
+		return
+	}
+
+	resolvedRepositories, diags := resolvedList(ctx, resolved)
 	if util.TestDiagnostic(&response.Diagnostics, diags) {
 		return
 	}
 
 	diags = response.State.Set(ctx, &DeploymentRepositoriesModel{
-		RetainOnDelete: state.RetainOnDelete,
-		ID:             types.StringValue(fmt.Sprintf("%v", deploymentId)),
-		Repositories:   listValue,
+		RetainOnDelete:       state.RetainOnDelete,
+		ID:                   types.StringValue(fmt.Sprintf("%v", deploymentId)),
+		Repositories:         listValue,
+		Repository:           refreshed.Blocks,
+		ResolvedRepositories: resolvedRepositories,
 	})
 	if util.TestDiagnostic(&response.Diagnostics, diags) {
 		return
@@ -167,18 +199,14 @@ func (receiver *DeploymentRepositoriesResource) Read(ctx context.Context, reques
 
 func (receiver *DeploymentRepositoriesResource) Update(ctx context.Context, request resource.UpdateRequest, response *resource.UpdateResponse) {
 	var (
-		plan, state           DeploymentRepositoriesModel
-		incomingRepositoryIDs = make([]string, 0)
-		existingRepositoryIDs = make([]string, 0)
-		diags                 diag.Diagnostics
-		err                   error
+		plan, state DeploymentRepositoriesModel
+		diags       diag.Diagnostics
+		err         error
 	)
 
 	if util.TestDiagnostics(&response.Diagnostics,
 		request.Plan.Get(ctx, &plan),
 		request.State.Get(ctx, &state),
-		plan.Repositories.ElementsAs(ctx, &incomingRepositoryIDs, true),
-		state.Repositories.ElementsAs(ctx, &existingRepositoryIDs, true),
 	) {
 		return
 	}
@@ -188,13 +216,30 @@ func (receiver *DeploymentRepositoriesResource) Update(ctx context.Context, requ
 		return
 	}
 
-	adding, removing := collections.Delta(existingRepositoryIDs, incomingRepositoryIDs)
-	for _, repository := range adding {
-		repositoryId, err := strconv.Atoi(repository)
-		if util.TestError(&response.Diagnostics, err, errorProvidedRepositoryMustBeNumber) {
-			return
-		}
+	plannedReferences, diags := NewRepositoryReferences(ctx, plan.Repositories, plan.Repository)
+	if util.TestDiagnostic(&response.Diagnostics, diags) {
+		return
+	}
+
+	inStateReferences, diags := NewRepositoryReferences(ctx, state.Repositories, state.Repository)
+	if util.TestDiagnostic(&response.Diagnostics, diags) {
+		return
+	}
 
+	resolver := NewRepositoryResolver(receiver.client, receiver.extendedClient)
+	resolved, diags := resolver.ResolveAll(plannedReferences)
+	if util.TestDiagnostic(&response.Diagnostics, diags) {
+		return
+	}
+
+	existingRepositoryIDs, diags := resolver.Owned(ctx, state.ResolvedRepositories, inStateReferences)
+	if util.TestDiagnostic(&response.Diagnostics, diags) {
+		return
+	}
+
+	adding, removing := collections.Delta(existingRepositoryIDs, resolvedIds(resolved))
+	for _, repository := range adding {
+		repositoryId, _ := strconv.Atoi(repository)
 		_, err = receiver.client.DeploymentService().AddSpecRepositories(deploymentId, repositoryId)
 		if util.TestError(&response.Diagnostics, err, "Failed to add deployment repositories") {
 			return
@@ -202,21 +247,24 @@ func (receiver *DeploymentRepositoriesResource) Update(ctx context.Context, requ
 	}
 
 	for _, repository := range removing {
-		repositoryId, err := strconv.Atoi(repository)
-		if util.TestError(&response.Diagnostics, err, errorProvidedRepositoryMustBeNumber) {
-			return
-		}
-
+		repositoryId, _ := strconv.Atoi(repository)
 		err = receiver.client.DeploymentService().RemoveSpecRepositories(deploymentId, repositoryId)
 		if util.TestError(&response.Diagnostics, err, "Failed to remove deployment repositories") {
 			return
 		}
 	}
 
+	resolvedRepositories, diags := resolvedList(ctx, resolved)
+	if util.TestDiagnostic(&response.Diagnostics, diags) {
+		return
+	}
+
 	diags = response.State.Set(ctx, &DeploymentRepositoriesModel{
-		RetainOnDelete: plan.RetainOnDelete,
-		ID:             types.StringValue(strconv.Itoa(deploymentId)),
-		Repositories:   plan.Repositories,
+		RetainOnDelete:       plan.RetainOnDelete,
+		ID:                   types.StringValue(strconv.Itoa(deploymentId)),
+		Repositories:         plan.Repositories,
+		Repository:           plannedReferences.Blocks,
+		ResolvedRepositories: resolvedRepositories,
 	})
 	if util.TestDiagnostic(&response.Diagnostics, diags) {
 		return
@@ -225,10 +273,9 @@ func (receiver *DeploymentRepositoriesResource) Update(ctx context.Context, requ
 
 func (receiver *DeploymentRepositoriesResource) Delete(ctx context.Context, request resource.DeleteRequest, response *resource.DeleteResponse) {
 	var (
-		state                 DeploymentRepositoriesModel
-		existingRepositoryIDs []string
-		diags                 diag.Diagnostics
-		err                   error
+		state DeploymentRepositoriesModel
+		diags diag.Diagnostics
+		err   error
 	)
 
 	diags = request.State.Get(ctx, &state)
@@ -243,17 +290,18 @@ func (receiver *DeploymentRepositoriesResource) Delete(ctx context.Context, requ
 			return
 		}
 
-		diags = state.Repositories.ElementsAs(ctx, &existingRepositoryIDs, true)
+		references, diags := NewRepositoryReferences(ctx, state.Repositories, state.Repository)
 		if util.TestDiagnostic(&response.Diagnostics, diags) {
 			return
 		}
 
-		for _, repository := range existingRepositoryIDs {
-			repositoryId, err := strconv.Atoi(repository)
-			if util.TestError(&response.Diagnostics, err, errorProvidedRepositoryMustBeNumber) {
-				return
-			}
+		existingRepositoryIDs, diags := NewRepositoryResolver(receiver.client, receiver.extendedClient).Owned(ctx, state.ResolvedRepositories, references)
+		if util.TestDiagnostic(&response.Diagnostics, diags) {
+			return
+		}
 
+		for _, repository := range existingRepositoryIDs {
+			repositoryId, _ := strconv.Atoi(repository)
 			err = receiver.client.DeploymentService().RemoveSpecRepositories(deploymentId, repositoryId)
 			if util.TestError(&response.Diagnostics, err, "Failed to remove deployment repositories") {
 				return
@@ -265,5 +313,27 @@ func (receiver *DeploymentRepositoriesResource) Delete(ctx context.Context, requ
 }
 
 func (receiver *DeploymentRepositoriesResource) ImportState(ctx context.Context, request resource.ImportStateRequest, response *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), request, response)
+	deploymentId, err := strconv.Atoi(request.ID)
+	if util.TestError(&response.Diagnostics, err, errorProvidedDeploymentIdMustBeNumber) {
+		return
+	}
+
+	granted, err := receiver.client.DeploymentService().GetSpecRepositories(deploymentId)
+	if util.TestError(&response.Diagnostics, err, errorFailedToReadDeploymentRepositories) {
+		return
+	}
+
+	repositories, resolvedRepositories, diags := importedReferences(ctx, granted)
+	if util.TestDiagnostic(&response.Diagnostics, diags) {
+		return
+	}
+
+	diags = response.State.Set(ctx, &DeploymentRepositoriesModel{
+		RetainOnDelete:       types.BoolValue(true),
+		ID:                   types.StringValue(strconv.Itoa(deploymentId)),
+		Repositories:         repositories,
+		Repository:           make([]RepositoryReferenceModel, 0),
+		ResolvedRepositories: resolvedRepositories,
+	})
+	util.TestDiagnostic(&response.Diagnostics, diags)
 }
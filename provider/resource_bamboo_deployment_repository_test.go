@@ -0,0 +1,23 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/yunarta/terraform-atlassian-api-client/bamboo"
+)
+
+func TestDeploymentRepositoryResource_ImportState(t *testing.T) {
+	virtualization := newAccessVirtualization()
+	virtualization.AddDeployment(bamboo.Deployment{ID: 7, Name: "Application Deployment"})
+	virtualization.GrantAccess("deployment/7", 4)
+	virtualization.GrantAccess("deployment/7", 1)
+
+	harness := newResourceHarness(t, NewDeploymentRepositoryResource(), virtualization, testBambooRss(false))
+	state, diags := harness.importState("7")
+	harness.require(diags)
+
+	requireImportedReferences(t, harness, state,
+		[]RepositoryReferenceData{{Id: "1", Name: "app"}, {Id: "4", Name: "other"}},
+		map[string]any{"id": "7", "repositories": []string{"1", "4"}},
+	)
+}
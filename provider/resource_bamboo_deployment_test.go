@@ -0,0 +1,185 @@
+package provider
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/yunarta/terraform-atlassian-api-client/bamboo"
+	"github.com/yunarta/terraform-provider-bamboo/provider/test"
+)
+
+func newDeploymentVirtualization() *test.ServiceVirtualization {
+	virtualization := test.NewServiceVirtualization()
+	virtualization.AddDeployment(bamboo.Deployment{ID: 7, Name: "app-deploy", PlanKey: bamboo.Key{Key: "PROJ-APP"}})
+	virtualization.AddDeployment(bamboo.Deployment{ID: 8, Name: "app-deploy-legacy", PlanKey: bamboo.Key{Key: "PROJ-LEGACY"}})
+	return virtualization
+}
+
+func TestDeploymentResource_ImportState(t *testing.T) {
+	tests := []struct {
+		name     string
+		id       string
+		wantId   string
+		wantName string
+		wantErr  string
+	}{
+		{name: "numeric id", id: "7", wantId: "7", wantName: "app-deploy"},
+		{name: "name", id: "name:app-deploy", wantId: "7", wantName: "app-deploy"},
+		{name: "unknown name", id: "name:app-deploy-missing", wantErr: "Unable to find deployment"},
+		{name: "not an id", id: "app-deploy", wantErr: errorProvidedDeploymentIdMustBeNumber},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			harness := newResourceHarness(t, NewDeploymentResource(), newDeploymentVirtualization(), testBambooRss(false))
+
+			state, diags := harness.importState(tt.id)
+			if tt.wantErr != "" {
+				if !diags.HasError() || diags.Errors()[0].Summary() != tt.wantErr {
+					t.Fatalf("ImportState() diagnostics = %v, want %q", diags, tt.wantErr)
+				}
+				return
+			}
+
+			harness.require(diags)
+
+			if got := harness.attribute(state, "id"); got != tt.wantId {
+				t.Errorf("ImportState() id = %q, want %q", got, tt.wantId)
+			}
+
+			if got := harness.attribute(state, "name"); got != tt.wantName {
+				t.Errorf("ImportState() name = %q, want %q", got, tt.wantName)
+			}
+
+			if got := harness.attribute(state, "plan_key"); got != "PROJ-APP" {
+				t.Errorf("ImportState() plan_key = %q, want PROJ-APP", got)
+			}
+		})
+	}
+}
+
+func TestDeploymentResource_Rename(t *testing.T) {
+	virtualization := newDeploymentVirtualization()
+
+	harness := newResourceHarness(t, NewDeploymentResource(), virtualization, testBambooRss(false))
+	state, diags := harness.importState("7")
+	harness.require(diags)
+
+	state, diags = harness.update(harness.planFrom(state, map[string]any{
+		"name": "app-deploy-renamed",
+	}), state)
+	harness.require(diags)
+
+	if got := harness.attribute(state, "id"); got != "7" {
+		t.Errorf("Update() id = %q, want 7", got)
+	}
+
+	deployment, ok := virtualization.Deployment(7)
+	if !ok || deployment.Name != "app-deploy-renamed" {
+		t.Errorf("Update() deployment = %v, want renamed in place", deployment)
+	}
+}
+
+func TestDeploymentResource_PlanModifiers(t *testing.T) {
+	ctx := context.Background()
+	response := &resource.SchemaResponse{}
+	NewDeploymentResource().Schema(ctx, resource.SchemaRequest{}, response)
+
+	tests := []struct {
+		name        string
+		attribute   string
+		state       types.String
+		plan        types.String
+		wantPlan    types.String
+		wantReplace bool
+	}{
+		// the id stays known on update, so a rename does not show it as known after apply
+		{name: "id kept on update", attribute: "id", state: types.StringValue("7"), plan: types.StringUnknown(), wantPlan: types.StringValue("7")},
+		// a rename is done in place
+		{name: "rename in place", attribute: "name", state: types.StringValue("app-deploy"), plan: types.StringValue("app-deploy-renamed"), wantPlan: types.StringValue("app-deploy-renamed")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			attribute := response.Schema.Attributes[tt.attribute].(schema.StringAttribute)
+
+			modified := &planmodifier.StringResponse{PlanValue: tt.plan}
+			for _, modifier := range attribute.PlanModifiers {
+				modifier.PlanModifyString(ctx, planmodifier.StringRequest{
+					StateValue:  tt.state,
+					PlanValue:   modified.PlanValue,
+					ConfigValue: types.StringNull(),
+				}, modified)
+			}
+
+			if !modified.PlanValue.Equal(tt.wantPlan) {
+				t.Errorf("PlanModifyString() plan = %v, want %v", modified.PlanValue, tt.wantPlan)
+			}
+
+			if modified.RequiresReplace != tt.wantReplace {
+				t.Errorf("PlanModifyString() requires replace = %v, want %v", modified.RequiresReplace, tt.wantReplace)
+			}
+		})
+	}
+}
+
+func TestDeploymentResource_ModifyPlanSpecsManaged(t *testing.T) {
+	tests := []struct {
+		name       string
+		attributes map[string]any
+		wantErr    bool
+	}{
+		{name: "rename", attributes: map[string]any{"name": "app-deploy-renamed"}, wantErr: true},
+		{name: "description", attributes: map[string]any{"description": "deploys the app"}, wantErr: true},
+		{name: "plan key", attributes: map[string]any{"plan_key": "PROJ-OTHER"}, wantErr: true},
+		{name: "repositories", attributes: map[string]any{"repositories": []string{"1", "2"}}},
+		{name: "no change", attributes: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			virtualization := test.NewServiceVirtualization()
+			virtualization.AddDeployment(bamboo.Deployment{ID: 7, Name: "app-deploy", PlanKey: bamboo.Key{Key: "PROJ-APP"}, RepositorySpecsManaged: true})
+			virtualization.AddRepository(test.Repository{ID: 1, Name: "app-specs"})
+			virtualization.AddRepository(test.Repository{ID: 2, Name: "app-deploy-specs"})
+			virtualization.GrantAccess("deployment/7", 1)
+
+			harness := newResourceHarness(t, NewDeploymentResource(), virtualization, testBambooRss(false))
+			state, diags := harness.importState("7")
+			harness.require(diags)
+
+			plan, diags := harness.modifyPlan(harness.planFrom(state, tt.attributes), state)
+			if tt.wantErr {
+				if !diags.HasError() || diags.Errors()[0].Summary() != errorDeploymentManagedBySpecs {
+					t.Fatalf("ModifyPlan() diagnostics = %v, want %q", diags, errorDeploymentManagedBySpecs)
+				}
+				return
+			}
+
+			harness.require(diags)
+
+			state, diags = harness.update(plan, state)
+			harness.require(diags)
+
+			var repositories []string
+			harness.require(state.GetAttribute(context.Background(), path.Root("repositories"), &repositories))
+			if tt.attributes != nil && !reflect.DeepEqual(repositories, []string{"1", "2"}) {
+				t.Errorf("Update() repositories = %v, want [1 2]", repositories)
+			}
+
+			if got := virtualization.Access("deployment/7"); tt.attributes != nil && !reflect.DeepEqual(got, []int{1, 2}) {
+				t.Errorf("Update() granted repositories = %v, want [1 2]", got)
+			}
+
+			if deployment, _ := virtualization.Deployment(7); deployment.Name != "app-deploy" || deployment.PlanKey.Key != "PROJ-APP" {
+				t.Errorf("Update() deployment = %v, want left to the specs", deployment)
+			}
+		})
+	}
+}
@@ -3,16 +3,18 @@ package provider
 import (
 	"context"
 	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"strconv"
-	"strings"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/yunarta/terraform-atlassian-api-client/bamboo"
 	"github.com/yunarta/terraform-provider-commons/util"
@@ -22,8 +24,11 @@ var (
 	_ resource.Resource                   = &LinkedRepositoryResource{}
 	_ resource.ResourceWithConfigure      = &LinkedRepositoryResource{}
 	_ resource.ResourceWithImportState    = &LinkedRepositoryResource{}
+	_ resource.ResourceWithModifyPlan     = &LinkedRepositoryResource{}
+	_ resource.ResourceWithValidateConfig = &LinkedRepositoryResource{}
 	_ LinkedRepositoryPermissionsReceiver = &LinkedRepositoryResource{}
 	_ ConfigurableReceiver                = &LinkedRepositoryResource{}
+	_ ExtendedConfigurableReceiver        = &LinkedRepositoryResource{}
 )
 
 func NewLinkedRepositoryResource() resource.Resource {
@@ -31,8 +36,9 @@ func NewLinkedRepositoryResource() resource.Resource {
 }
 
 type LinkedRepositoryResource struct {
-	config BambooProviderConfig
-	client *bamboo.Client
+	config         BambooProviderConfig
+	client         *bamboo.Client
+	extendedClient *ExtendedClient
 }
 
 func (receiver *LinkedRepositoryResource) setConfig(config BambooProviderConfig, client *bamboo.Client) {
@@ -40,6 +46,10 @@ func (receiver *LinkedRepositoryResource) setConfig(config BambooProviderConfig,
 	receiver.client = client
 }
 
+func (receiver *LinkedRepositoryResource) setExtendedClient(extendedClient *ExtendedClient) {
+	receiver.extendedClient = extendedClient
+}
+
 func (receiver *LinkedRepositoryResource) getClient() *bamboo.Client {
 	return receiver.client
 }
@@ -54,8 +64,12 @@ func (receiver *LinkedRepositoryResource) Schema(ctx context.Context, request re
 
 One of the main focus of this resource is the permission management, which usually overlooked when creating linked repository through GUI.
 
-The priority block has a priority that defines the final assigned permissions of the user or group.`,
-		Attributes: map[string]schema.Attribute{
+The priority block has a priority that defines the final assigned permissions of the user or group.
+
+The ` + "`type`" + ` attribute selects the source of the repository, ` + "`bitbucket_server`" + ` uses the ` + "`bamboo_rss`" + ` provider settings with ` + "`project`" + ` and ` + "`slug`" + `,
+while ` + "`git`" + `, ` + "`github`" + ` and ` + "`gitlab`" + ` are configured with the block of the same name.
+On import the type and the source repository are read from Bamboo, except the SSH key of a ` + "`git`" + ` repository which Bamboo does not return.`,
+		Attributes: withSpecsScanAttributes(map[string]schema.Attribute{
 			"id": schema.StringAttribute{
 				Computed:            true,
 				MarkdownDescription: "Numeric id of the linked repository.",
@@ -67,37 +81,198 @@ The priority block has a priority that defines the final assigned permissions of
 				},
 				MarkdownDescription: "Name of the linked repository.",
 			},
+			"retain_on_delete": schema.BoolAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(true),
+				MarkdownDescription: "Default value is `true`, and if the value set to `false` when the resource destroyed, the linked repository will be removed.",
+			},
+			"force_delete": schema.BoolAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+				MarkdownDescription: "Default value is `false`, the linked repository is only removed when no plan or deployment uses it, and the destroy fails with the list of users otherwise. Set to `true` to remove it regardless.",
+			},
+			"adopt_existing": schema.BoolAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+				MarkdownDescription: "Default value is `false`, and if the value set to `true` when a linked repository with the same name already exists, the resource will take over the repository, update it to the planned source repository and apply the assignments instead of failing.",
+			},
+			"type": schema.StringAttribute{
+				Optional: true,
+				Computed: true,
+				Default:  stringdefault.StaticString(linkedRepositoryTypeBitbucketServer),
+				Validators: []validator.String{
+					stringvalidator.OneOf(
+						linkedRepositoryTypeBitbucketServer,
+						linkedRepositoryTypeGit,
+						linkedRepositoryTypeGitHub,
+						linkedRepositoryTypeGitLab,
+					),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplaceIf(linkedRepositoryTypeCheck, "", ""),
+				},
+				MarkdownDescription: "Type of the linked repository (bitbucket_server, git, github, gitlab). Default value is `bitbucket_server`, changing the type will recreate the linked repository.",
+			},
 			"rss_enabled": schema.BoolAttribute{
 				Optional:            true,
 				MarkdownDescription: "Flag to modify Bamboo Spec flag after creation.",
 			},
 			"project": schema.StringAttribute{
-				Required:            true,
-				MarkdownDescription: "Bitbucket project key that owns the Git repository.",
+				Optional:            true,
+				MarkdownDescription: "Bitbucket project key that owns the Git repository, required when `type` is `bitbucket_server`.",
 			},
 			"slug": schema.StringAttribute{
-				Required:            true,
-				MarkdownDescription: "Bitbucket repository slug.",
+				Optional:            true,
+				MarkdownDescription: "Bitbucket repository slug, required when `type` is `bitbucket_server`.",
 			},
 			"branch": schema.StringAttribute{
 				Optional:            true,
 				Computed:            true,
-				MarkdownDescription: "Bitbucket repository branch.",
+				MarkdownDescription: "Repository branch.",
 				Default:             stringdefault.StaticString("master"),
 			},
-			"assignment_version": schema.StringAttribute{
-				Optional:            true,
-				MarkdownDescription: "Assignment version, used to force update the permission.",
-			},
-			"computed_users":  ComputedAssignmentSchema,
-			"computed_groups": ComputedAssignmentSchema,
-		},
+			"assignment_version": AssignmentVersionSchema,
+			"authoritative":      AuthoritativeSchema,
+			"ignore_principals":  IgnorePrincipalsSchema,
+			"merge_strategy":     MergeStrategySchema,
+			"strict_principals":  StrictPrincipalsSchema,
+			"computed_users":     ComputedAssignmentSchema,
+			"computed_groups":    ComputedAssignmentSchema,
+		}),
 		Blocks: map[string]schema.Block{
-			"assignments": AssignmentSchema("READ", "ADMINISTRATION"),
+			"assignments":      AssignmentSchema("READ", "ADMINISTRATION"),
+			"roles":            RolesSchema([]string{"READ", "ADMINISTRATION"}, nil),
+			"change_detection": ChangeDetectionSchema,
+			"git": schema.SingleNestedBlock{
+				MarkdownDescription: "Plain Git repository, used when `type` is `git`. Authenticate with either `shared_credential` or `ssh_key`.",
+				Attributes: map[string]schema.Attribute{
+					"url": schema.StringAttribute{
+						Optional:            true,
+						MarkdownDescription: "Clone URL of the Git repository.",
+					},
+					"shared_credential": schema.StringAttribute{
+						Optional:            true,
+						MarkdownDescription: "Name of the Bamboo shared credential used to authenticate.",
+					},
+					"ssh_key": schema.StringAttribute{
+						Optional:            true,
+						Sensitive:           true,
+						MarkdownDescription: "SSH private key used to authenticate.",
+					},
+					"ssh_passphrase": schema.StringAttribute{
+						Optional:            true,
+						Sensitive:           true,
+						MarkdownDescription: "Passphrase of the SSH private key.",
+					},
+				},
+			},
+			"github": schema.SingleNestedBlock{
+				MarkdownDescription: "GitHub repository, used when `type` is `github`.",
+				Attributes: map[string]schema.Attribute{
+					"repository": schema.StringAttribute{
+						Optional:            true,
+						MarkdownDescription: "GitHub repository in the form of `owner/name`.",
+					},
+					"token_credential": schema.StringAttribute{
+						Optional:            true,
+						MarkdownDescription: "Name of the Bamboo shared credential holding the GitHub access token.",
+					},
+				},
+			},
+			"gitlab": schema.SingleNestedBlock{
+				MarkdownDescription: "GitLab project, used when `type` is `gitlab`. The project is linked as a Git repository, so Bamboo cannot tell it apart and an imported GitLab project is read as type `git` with its clone URL in the `git` block.",
+				Attributes: map[string]schema.Attribute{
+					"url": schema.StringAttribute{
+						Optional:            true,
+						MarkdownDescription: "GitLab server URL, default value is `https://gitlab.com`.",
+					},
+					"project": schema.StringAttribute{
+						Optional:            true,
+						MarkdownDescription: "Path of the GitLab project, for example `group/project`.",
+					},
+					"token_credential": schema.StringAttribute{
+						Optional:            true,
+						MarkdownDescription: "Name of the Bamboo shared credential holding the GitLab access token.",
+					},
+				},
+			},
 		},
 	}
 }
 
+func (receiver *LinkedRepositoryResource) ValidateConfig(ctx context.Context, request resource.ValidateConfigRequest, response *resource.ValidateConfigResponse) {
+	var config LinkedRepositoryModel
+
+	diags := request.Config.Get(ctx, &config)
+	if util.TestDiagnostic(&response.Diagnostics, diags) {
+		return
+	}
+
+	if config.Type.IsUnknown() {
+		return
+	}
+
+	var requireAttribute = func(value types.String, attributePath path.Path) {
+		if value.IsNull() {
+			response.Diagnostics.AddAttributeError(attributePath, "Missing required attribute",
+				fmt.Sprintf("%s is required when type is %s", attributePath, config.getType()))
+		}
+	}
+
+	var requireBlock = func(present bool, block string) bool {
+		if !present {
+			response.Diagnostics.AddAttributeError(path.Root(block), "Missing required block",
+				fmt.Sprintf("%s block is required when type is %s", block, config.getType()))
+		}
+
+		return present
+	}
+
+	switch config.getType() {
+	case linkedRepositoryTypeBitbucketServer:
+		requireAttribute(config.Project, path.Root("project"))
+		requireAttribute(config.Slug, path.Root("slug"))
+	case linkedRepositoryTypeGit:
+		if requireBlock(config.Git != nil, "git") {
+			requireAttribute(config.Git.Url, path.Root("git").AtName("url"))
+			if config.Git.SharedCredential.IsNull() == config.Git.SshKey.IsNull() {
+				response.Diagnostics.AddAttributeError(path.Root("git"), "Invalid authentication",
+					"exactly one of shared_credential or ssh_key must be set")
+			}
+		}
+	case linkedRepositoryTypeGitHub:
+		if requireBlock(config.GitHub != nil, "github") {
+			requireAttribute(config.GitHub.Repository, path.Root("github").AtName("repository"))
+			requireAttribute(config.GitHub.TokenCredential, path.Root("github").AtName("token_credential"))
+		}
+	case linkedRepositoryTypeGitLab:
+		if requireBlock(config.GitLab != nil, "gitlab") {
+			requireAttribute(config.GitLab.Project, path.Root("gitlab").AtName("project"))
+			requireAttribute(config.GitLab.TokenCredential, path.Root("gitlab").AtName("token_credential"))
+		}
+	}
+}
+
+func linkedRepositoryTypeCheck(ctx context.Context, request planmodifier.StringRequest, response *stringplanmodifier.RequiresReplaceIfFuncResponse) {
+	var plan, state LinkedRepositoryModel
+
+	diags := request.Plan.Get(ctx, &plan)
+	if util.TestDiagnostic(&response.Diagnostics, diags) {
+		return
+	}
+
+	diags = request.State.Get(ctx, &state)
+	if util.TestDiagnostic(&response.Diagnostics, diags) {
+		return
+	}
+
+	// repositories created before the type attribute existed are Bitbucket Server repositories
+	response.RequiresReplace = plan.getType() != state.getType() && !request.State.Raw.IsNull()
+}
+
 func linkedRepositoryNameCheck(ctx context.Context, request planmodifier.StringRequest, response *stringplanmodifier.RequiresReplaceIfFuncResponse) {
 	var plan, state LinkedRepositoryModel
 
@@ -125,45 +300,42 @@ func (receiver *LinkedRepositoryResource) Create(ctx context.Context, request re
 		plan LinkedRepositoryModel
 	)
 
-	repository, err := receiver.client.RepositoryService().Read(plan.Name.ValueString())
-	if err == nil && repository != nil {
-		response.Diagnostics.AddError("linked repository already exists", "Unable to create as the requested repository already exists, manual deletion of linked repository may be required")
-		return
-	}
-
 	diags = request.Plan.Get(ctx, &plan)
 	if util.TestDiagnostic(&response.Diagnostics, diags) {
 		return
 	}
 
-	repositoryId, err := receiver.client.RepositoryService().Create(bamboo.CreateRepository{
-		Name:             plan.Name.ValueString(),
-		ProjectKey:       strings.ToLower(plan.Project.ValueString()),
-		RepositorySlug:   strings.ToLower(plan.Slug.ValueString()),
-		RepositoryBranch: strings.ToLower(plan.Branch.ValueString()),
-		ServerId:         receiver.config.BambooRss.Server.ValueString(),
-		ServerName:       receiver.config.BambooRss.Name.ValueString(),
-		CloneUrl: strings.ToLower(fmt.Sprintf(
-			receiver.config.BambooRss.CloneUrl.ValueString(),
-			plan.Project.ValueString(),
-			plan.Slug.ValueString(),
-		)),
-	})
+	repository, err := receiver.client.RepositoryService().Read(plan.Name.ValueString())
 	if util.TestError(&response.Diagnostics, err, errorFailedToReadRepository) {
 		return
 	}
 
+	var repositoryId int
+	if repository != nil {
+		if !plan.AdoptExisting.ValueBool() {
+			response.Diagnostics.AddError("linked repository already exists", "Unable to create as the requested repository already exists, set adopt_existing to take over the repository, or manual deletion of linked repository may be required")
+			return
+		}
+
+		// take over the existing repository, and point it to the planned source repository
+		repositoryId, err = receiver.importRepository(plan, repository.ID)
+		if util.TestError(&response.Diagnostics, err, errorFailedToUpdateRepository) {
+			return
+		}
+	} else {
+		repositoryId, err = receiver.importRepository(plan, 0)
+		if util.TestError(&response.Diagnostics, err, errorFailedToAddRepository) {
+			return
+		}
+	}
+
 	err = receiver.client.RepositoryService().EnableCI(repositoryId, plan.RssEnabled.ValueBool())
 	if util.TestError(&response.Diagnostics, err, errorFailedToUpdateRepository) {
 		return
 	}
 
-	if plan.RssEnabled.ValueBool() {
-		err = receiver.client.RepositoryService().ScanCI(repositoryId)
-		if util.TestError(&response.Diagnostics, err, "Failed to execute scan repository") {
-			return
-		}
-	}
+	scan, scanDiags := scanSpecs(ctx, receiver, receiver.extendedClient, repositoryId, plan.specsScanSettings())
+	plan.LastSpecsScanResult, plan.LastSpecsScanTime = specsScanAttributes(scan)
 
 	repository = &bamboo.Repository{
 		ID:         repositoryId,
@@ -183,6 +355,62 @@ func (receiver *LinkedRepositoryResource) Create(ctx context.Context, request re
 	if util.TestDiagnostic(&response.Diagnostics, diags) {
 		return
 	}
+
+	// a failed scan is reported after the repository is recorded in the state
+	response.Diagnostics.Append(scanDiags...)
+}
+
+// importRepository creates the linked repository when repositoryId is 0, otherwise updates the existing one.
+func (receiver *LinkedRepositoryResource) importRepository(plan LinkedRepositoryModel, repositoryId int) (int, error) {
+	var oid string
+	if repositoryId != 0 {
+		var err error
+		oid, err = receiver.extendedClient.RepositoryService().ReadOid(repositoryId)
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	return receiver.extendedClient.RepositoryService().Import(receiver.repositorySpec(plan), oid)
+}
+
+func (receiver *LinkedRepositoryResource) repositorySpec(plan LinkedRepositoryModel) RepositorySpec {
+	switch plan.getType() {
+	case linkedRepositoryTypeBitbucketServer:
+		return NewBitbucketServerRepositorySpec(receiver.config.BambooRss, "",
+			plan.Name.ValueString(),
+			plan.Project.ValueString(),
+			plan.Slug.ValueString(),
+			plan.Branch.ValueString(),
+			plan.ChangeDetection.settings(),
+		)
+	case linkedRepositoryTypeGitHub:
+		return GitHubRepositorySpec{
+			Name:             plan.Name.ValueString(),
+			Repository:       plan.GitHub.Repository.ValueString(),
+			Branch:           plan.Branch.ValueString(),
+			SharedCredential: plan.GitHub.TokenCredential.ValueString(),
+			ChangeDetection:  plan.ChangeDetection.settings(),
+		}
+	case linkedRepositoryTypeGitLab:
+		return GitRepositorySpec{
+			Name:             plan.Name.ValueString(),
+			Url:              plan.GitLab.cloneUrl(),
+			Branch:           plan.Branch.ValueString(),
+			SharedCredential: plan.GitLab.TokenCredential.ValueString(),
+			ChangeDetection:  plan.ChangeDetection.settings(),
+		}
+	default:
+		return GitRepositorySpec{
+			Name:             plan.Name.ValueString(),
+			Url:              plan.Git.Url.ValueString(),
+			Branch:           plan.Branch.ValueString(),
+			SharedCredential: plan.Git.SharedCredential.ValueString(),
+			SshKey:           plan.Git.SshKey.ValueString(),
+			SshPassphrase:    plan.Git.SshPassphrase.ValueString(),
+			ChangeDetection:  plan.ChangeDetection.settings(),
+		}
+	}
 }
 
 func (receiver *LinkedRepositoryResource) Read(ctx context.Context, request resource.ReadRequest, response *resource.ReadResponse) {
@@ -208,6 +436,26 @@ func (receiver *LinkedRepositoryResource) Read(ctx context.Context, request reso
 	}
 
 	state.ID = types.StringValue(fmt.Sprintf("%d", repository.ID))
+	state.RssEnabled = refreshBool(state.RssEnabled, repository.RssEnabled)
+	state.Type = types.StringValue(state.getType())
+
+	detail, err := receiver.extendedClient.RepositoryService().ReadDetail(repository.ID)
+	if util.TestError(&response.Diagnostics, err, errorFailedToReadRepository) {
+		return
+	}
+
+	if detail != nil {
+		state.Branch = refreshString(state.Branch, detail.Branch, false)
+		state.ChangeDetection = refreshChangeDetection(state.ChangeDetection, detail.ChangeDetection())
+		state.refreshLocation(*detail, receiver.config.BambooRss.Lowercase.ValueBool())
+	}
+
+	scan, err := receiver.extendedClient.RepositoryService().ReadSpecsState(repository.ID)
+	if util.TestError(&response.Diagnostics, err, errorFailedToReadSpecsScan) {
+		return
+	}
+
+	state.LastSpecsScanResult, state.LastSpecsScanTime = specsScanAttributes(scan)
 
 	computation, diags := ComputeProjectLinkedRepositoryAssignments(ctx, receiver, state)
 	if util.TestDiagnostic(&response.Diagnostics, diags) {
@@ -249,38 +497,29 @@ func (receiver *LinkedRepositoryResource) Update(ctx context.Context, request re
 		return
 	}
 
+	if !plan.sameLocation(state) {
+		_, err = receiver.importRepository(plan, repository.ID)
+		if util.TestError(&response.Diagnostics, err, errorFailedToUpdateRepository) {
+			return
+		}
+	}
+
 	err = receiver.client.RepositoryService().EnableCI(repository.ID, plan.RssEnabled.ValueBool())
 	if util.TestError(&response.Diagnostics, err, errorFailedToUpdateRepository) {
 		return
 	}
 
-	if plan.RssEnabled.ValueBool() {
-		err = receiver.client.RepositoryService().ScanCI(repository.ID)
-		if util.TestError(&response.Diagnostics, err, "Failed to execute scan repository") {
-			return
-		}
-	}
+	// scan after the repository points to the planned branch
+	scan, scanDiags := scanSpecs(ctx, receiver, receiver.extendedClient, repository.ID, plan.specsScanSettings())
+	plan.LastSpecsScanResult, plan.LastSpecsScanTime = specsScanAttributes(scan)
 
-	if !plan.Project.Equal(state.Project) || !plan.Slug.Equal(state.Slug) || !plan.Branch.Equal(state.Branch) {
-		err = receiver.client.RepositoryService().Update(repository.ID, bamboo.CreateRepository{
-			Name:             plan.Name.ValueString(),
-			ProjectKey:       strings.ToLower(plan.Project.ValueString()),
-			RepositorySlug:   strings.ToLower(plan.Slug.ValueString()),
-			RepositoryBranch: strings.ToLower(plan.Branch.ValueString()),
-			ServerId:         receiver.config.BambooRss.Server.ValueString(),
-			ServerName:       receiver.config.BambooRss.Name.ValueString(),
-			CloneUrl: strings.ToLower(fmt.Sprintf(
-				receiver.config.BambooRss.CloneUrl.ValueString(),
-				plan.Project.ValueString(),
-				plan.Slug.ValueString(),
-			)),
-		})
-		if util.TestError(&response.Diagnostics, err, errorFailedToUpdateRepository) {
-			return
-		}
+	// permissions changed outside of Terraform are pushed again
+	drifted, diags := AssignmentDrift(ctx, request.State)
+	if util.TestDiagnostic(&response.Diagnostics, diags) {
+		return
 	}
 
-	forceUpdate := !plan.AssignmentVersion.Equal(state.AssignmentVersion)
+	forceUpdate := drifted || !plan.AssignmentVersion.Equal(state.AssignmentVersion)
 	computation, diags := UpdateLinkedRepositoryAssignments(ctx, receiver, plan, state, forceUpdate)
 	if util.TestDiagnostic(&response.Diagnostics, diags) {
 		return
@@ -292,6 +531,21 @@ func (receiver *LinkedRepositoryResource) Update(ctx context.Context, request re
 	if util.TestDiagnostic(&response.Diagnostics, diags) {
 		return
 	}
+
+	// a failed scan is reported after the repository is recorded in the state
+	response.Diagnostics.Append(scanDiags...)
+}
+
+func (receiver *LinkedRepositoryResource) ModifyPlan(ctx context.Context, request resource.ModifyPlanRequest, response *resource.ModifyPlanResponse) {
+	// nothing to check on create and destroy
+	if request.State.Raw.IsNull() || request.Plan.Raw.IsNull() {
+		return
+	}
+
+	diags := ModifyAssignmentPlan(ctx, request.State, &response.Plan)
+	if util.TestDiagnostic(&response.Diagnostics, diags) {
+		return
+	}
 }
 
 func (receiver *LinkedRepositoryResource) Delete(ctx context.Context, request resource.DeleteRequest, response *resource.DeleteResponse) {
@@ -310,14 +564,61 @@ func (receiver *LinkedRepositoryResource) Delete(ctx context.Context, request re
 		return
 	}
 
-	err = receiver.client.RepositoryService().Delete(repositoryId)
-	if util.TestError(&response.Diagnostics, err, errorFailedToUpdateRepository) {
-		return
+	if !state.RetainOnDelete.ValueBool() {
+		if !state.ForceDelete.ValueBool() {
+			usage, err := receiver.extendedClient.RepositoryService().ReadUsage(repositoryId)
+			if util.TestError(&response.Diagnostics, err, errorFailedToReadRepositoryUsage) {
+				return
+			}
+
+			if usage.inUse() {
+				response.Diagnostics.AddError(errorLinkedRepositoryInUse, fmt.Sprintf(
+					"Linked repository %s is still used by\n%s\nRemove the usages, or set force_delete to remove it regardless.",
+					state.Name.ValueString(), describeRepositoryUsage(*usage)))
+				return
+			}
+		}
+
+		err = receiver.client.RepositoryService().Delete(repositoryId)
+		if util.TestError(&response.Diagnostics, err, errorFailedToRemoveRepository) {
+			return
+		}
 	}
 
 	response.State.RemoveResource(ctx)
 }
 
 func (receiver *LinkedRepositoryResource) ImportState(ctx context.Context, request resource.ImportStateRequest, response *resource.ImportStateResponse) {
+	// the type and the source repository are left null, Read fills them from the repository detail
 	resource.ImportStatePassthroughID(ctx, path.Root("name"), request, response)
+
+	diags := response.State.SetAttribute(ctx, path.Root("adopt_existing"), false)
+	if util.TestDiagnostic(&response.Diagnostics, diags) {
+		return
+	}
+
+	diags = response.State.SetAttribute(ctx, path.Root("retain_on_delete"), true)
+	if util.TestDiagnostic(&response.Diagnostics, diags) {
+		return
+	}
+
+	diags = response.State.SetAttribute(ctx, path.Root("force_delete"), false)
+	if util.TestDiagnostic(&response.Diagnostics, diags) {
+		return
+	}
+
+	diags = response.State.SetAttribute(ctx, path.Root("wait_for_specs_scan"), false)
+	if util.TestDiagnostic(&response.Diagnostics, diags) {
+		return
+	}
+
+	diags = response.State.SetAttribute(ctx, path.Root("specs_scan_timeout"), defaultSpecsScanTimeout)
+	if util.TestDiagnostic(&response.Diagnostics, diags) {
+		return
+	}
+
+	diags = response.State.SetAttribute(ctx, path.Root("fail_on_specs_scan_error"), true)
+	if util.TestDiagnostic(&response.Diagnostics, diags) {
+		return
+	}
 }
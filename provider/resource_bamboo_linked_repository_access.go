@@ -0,0 +1,488 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/yunarta/golang-quality-of-life-pack/collections"
+	"github.com/yunarta/terraform-atlassian-api-client/bamboo"
+	"github.com/yunarta/terraform-provider-commons/util"
+	"strconv"
+)
+
+type LinkedRepositoryAccessModel struct {
+	RetainOnDelete types.Bool   `tfsdk:"retain_on_delete"`
+	ID             types.String `tfsdk:"id"`
+	Projects       types.List   `tfsdk:"projects"`
+	Deployments    types.List   `tfsdk:"deployments"`
+	Repositories   types.List   `tfsdk:"repositories"`
+}
+
+var (
+	_ resource.Resource                = &LinkedRepositoryAccessResource{}
+	_ resource.ResourceWithConfigure   = &LinkedRepositoryAccessResource{}
+	_ resource.ResourceWithImportState = &LinkedRepositoryAccessResource{}
+	_ ConfigurableReceiver             = &LinkedRepositoryAccessResource{}
+	_ ExtendedConfigurableReceiver     = &LinkedRepositoryAccessResource{}
+)
+
+func NewLinkedRepositoryAccessResource() resource.Resource {
+	return &LinkedRepositoryAccessResource{}
+}
+
+type LinkedRepositoryAccessResource struct {
+	config         BambooProviderConfig
+	client         *bamboo.Client
+	extendedClient *ExtendedClient
+}
+
+func (receiver *LinkedRepositoryAccessResource) setConfig(config BambooProviderConfig, client *bamboo.Client) {
+	receiver.config = config
+	receiver.client = client
+}
+
+func (receiver *LinkedRepositoryAccessResource) setExtendedClient(extendedClient *ExtendedClient) {
+	receiver.extendedClient = extendedClient
+}
+
+func (receiver *LinkedRepositoryAccessResource) Metadata(ctx context.Context, request resource.MetadataRequest, response *resource.MetadataResponse) {
+	response.TypeName = request.ProviderTypeName + "_linked_repository_access"
+}
+
+func (receiver *LinkedRepositoryAccessResource) Schema(ctx context.Context, request resource.SchemaRequest, response *resource.SchemaResponse) {
+	numericIds := []validator.List{
+		listvalidator.ValueStringsAre(stringvalidator.RegexMatches(numericPattern, "value must be a numeric")),
+	}
+
+	response.Schema = schema.Schema{
+		MarkdownDescription: `This resource define, from the linked repository side, the projects, deployments and repositories allowed to use the repository Bamboo Specs.
+
+It is the counterpart of ` + "`bamboo_project_repositories`, `bamboo_deployment_repositories` and `bamboo_linked_repository_accessor`" + `, to let the repository owner manage the access in one place. Only the access listed here is changed, the access granted outside of this resource is left untouched.
+
+Import with the numeric id of the linked repository, every project, deployment and repository currently allowed to use it is listed. The import reads the repositories of every project and deployment, which may take a while on large instances.`,
+		Attributes: map[string]schema.Attribute{
+			"retain_on_delete": schema.BoolAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(true),
+				MarkdownDescription: "Default value is `true`, and if the value set to `false` when the resource destroyed, the access will be removed.",
+			},
+			"id": schema.StringAttribute{
+				Required: true,
+				PlanModifiers: []planmodifier.String{
+					util.ReplaceIfStringDiff(),
+				},
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(numericPattern, "value must be a numeric"),
+				},
+				MarkdownDescription: "Numeric id of the linked repository.",
+			},
+			"projects": schema.ListAttribute{
+				Optional:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "Keys of the projects allowed to use the repository.",
+			},
+			"deployments": schema.ListAttribute{
+				Optional:            true,
+				ElementType:         types.StringType,
+				Validators:          numericIds,
+				MarkdownDescription: "Numeric ids of the deployments allowed to use the repository.",
+			},
+			"repositories": schema.ListAttribute{
+				Optional:            true,
+				ElementType:         types.StringType,
+				Validators:          numericIds,
+				MarkdownDescription: "Numeric ids of the linked repositories allowed to use the repository.",
+			},
+		},
+	}
+}
+
+func (receiver *LinkedRepositoryAccessResource) Configure(ctx context.Context, request resource.ConfigureRequest, response *resource.ConfigureResponse) {
+	ConfigureResource(receiver, ctx, request, response)
+}
+
+func (receiver *LinkedRepositoryAccessResource) Create(ctx context.Context, request resource.CreateRequest, response *resource.CreateResponse) {
+	var (
+		diags diag.Diagnostics
+		plan  LinkedRepositoryAccessModel
+	)
+
+	diags = request.Plan.Get(ctx, &plan)
+	if util.TestDiagnostic(&response.Diagnostics, diags) {
+		return
+	}
+
+	repositoryId, err := strconv.Atoi(plan.ID.ValueString())
+	if util.TestError(&response.Diagnostics, err, errorProvidedRepositoryMustBeNumber) {
+		return
+	}
+
+	diags = receiver.reconcile(ctx, repositoryId, LinkedRepositoryAccessModel{}, plan)
+	if util.TestDiagnostic(&response.Diagnostics, diags) {
+		return
+	}
+
+	diags = response.State.Set(ctx, &plan)
+	if util.TestDiagnostic(&response.Diagnostics, diags) {
+		return
+	}
+}
+
+func (receiver *LinkedRepositoryAccessResource) Read(ctx context.Context, request resource.ReadRequest, response *resource.ReadResponse) {
+	var (
+		diags diag.Diagnostics
+		state LinkedRepositoryAccessModel
+	)
+
+	diags = request.State.Get(ctx, &state)
+	if util.TestDiagnostic(&response.Diagnostics, diags) {
+		return
+	}
+
+	repositoryId, err := strconv.Atoi(state.ID.ValueString())
+	if util.TestError(&response.Diagnostics, err, errorProvidedRepositoryMustBeNumber) {
+		return
+	}
+
+	// the repository was removed outside of Terraform, so was its access
+	detail, err := receiver.extendedClient.RepositoryService().ReadDetail(repositoryId)
+	if util.TestError(&response.Diagnostics, err, errorFailedToReadRepository) {
+		return
+	}
+
+	if detail == nil {
+		response.State.RemoveResource(ctx)
+		return
+	}
+
+	refreshed := LinkedRepositoryAccessModel{RetainOnDelete: state.RetainOnDelete, ID: state.ID}
+	for _, consumer := range receiver.consumers(repositoryId) {
+		keys, diags := consumerKeys(ctx, *consumer.list(&state))
+		if util.TestDiagnostic(&response.Diagnostics, diags) {
+			return
+		}
+
+		granted, err := consumer.filterGranted(keys)
+		if util.TestError(&response.Diagnostics, err, consumer.errorRead) {
+			return
+		}
+
+		*consumer.list(&refreshed), diags = consumerList(ctx, *consumer.list(&state), granted)
+		if util.TestDiagnostic(&response.Diagnostics, diags) {
+			return
+		}
+	}
+
+	diags = response.State.Set(ctx, &refreshed)
+	if util.TestDiagnostic(&response.Diagnostics, diags) {
+		return
+	}
+}
+
+func (receiver *LinkedRepositoryAccessResource) Update(ctx context.Context, request resource.UpdateRequest, response *resource.UpdateResponse) {
+	var plan, state LinkedRepositoryAccessModel
+
+	if util.TestDiagnostics(&response.Diagnostics,
+		request.Plan.Get(ctx, &plan),
+		request.State.Get(ctx, &state),
+	) {
+		return
+	}
+
+	repositoryId, err := strconv.Atoi(plan.ID.ValueString())
+	if util.TestError(&response.Diagnostics, err, errorProvidedRepositoryMustBeNumber) {
+		return
+	}
+
+	diags := receiver.reconcile(ctx, repositoryId, state, plan)
+	if util.TestDiagnostic(&response.Diagnostics, diags) {
+		return
+	}
+
+	diags = response.State.Set(ctx, &plan)
+	if util.TestDiagnostic(&response.Diagnostics, diags) {
+		return
+	}
+}
+
+func (receiver *LinkedRepositoryAccessResource) Delete(ctx context.Context, request resource.DeleteRequest, response *resource.DeleteResponse) {
+	var state LinkedRepositoryAccessModel
+
+	diags := request.State.Get(ctx, &state)
+	if util.TestDiagnostic(&response.Diagnostics, diags) {
+		return
+	}
+
+	if !state.RetainOnDelete.ValueBool() {
+		repositoryId, err := strconv.Atoi(state.ID.ValueString())
+		if util.TestError(&response.Diagnostics, err, errorProvidedRepositoryMustBeNumber) {
+			return
+		}
+
+		diags = receiver.reconcile(ctx, repositoryId, state, LinkedRepositoryAccessModel{})
+		if util.TestDiagnostic(&response.Diagnostics, diags) {
+			return
+		}
+	}
+
+	response.State.RemoveResource(ctx)
+}
+
+func (receiver *LinkedRepositoryAccessResource) ImportState(ctx context.Context, request resource.ImportStateRequest, response *resource.ImportStateResponse) {
+	repositoryId, err := strconv.Atoi(request.ID)
+	if util.TestError(&response.Diagnostics, err, errorProvidedRepositoryMustBeNumber) {
+		return
+	}
+
+	imported := LinkedRepositoryAccessModel{
+		RetainOnDelete: types.BoolValue(true),
+		ID:             types.StringValue(strconv.Itoa(repositoryId)),
+	}
+
+	for _, consumer := range receiver.consumers(repositoryId) {
+		candidates, err := consumer.all()
+		if util.TestError(&response.Diagnostics, err, consumer.errorRead) {
+			return
+		}
+
+		granted, err := consumer.filterGranted(candidates)
+		if util.TestError(&response.Diagnostics, err, consumer.errorRead) {
+			return
+		}
+
+		var diags diag.Diagnostics
+		*consumer.list(&imported), diags = consumerList(ctx, types.ListNull(types.StringType), granted)
+		if util.TestDiagnostic(&response.Diagnostics, diags) {
+			return
+		}
+	}
+
+	diags := response.State.Set(ctx, &imported)
+	util.TestDiagnostic(&response.Diagnostics, diags)
+}
+
+// reconcile grants and revokes the access changed between the lists in state and the planned lists, the access
+// granted outside of this resource is left untouched.
+func (receiver *LinkedRepositoryAccessResource) reconcile(ctx context.Context, repositoryId int, state LinkedRepositoryAccessModel, plan LinkedRepositoryAccessModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	for _, consumer := range receiver.consumers(repositoryId) {
+		inState, stateDiags := consumerKeys(ctx, *consumer.list(&state))
+		diags.Append(stateDiags...)
+
+		planned, planDiags := consumerKeys(ctx, *consumer.list(&plan))
+		diags.Append(planDiags...)
+		if diags.HasError() {
+			return diags
+		}
+
+		if consumer.kind == accessNodeRepository && collections.Contains(planned, strconv.Itoa(repositoryId)) {
+			diags.AddError("Cannot add self as accessor", fmt.Sprintf("Repository %d", repositoryId))
+			return diags
+		}
+
+		adding, removing := collections.Delta(inState, planned)
+		for _, key := range adding {
+			granted, err := consumer.granted(key)
+			if err != nil {
+				diags.AddError(consumer.errorRead, err.Error())
+				return diags
+			}
+
+			if !granted {
+				if err = consumer.add(key); err != nil {
+					diags.AddError(consumer.errorAdd, err.Error())
+					return diags
+				}
+			}
+		}
+
+		for _, key := range removing {
+			granted, err := consumer.granted(key)
+			if err != nil {
+				diags.AddError(consumer.errorRead, err.Error())
+				return diags
+			}
+
+			if granted {
+				if err = consumer.remove(key); err != nil {
+					diags.AddError(consumer.errorRemove, err.Error())
+					return diags
+				}
+			}
+		}
+	}
+
+	return diags
+}
+
+// repositoryConsumer reads and changes the access of one kind of consumer, by its project key, deployment id or
+// repository id, to the linked repository.
+type repositoryConsumer struct {
+	kind string
+	list func(model *LinkedRepositoryAccessModel) *types.List
+
+	all     func() ([]string, error)
+	granted func(key string) (bool, error)
+	add     func(key string) error
+	remove  func(key string) error
+
+	errorRead   string
+	errorAdd    string
+	errorRemove string
+}
+
+func (consumer repositoryConsumer) filterGranted(keys []string) ([]string, error) {
+	var granted = make([]string, 0)
+	for _, key := range keys {
+		ok, err := consumer.granted(key)
+		if err != nil {
+			return nil, err
+		}
+
+		if ok {
+			granted = append(granted, key)
+		}
+	}
+
+	return granted, nil
+}
+
+// consumers returns the consumers for one pass over the repository. The repository accessors come in a single list,
+// so they are read once per pass rather than once for every key, a pass never checks a key after changing it.
+func (receiver *LinkedRepositoryAccessResource) consumers(repositoryId int) []repositoryConsumer {
+	var accessors []bamboo.Repository
+	readAccessors := func() ([]bamboo.Repository, error) {
+		if accessors == nil {
+			read, err := receiver.client.RepositoryService().ReadAccessor(repositoryId)
+			if err != nil {
+				return nil, err
+			}
+
+			accessors = append(make([]bamboo.Repository, 0), read...)
+		}
+
+		return accessors, nil
+	}
+
+	return []repositoryConsumer{
+		{
+			kind: accessNodeProject,
+			list: func(model *LinkedRepositoryAccessModel) *types.List { return &model.Projects },
+			all: func() ([]string, error) {
+				projects, err := receiver.extendedClient.ProjectService().ReadAll()
+				if err != nil {
+					return nil, err
+				}
+
+				var keys = make([]string, 0)
+				for _, project := range projects {
+					keys = append(keys, project.Key)
+				}
+
+				return keys, nil
+			},
+			granted: func(key string) (bool, error) {
+				repositories, err := receiver.client.ProjectService().GetSpecRepositories(key)
+				return containsRepository(repositories, repositoryId), err
+			},
+			add: func(key string) error {
+				_, err := receiver.client.ProjectService().AddSpecRepositories(key, repositoryId)
+				return err
+			},
+			remove: func(key string) error {
+				return receiver.client.ProjectService().RemoveSpecRepositories(key, repositoryId)
+			},
+			errorRead:   errorFailedToReadProjectRepositories,
+			errorAdd:    errorFailedToAddProjectRepositories,
+			errorRemove: errorFailedToRemoveProjectRepositories,
+		},
+		{
+			kind: accessNodeDeployment,
+			list: func(model *LinkedRepositoryAccessModel) *types.List { return &model.Deployments },
+			all: func() ([]string, error) {
+				deployments, err := receiver.extendedClient.DeploymentService().ReadAll()
+				if err != nil {
+					return nil, err
+				}
+
+				var ids = make([]string, 0)
+				for _, deployment := range deployments {
+					ids = append(ids, strconv.Itoa(deployment.ID))
+				}
+
+				return ids, nil
+			},
+			granted: func(key string) (bool, error) {
+				deploymentId, _ := strconv.Atoi(key)
+				repositories, err := receiver.client.DeploymentService().GetSpecRepositories(deploymentId)
+				return containsRepository(repositories, repositoryId), err
+			},
+			add: func(key string) error {
+				deploymentId, _ := strconv.Atoi(key)
+				_, err := receiver.client.DeploymentService().AddSpecRepositories(deploymentId, repositoryId)
+				return err
+			},
+			remove: func(key string) error {
+				deploymentId, _ := strconv.Atoi(key)
+				return receiver.client.DeploymentService().RemoveSpecRepositories(deploymentId, repositoryId)
+			},
+			errorRead:   errorFailedToReadDeploymentRepositories,
+			errorAdd:    errorFailedToAddDeploymentRepositories,
+			errorRemove: errorFailedToRemoveDeploymentRepositories,
+		},
+		{
+			kind: accessNodeRepository,
+			list: func(model *LinkedRepositoryAccessModel) *types.List { return &model.Repositories },
+			all: func() ([]string, error) {
+				accessors, err := readAccessors()
+				return repositoryIds(accessors), err
+			},
+			granted: func(key string) (bool, error) {
+				accessorId, _ := strconv.Atoi(key)
+				accessors, err := readAccessors()
+				return containsRepository(accessors, accessorId), err
+			},
+			add: func(key string) error {
+				accessorId, _ := strconv.Atoi(key)
+				_, err := receiver.client.RepositoryService().AddAccessor(repositoryId, accessorId)
+				return err
+			},
+			remove: func(key string) error {
+				accessorId, _ := strconv.Atoi(key)
+				return receiver.client.RepositoryService().RemoveAccessor(repositoryId, accessorId)
+			},
+			errorRead:   errorFailedToReadRepositoryAccessor,
+			errorAdd:    errorFailedToAddRepositoryAccessor,
+			errorRemove: errorFailedToRemoveRepositoryAccessor,
+		},
+	}
+}
+
+func consumerKeys(ctx context.Context, list types.List) ([]string, diag.Diagnostics) {
+	var keys = make([]string, 0)
+	if list.IsNull() || list.IsUnknown() {
+		return keys, nil
+	}
+
+	diags := list.ElementsAs(ctx, &keys, true)
+	return keys, diags
+}
+
+// consumerList returns the keys as a list, kept null when the list was not configured and nothing is granted.
+func consumerList(ctx context.Context, configured types.List, keys []string) (types.List, diag.Diagnostics) {
+	if configured.IsNull() && len(keys) == 0 {
+		return types.ListNull(types.StringType), nil
+	}
+
+	return types.ListValueFrom(ctx, types.StringType, keys)
+}
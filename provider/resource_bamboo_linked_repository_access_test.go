@@ -0,0 +1,158 @@
+package provider
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/yunarta/terraform-atlassian-api-client/bamboo"
+	"github.com/yunarta/terraform-provider-bamboo/provider/test"
+)
+
+func newConsumerVirtualization() *test.ServiceVirtualization {
+	virtualization := newAccessVirtualization()
+	virtualization.AddProject("PROJ", "Project")
+	virtualization.AddProject("OPS", "Operations")
+	virtualization.AddDeployment(bamboo.Deployment{ID: 7, Name: "Application Deployment"})
+	virtualization.AddDeployment(bamboo.Deployment{ID: 8, Name: "Other Deployment"})
+	return virtualization
+}
+
+func TestLinkedRepositoryAccessResource_Lifecycle(t *testing.T) {
+	virtualization := newConsumerVirtualization()
+	// granted outside of the resource
+	virtualization.GrantAccess("project/OPS", 1)
+
+	harness := newResourceHarness(t, NewLinkedRepositoryAccessResource(), virtualization, testBambooRss(false))
+	state, diags := harness.create(harness.plan(map[string]any{
+		"id":               "1",
+		"retain_on_delete": false,
+		"projects":         []string{"PROJ"},
+		"deployments":      []string{"7"},
+		"repositories":     []string{"2", "3"},
+	}))
+	harness.require(diags)
+
+	expectAccess := func(step string, entity string, want []int) {
+		t.Helper()
+		if got := sortedAccess(virtualization, entity); !reflect.DeepEqual(got, want) {
+			t.Errorf("%s() %s access = %v, want %v", step, entity, got, want)
+		}
+	}
+
+	expectAccess("Create", "project/PROJ", []int{1})
+	expectAccess("Create", "project/OPS", []int{1})
+	expectAccess("Create", "deployment/7", []int{1})
+	expectAccess("Create", "repository/1", []int{2, 3})
+
+	state, diags = harness.update(harness.planFrom(state, map[string]any{
+		"projects":     []string{},
+		"deployments":  []string{"7", "8"},
+		"repositories": []string{"3"},
+	}), state)
+	harness.require(diags)
+
+	expectAccess("Update", "project/PROJ", []int{})
+	expectAccess("Update", "project/OPS", []int{1})
+	expectAccess("Update", "deployment/8", []int{1})
+	expectAccess("Update", "repository/1", []int{3})
+
+	// access revoked outside of the resource shows up as drift
+	virtualization.RemoveRepository(3)
+	state, diags = harness.read(state)
+	harness.require(diags)
+
+	if got := listAttribute(t, state, "repositories"); !reflect.DeepEqual(got, []string{}) {
+		t.Errorf("Read() repositories = %v, want none", got)
+	}
+
+	if got := listAttribute(t, state, "deployments"); !reflect.DeepEqual(got, []string{"7", "8"}) {
+		t.Errorf("Read() deployments = %v, want [7 8]", got)
+	}
+
+	harness.require(harness.delete(state))
+	expectAccess("Delete", "deployment/7", []int{})
+	expectAccess("Delete", "deployment/8", []int{})
+	expectAccess("Delete", "project/OPS", []int{1})
+}
+
+func TestLinkedRepositoryAccessResource_ImportState(t *testing.T) {
+	virtualization := newConsumerVirtualization()
+	virtualization.GrantAccess("project/OPS", 1)
+	virtualization.GrantAccess("project/PROJ", 2)
+	virtualization.GrantAccess("deployment/8", 1)
+	virtualization.GrantAccess("repository/1", 4)
+
+	harness := newResourceHarness(t, NewLinkedRepositoryAccessResource(), virtualization, testBambooRss(false))
+	state, diags := harness.importState("1")
+	harness.require(diags)
+
+	plan := harness.plan(map[string]any{
+		"id":               "1",
+		"retain_on_delete": true,
+		"projects":         []string{"OPS"},
+		"deployments":      []string{"8"},
+		"repositories":     []string{"4"},
+	})
+	if !plan.Raw.Equal(state.Raw) {
+		t.Errorf("ImportState() state = %v, want %v", state.Raw, plan.Raw)
+	}
+}
+
+func TestLinkedRepositoryAccessResource_Self(t *testing.T) {
+	harness := newResourceHarness(t, NewLinkedRepositoryAccessResource(), newConsumerVirtualization(), testBambooRss(false))
+	_, diags := harness.create(harness.plan(map[string]any{
+		"id":           "1",
+		"repositories": []string{"1"},
+	}))
+	if !diags.HasError() {
+		t.Fatalf("Create() expected an error when the repository is allowed to use itself")
+	}
+}
+
+func TestLinkedRepositoryAccessResource_ReadAccessorsOnce(t *testing.T) {
+	virtualization := newConsumerVirtualization()
+	virtualization.GrantAccess("repository/1", 2)
+	virtualization.GrantAccess("repository/1", 3)
+
+	harness := newResourceHarness(t, NewLinkedRepositoryAccessResource(), virtualization, testBambooRss(false))
+	state, diags := harness.importState("1")
+	harness.require(diags)
+
+	if got := virtualization.Requests(http.MethodGet, "/rest/api/latest/repository/1/rssrepository"); got != 2 {
+		t.Errorf("ImportState() accessor reads = %d, want one for the import and one for the read", got)
+	}
+
+	state, diags = harness.update(harness.planFrom(state, map[string]any{
+		"repositories": []string{"4"},
+	}), state)
+	harness.require(diags)
+
+	if got := virtualization.Requests(http.MethodGet, "/rest/api/latest/repository/1/rssrepository"); got != 3 {
+		t.Errorf("Update() accessor reads = %d, want one more", got)
+	}
+
+	if got := sortedAccess(virtualization, "repository/1"); !reflect.DeepEqual(got, []int{4}) {
+		t.Errorf("Update() repository/1 access = %v, want [4]", got)
+	}
+}
+
+func TestLinkedRepositoryAccessResource_ReadRemoved(t *testing.T) {
+	virtualization := newConsumerVirtualization()
+
+	harness := newResourceHarness(t, NewLinkedRepositoryAccessResource(), virtualization, testBambooRss(false))
+	state, diags := harness.create(harness.plan(map[string]any{
+		"id":           "1",
+		"repositories": []string{"2"},
+	}))
+	harness.require(diags)
+
+	// the repository was removed outside of Terraform
+	virtualization.RemoveRepository(1)
+	state, diags = harness.read(state)
+	harness.require(diags)
+
+	if !state.Raw.IsNull() {
+		t.Errorf("Read() state = %v, want removed", state.Raw)
+	}
+}
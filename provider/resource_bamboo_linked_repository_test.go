@@ -0,0 +1,771 @@
+package provider
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/yunarta/terraform-provider-bamboo/provider/test"
+)
+
+func testBambooRss(lowercase bool) BambooProviderConfig {
+	return BambooProviderConfig{
+		BambooRss: BambooRss{
+			Server:    types.StringValue("server-id"),
+			Name:      types.StringValue("bitbucket"),
+			CloneUrl:  types.StringValue("ssh://git@bitbucket.example.com:7999/%s/%s.git"),
+			Lowercase: types.BoolValue(lowercase),
+		},
+	}
+}
+
+func TestLinkedRepositoryResource_CaseRoundTrip(t *testing.T) {
+	tests := []struct {
+		name         string
+		lowercase    bool
+		wantProject  string
+		wantSlug     string
+		wantCloneUrl string
+	}{
+		{
+			name:         "preserved",
+			wantProject:  "PROJ",
+			wantSlug:     "My-App",
+			wantCloneUrl: "ssh://git@bitbucket.example.com:7999/PROJ/My-App.git",
+		},
+		{
+			name:         "lowercase server",
+			lowercase:    true,
+			wantProject:  "proj",
+			wantSlug:     "my-app",
+			wantCloneUrl: "ssh://git@bitbucket.example.com:7999/proj/my-app.git",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			virtualization := test.NewServiceVirtualization()
+			harness := newResourceHarness(t, NewLinkedRepositoryResource(), virtualization, testBambooRss(tt.lowercase))
+
+			state, diags := harness.create(harness.plan(map[string]any{
+				"name":    "my-app",
+				"project": "PROJ",
+				"slug":    "My-App",
+				"branch":  "release/Q4-Hotfix",
+			}))
+			harness.require(diags)
+
+			spec := virtualization.Repository(1).Spec
+			if spec["branch"] != "release/Q4-Hotfix" {
+				t.Errorf("created branch = %v, want release/Q4-Hotfix", spec["branch"])
+			}
+			if spec["projectKey"] != tt.wantProject || spec["repositorySlug"] != tt.wantSlug {
+				t.Errorf("created project/slug = %v/%v, want %v/%v", spec["projectKey"], spec["repositorySlug"], tt.wantProject, tt.wantSlug)
+			}
+			if spec["sshCloneUrl"] != tt.wantCloneUrl {
+				t.Errorf("created clone url = %v, want %v", spec["sshCloneUrl"], tt.wantCloneUrl)
+			}
+
+			state, diags = harness.read(state)
+			harness.require(diags)
+			if got := harness.attribute(state, "branch"); got != "release/Q4-Hotfix" {
+				t.Errorf("read branch = %v, want release/Q4-Hotfix", got)
+			}
+			if got := harness.attribute(state, "project"); got != "PROJ" {
+				t.Errorf("read project = %v, want PROJ", got)
+			}
+
+			state, diags = harness.update(harness.planFrom(state, map[string]any{"branch": "feature/Mixed-Case"}), state)
+			harness.require(diags)
+			if spec := virtualization.Repository(1).Spec; spec["branch"] != "feature/Mixed-Case" || spec["sshCloneUrl"] != tt.wantCloneUrl {
+				t.Errorf("updated branch/clone url = %v/%v, want feature/Mixed-Case/%v", spec["branch"], spec["sshCloneUrl"], tt.wantCloneUrl)
+			}
+
+			state, diags = harness.read(state)
+			harness.require(diags)
+			if got := harness.attribute(state, "branch"); got != "feature/Mixed-Case" {
+				t.Errorf("read branch after update = %v, want feature/Mixed-Case", got)
+			}
+		})
+	}
+}
+
+func TestLinkedRepositoryResource_Types(t *testing.T) {
+	tests := []struct {
+		name       string
+		attributes map[string]any
+		wantSpec   map[string]any
+		wantType   string
+		// location returns the source repository recorded in the state
+		location     func(model LinkedRepositoryModel) string
+		wantLocation string
+	}{
+		{
+			name: "git with shared credential",
+			attributes: map[string]any{"type": linkedRepositoryTypeGit, "git": &LinkedRepositoryGitModel{
+				Url:              types.StringValue("https://git.example.com/my-app.git"),
+				SharedCredential: types.StringValue("git-credential"),
+				SshKey:           types.StringNull(),
+				SshPassphrase:    types.StringNull(),
+			}},
+			wantSpec: map[string]any{
+				"url":                      "https://git.example.com/my-app.git",
+				"authenticationProperties": map[string]any{"name": "git-credential"},
+			},
+			wantType:     linkedRepositoryTypeGit,
+			location:     func(model LinkedRepositoryModel) string { return model.Git.Url.ValueString() },
+			wantLocation: "https://git.example.com/my-app.git",
+		},
+		{
+			name: "git with ssh key",
+			attributes: map[string]any{"type": linkedRepositoryTypeGit, "git": &LinkedRepositoryGitModel{
+				Url:              types.StringValue("ssh://git@git.example.com/my-app.git"),
+				SharedCredential: types.StringNull(),
+				SshKey:           types.StringValue("private-key"),
+				SshPassphrase:    types.StringValue("passphrase"),
+			}},
+			wantSpec: map[string]any{
+				"url":                      "ssh://git@git.example.com/my-app.git",
+				"authenticationProperties": map[string]any{"sshPrivateKey": "private-key", "passphrase": "passphrase"},
+			},
+			wantType:     linkedRepositoryTypeGit,
+			location:     func(model LinkedRepositoryModel) string { return model.Git.Url.ValueString() },
+			wantLocation: "ssh://git@git.example.com/my-app.git",
+		},
+		{
+			name: "github",
+			attributes: map[string]any{"type": linkedRepositoryTypeGitHub, "github": &LinkedRepositoryGitHubModel{
+				Repository:      types.StringValue("example/my-app"),
+				TokenCredential: types.StringValue("github-token"),
+			}},
+			wantSpec: map[string]any{
+				"repository":        "example/my-app",
+				"sharedCredentials": map[string]any{"name": "github-token"},
+			},
+			wantType:     linkedRepositoryTypeGitHub,
+			location:     func(model LinkedRepositoryModel) string { return model.GitHub.Repository.ValueString() },
+			wantLocation: "example/my-app",
+		},
+		{
+			name: "gitlab",
+			attributes: map[string]any{"type": linkedRepositoryTypeGitLab, "gitlab": &LinkedRepositoryGitLabModel{
+				Url:             types.StringValue("https://gitlab.example.com/"),
+				Project:         types.StringValue("group/my-app"),
+				TokenCredential: types.StringValue("gitlab-token"),
+			}},
+			wantSpec: map[string]any{
+				"url":                      "https://gitlab.example.com/group/my-app.git",
+				"authenticationProperties": map[string]any{"name": "gitlab-token"},
+			},
+			// Bamboo links the project as a Git repository, so it is imported as one
+			wantType:     linkedRepositoryTypeGit,
+			location:     func(model LinkedRepositoryModel) string { return model.Git.Url.ValueString() },
+			wantLocation: "https://gitlab.example.com/group/my-app.git",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			virtualization := test.NewServiceVirtualization()
+			harness := newResourceHarness(t, NewLinkedRepositoryResource(), virtualization, testBambooRss(false))
+
+			tt.attributes["name"] = "my-app"
+			tt.attributes["branch"] = "main"
+			plan := harness.plan(tt.attributes)
+			state, diags := harness.create(plan)
+			harness.require(diags)
+
+			spec := virtualization.Repository(1).Spec
+			for key, want := range tt.wantSpec {
+				if !reflect.DeepEqual(spec[key], want) {
+					t.Errorf("created %s = %v, want %v", key, spec[key], want)
+				}
+			}
+
+			state, diags = harness.read(state)
+			harness.require(diags)
+
+			var planned, refreshed LinkedRepositoryModel
+			harness.require(plan.Get(context.Background(), &planned))
+			harness.require(state.Get(context.Background(), &refreshed))
+			if !refreshed.Type.Equal(planned.Type) || !refreshed.sameLocation(planned) {
+				t.Errorf("Read() = %v %v %v %v, want the planned repository", refreshed.Type, refreshed.Git, refreshed.GitHub, refreshed.GitLab)
+			}
+
+			state, diags = harness.importState("my-app")
+			harness.require(diags)
+
+			var imported LinkedRepositoryModel
+			harness.require(state.Get(context.Background(), &imported))
+			if imported.Type.ValueString() != tt.wantType || tt.location(imported) != tt.wantLocation {
+				t.Errorf("ImportState() = %v %v, want %v %v", imported.Type, tt.location(imported), tt.wantType, tt.wantLocation)
+			}
+		})
+	}
+}
+
+func TestProjectLinkedRepositoryResource_CaseRoundTrip(t *testing.T) {
+	virtualization := test.NewServiceVirtualization()
+	harness := newResourceHarness(t, NewProjectLinkedRepositoryResource(), virtualization, testBambooRss(false))
+
+	state, diags := harness.create(harness.plan(map[string]any{
+		"key":     "BAM",
+		"name":    "my-app",
+		"project": "PROJ",
+		"slug":    "My-App",
+		"branch":  "release/Q4-Hotfix",
+	}))
+	harness.require(diags)
+
+	spec := virtualization.Repository(1).Spec
+	if spec["branch"] != "release/Q4-Hotfix" || spec["sshCloneUrl"] != "ssh://git@bitbucket.example.com:7999/PROJ/My-App.git" {
+		t.Errorf("created branch/clone url = %v/%v", spec["branch"], spec["sshCloneUrl"])
+	}
+
+	state, diags = harness.update(harness.planFrom(state, map[string]any{"branch": "feature/Mixed-Case"}), state)
+	harness.require(diags)
+
+	state, diags = harness.read(state)
+	harness.require(diags)
+	if got := harness.attribute(state, "branch"); got != "feature/Mixed-Case" {
+		t.Errorf("read branch after update = %v, want feature/Mixed-Case", got)
+	}
+	if got := harness.attribute(state, "slug"); got != "My-App" {
+		t.Errorf("read slug = %v, want My-App", got)
+	}
+}
+
+func TestLinkedRepositoryResource_ChangeDetection(t *testing.T) {
+	virtualization := test.NewServiceVirtualization()
+	harness := newResourceHarness(t, NewLinkedRepositoryResource(), virtualization, testBambooRss(false))
+
+	state, diags := harness.create(harness.plan(map[string]any{
+		"name":    "my-app",
+		"project": "PROJ",
+		"slug":    "my-app",
+		"branch":  "main",
+		"change_detection": &ChangeDetectionModel{
+			Trigger:               types.StringValue(changeDetectionTriggerPolling),
+			PollingInterval:       types.Int64Value(60),
+			IncludePaths:          types.StringNull(),
+			ExcludePaths:          types.StringValue("docs/.*"),
+			ExcludeCommitMessages: types.StringValue(".*\\[skip ci\\].*"),
+			ShallowClones:         types.BoolNull(),
+			Submodules:            types.BoolValue(false),
+			Lfs:                   types.BoolValue(true),
+		},
+	}))
+	harness.require(diags)
+
+	spec := virtualization.Repository(1).Spec
+	vcs, _ := spec["vcsChangeDetection"].(map[string]any)
+	if spec["webhookEnabled"] != false || spec["useLfs"] != true || spec["useShallowClones"] != false {
+		t.Errorf("created checkout settings = %v", spec)
+	}
+	if vcs["pollingInterval"] != 60 || vcs["filterFilePatternOption"] != "EXCLUDE_ALL" || vcs["filterFilePatternRegex"] != "docs/.*" ||
+		vcs["changesetFilterPatternRegex"] != ".*\\[skip ci\\].*" {
+		t.Errorf("created change detection = %v", vcs)
+	}
+
+	readChangeDetection := func(state tfsdk.State) *ChangeDetectionModel {
+		t.Helper()
+
+		var model *ChangeDetectionModel
+		harness.require(state.GetAttribute(harness.ctx, path.Root("change_detection"), &model))
+		return model
+	}
+
+	planned := readChangeDetection(state)
+	state, diags = harness.read(state)
+	harness.require(diags)
+	if got := readChangeDetection(state); !reflect.DeepEqual(got, planned) {
+		t.Errorf("read change detection = %+v, want %+v", got, planned)
+	}
+
+	// changed through the UI
+	spec["useShallowClones"] = true
+	delete(vcs, "filterFilePatternRegex")
+	vcs["filterFilePatternOption"] = "NONE"
+
+	state, diags = harness.read(state)
+	harness.require(diags)
+	got := readChangeDetection(state)
+	if !got.ShallowClones.Equal(types.BoolValue(true)) || !got.ExcludePaths.IsNull() {
+		t.Errorf("read change detection after drift = %+v", got)
+	}
+}
+
+func TestLinkedRepositoryResource_ChangeDetectionDefaults(t *testing.T) {
+	virtualization := test.NewServiceVirtualization()
+	harness := newResourceHarness(t, NewLinkedRepositoryResource(), virtualization, testBambooRss(false))
+
+	state, diags := harness.create(harness.plan(map[string]any{
+		"name":    "my-app",
+		"project": "PROJ",
+		"slug":    "my-app",
+		"branch":  "main",
+	}))
+	harness.require(diags)
+
+	state, diags = harness.read(state)
+	harness.require(diags)
+
+	var model *ChangeDetectionModel
+	harness.require(state.GetAttribute(harness.ctx, path.Root("change_detection"), &model))
+	if model != nil {
+		t.Errorf("read change detection = %+v, want none while Bamboo keeps the defaults", model)
+	}
+
+	// polling enabled through the UI
+	virtualization.Repository(1).Spec["webhookEnabled"] = false
+
+	state, diags = harness.read(state)
+	harness.require(diags)
+	harness.require(state.GetAttribute(harness.ctx, path.Root("change_detection"), &model))
+	if model == nil || model.Trigger.ValueString() != changeDetectionTriggerPolling || !model.PollingInterval.IsNull() || !model.Lfs.IsNull() {
+		t.Errorf("read change detection after drift = %+v", model)
+	}
+}
+
+func TestLinkedRepositoryResource_WaitForSpecsScan(t *testing.T) {
+	defer func(interval time.Duration) { specsScanPollInterval = interval }(specsScanPollInterval)
+	specsScanPollInterval = 10 * time.Millisecond
+
+	tests := []struct {
+		name        string
+		outcome     *test.SpecsScan
+		failOnError bool
+		timeout     int64
+		cancelled   bool
+		wantResult  string
+		wantError   string
+		wantWarning string
+	}{
+		{
+			name:        "success",
+			outcome:     &test.SpecsScan{State: "SUCCESS", Pending: 2},
+			failOnError: true,
+			timeout:     60,
+			wantResult:  "SUCCESS",
+		},
+		{
+			name:        "failure as error",
+			outcome:     &test.SpecsScan{State: "FAILED", Log: "Loading bamboo-specs/bamboo.yaml\nUnknown key 'stagess'"},
+			failOnError: true,
+			timeout:     60,
+			wantResult:  "FAILED",
+			wantError:   "Unknown key 'stagess'",
+		},
+		{
+			name:        "failure as warning",
+			outcome:     &test.SpecsScan{State: "FAILED", Log: "Unknown key 'stagess'"},
+			timeout:     60,
+			wantResult:  "FAILED",
+			wantWarning: "Unknown key 'stagess'",
+		},
+		{
+			name:        "timeout",
+			outcome:     &test.SpecsScan{State: "SUCCESS", Pending: 1000},
+			failOnError: true,
+			timeout:     1,
+			wantResult:  "IN_PROGRESS",
+			wantError:   "did not finish within 1s",
+		},
+		{
+			name:        "cancelled",
+			outcome:     &test.SpecsScan{State: "SUCCESS", Pending: 1000},
+			failOnError: true,
+			timeout:     60,
+			cancelled:   true,
+			wantResult:  "IN_PROGRESS",
+			wantError:   "context canceled",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			virtualization := test.NewServiceVirtualization()
+			harness := newResourceHarness(t, NewLinkedRepositoryResource(), virtualization, testBambooRss(false))
+
+			state, diags := harness.create(harness.plan(map[string]any{
+				"name":                     "my-app",
+				"project":                  "PROJ",
+				"slug":                     "my-app",
+				"branch":                   "main",
+				"rss_enabled":              false,
+				"wait_for_specs_scan":      true,
+				"specs_scan_timeout":       tt.timeout,
+				"fail_on_specs_scan_error": tt.failOnError,
+			}))
+			harness.require(diags)
+			if got := harness.attribute(state, "last_specs_scan_result"); got != "" {
+				t.Errorf("last_specs_scan_result before any scan = %v, want none", got)
+			}
+
+			virtualization.Repository(1).ScanOutcome = tt.outcome
+			if tt.cancelled {
+				// Terraform cancels the context when the apply is interrupted
+				ctx, cancel := context.WithCancel(harness.ctx)
+				cancel()
+				harness.ctx = ctx
+			}
+
+			state, diags = harness.update(harness.planFrom(state, map[string]any{"rss_enabled": true}), state)
+
+			hasDiagnostic := func(severity diag.Severity, detail string) bool {
+				for _, diagnostic := range diags {
+					if diagnostic.Severity() == severity && strings.Contains(diagnostic.Detail(), detail) {
+						return true
+					}
+				}
+
+				return false
+			}
+			if tt.wantError == "" && diags.HasError() || tt.wantError != "" && !hasDiagnostic(diag.SeverityError, tt.wantError) {
+				t.Errorf("update diagnostics = %v, want error %q", diags, tt.wantError)
+			}
+			if tt.wantWarning != "" && !hasDiagnostic(diag.SeverityWarning, tt.wantWarning) {
+				t.Errorf("update diagnostics = %v, want warning %q", diags, tt.wantWarning)
+			}
+
+			if got := harness.attribute(state, "id"); got != "1" {
+				t.Errorf("id = %v, want the repository kept in the state", got)
+			}
+			if got := harness.attribute(state, "last_specs_scan_result"); got != tt.wantResult {
+				t.Errorf("last_specs_scan_result = %v, want %v", got, tt.wantResult)
+			}
+			if got := harness.attribute(state, "last_specs_scan_time"); tt.wantResult != "IN_PROGRESS" && got != "2024-01-01T00:01:01Z" {
+				t.Errorf("last_specs_scan_time = %v, want the finish time of the scan", got)
+			}
+		})
+	}
+}
+
+func TestLinkedRepositoryResource_Delete(t *testing.T) {
+	tests := []struct {
+		name           string
+		retainOnDelete bool
+		forceDelete    bool
+		usedByPlan     bool
+		wantError      string
+		wantDeleted    bool
+	}{
+		{name: "retained", retainOnDelete: true, usedByPlan: true},
+		{name: "unused", wantDeleted: true},
+		{name: "used", usedByPlan: true, wantError: "- plan PROJ-APP (Application)\n- deployment 7 (Application Deployment)"},
+		{name: "used and forced", usedByPlan: true, forceDelete: true, wantDeleted: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			virtualization := test.NewServiceVirtualization()
+			harness := newResourceHarness(t, NewLinkedRepositoryResource(), virtualization, testBambooRss(false))
+
+			state, diags := harness.create(harness.plan(map[string]any{
+				"name":             "my-app",
+				"project":          "PROJ",
+				"slug":             "my-app",
+				"branch":           "main",
+				"retain_on_delete": tt.retainOnDelete,
+				"force_delete":     tt.forceDelete,
+			}))
+			harness.require(diags)
+
+			if tt.usedByPlan {
+				virtualization.Repository(1).Plans = map[string]string{"PROJ-APP": "Application"}
+				virtualization.Repository(1).Deployments = map[int]string{7: "Application Deployment"}
+			}
+
+			diags = harness.delete(state)
+			if tt.wantError == "" {
+				harness.require(diags)
+			} else if !diags.HasError() || !strings.Contains(diags.Errors()[0].Detail(), tt.wantError) {
+				t.Errorf("delete diagnostics = %v, want error listing %q", diags, tt.wantError)
+			}
+
+			if deleted := virtualization.Repository(1) == nil; deleted != tt.wantDeleted {
+				t.Errorf("repository deleted = %v, want %v", deleted, tt.wantDeleted)
+			}
+		})
+	}
+}
+
+func TestProjectLinkedRepositoryResource_Delete(t *testing.T) {
+	virtualization := test.NewServiceVirtualization()
+	harness := newResourceHarness(t, NewProjectLinkedRepositoryResource(), virtualization, testBambooRss(false))
+
+	state, diags := harness.create(harness.plan(map[string]any{
+		"key":              "BAM",
+		"name":             "my-app",
+		"project":          "PROJ",
+		"slug":             "my-app",
+		"branch":           "main",
+		"retain_on_delete": false,
+		"force_delete":     false,
+	}))
+	harness.require(diags)
+
+	harness.require(harness.delete(state))
+	if virtualization.Repository(1) != nil {
+		t.Errorf("repository of project BAM was not deleted")
+	}
+}
+
+func TestLinkedRepositoryResource_AdoptExisting(t *testing.T) {
+	tests := []struct {
+		name          string
+		adoptExisting bool
+		wantErr       string
+	}{
+		{name: "name collision", wantErr: "linked repository already exists"},
+		{name: "adopted", adoptExisting: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			virtualization := test.NewServiceVirtualization()
+			virtualization.AddRepository(test.Repository{ID: 5, Name: "my-app", Spec: map[string]any{"branch": "legacy"}})
+			harness := newResourceHarness(t, NewLinkedRepositoryResource(), virtualization, testBambooRss(false))
+
+			state, diags := harness.create(harness.plan(map[string]any{
+				"name":           "my-app",
+				"project":        "PROJ",
+				"slug":           "my-app",
+				"branch":         "main",
+				"adopt_existing": tt.adoptExisting,
+			}))
+
+			if virtualization.Repository(6) != nil {
+				t.Errorf("create added a second repository, want only repository 5")
+			}
+
+			if tt.wantErr != "" {
+				if !diags.HasError() || diags.Errors()[0].Summary() != tt.wantErr {
+					t.Fatalf("create diagnostics = %v, want %q", diags, tt.wantErr)
+				}
+
+				if branch := virtualization.Repository(5).Spec["branch"]; branch != "legacy" {
+					t.Errorf("existing branch = %v, want legacy left untouched", branch)
+				}
+				return
+			}
+
+			harness.require(diags)
+			if got := harness.attribute(state, "id"); got != "5" {
+				t.Errorf("adopted id = %v, want 5", got)
+			}
+
+			if branch := virtualization.Repository(5).Spec["branch"]; branch != "main" {
+				t.Errorf("adopted branch = %v, want main", branch)
+			}
+		})
+	}
+}
+
+func TestProjectLinkedRepositoryResource_AdoptExisting(t *testing.T) {
+	tests := []struct {
+		name          string
+		adoptExisting bool
+		wantErr       string
+	}{
+		{name: "name collision", wantErr: "linked repository already exists"},
+		{name: "adopted", adoptExisting: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			virtualization := test.NewServiceVirtualization()
+			virtualization.AddRepository(test.Repository{ID: 5, Name: "my-app", Spec: map[string]any{
+				"branch":  "legacy",
+				"project": map[string]any{"key": "BAM"},
+			}})
+			harness := newResourceHarness(t, NewProjectLinkedRepositoryResource(), virtualization, testBambooRss(false))
+
+			state, diags := harness.create(harness.plan(map[string]any{
+				"key":            "BAM",
+				"name":           "my-app",
+				"project":        "PROJ",
+				"slug":           "my-app",
+				"branch":         "main",
+				"adopt_existing": tt.adoptExisting,
+			}))
+
+			if virtualization.Repository(6) != nil {
+				t.Errorf("create added a second repository, want only repository 5")
+			}
+
+			if tt.wantErr != "" {
+				if !diags.HasError() || diags.Errors()[0].Summary() != tt.wantErr {
+					t.Fatalf("create diagnostics = %v, want %q", diags, tt.wantErr)
+				}
+
+				if branch := virtualization.Repository(5).Spec["branch"]; branch != "legacy" {
+					t.Errorf("existing branch = %v, want legacy left untouched", branch)
+				}
+				return
+			}
+
+			harness.require(diags)
+			if got := harness.attribute(state, "id"); got != "5" {
+				t.Errorf("adopted id = %v, want 5", got)
+			}
+
+			if branch := virtualization.Repository(5).Spec["branch"]; branch != "main" {
+				t.Errorf("adopted branch = %v, want main", branch)
+			}
+		})
+	}
+}
+
+func TestLinkedRepositoryResource_ImportState(t *testing.T) {
+	tests := []struct {
+		name   string
+		spec   map[string]any
+		config LinkedRepositoryModel
+	}{
+		{
+			name: "bitbucket server",
+			spec: map[string]any{"projectKey": "PROJ", "repositorySlug": "my-app"},
+			config: LinkedRepositoryModel{
+				Type:    types.StringValue(linkedRepositoryTypeBitbucketServer),
+				Project: types.StringValue("PROJ"),
+				Slug:    types.StringValue("my-app"),
+			},
+		},
+		{
+			name: "git",
+			spec: map[string]any{
+				"url":                      "https://git.example.com/my-app.git",
+				"authenticationProperties": map[string]any{"name": "git-credential"},
+			},
+			config: LinkedRepositoryModel{
+				Type: types.StringValue(linkedRepositoryTypeGit),
+				Git: &LinkedRepositoryGitModel{
+					Url:              types.StringValue("https://git.example.com/my-app.git"),
+					SharedCredential: types.StringValue("git-credential"),
+					SshKey:           types.StringNull(),
+					SshPassphrase:    types.StringNull(),
+				},
+			},
+		},
+		{
+			name: "github",
+			spec: map[string]any{
+				"repository":        "example/my-app",
+				"sharedCredentials": map[string]any{"name": "github-token"},
+			},
+			config: LinkedRepositoryModel{
+				Type: types.StringValue(linkedRepositoryTypeGitHub),
+				GitHub: &LinkedRepositoryGitHubModel{
+					Repository:      types.StringValue("example/my-app"),
+					TokenCredential: types.StringValue("github-token"),
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			virtualization := test.NewServiceVirtualization()
+			tt.spec["branch"] = "main"
+			virtualization.AddRepository(test.Repository{ID: 5, Name: "my-app", Spec: tt.spec})
+			harness := newResourceHarness(t, NewLinkedRepositoryResource(), virtualization, testBambooRss(false))
+
+			state, diags := harness.importState("my-app")
+			harness.require(diags)
+
+			var imported LinkedRepositoryModel
+			harness.require(state.Get(context.Background(), &imported))
+
+			// the configuration the repository was imported for plans no change
+			tt.config.Branch = types.StringValue("main")
+			tt.config.ChangeDetection = imported.ChangeDetection
+			if !imported.Type.Equal(tt.config.Type) || !imported.sameLocation(tt.config) {
+				t.Errorf("ImportState() = %v %v/%v %v %v, want %v", imported.Type, imported.Project, imported.Slug, imported.Git, imported.GitHub, tt.config.Type)
+			}
+		})
+	}
+}
+
+func TestLinkedRepositoryResource_ReadLocationDrift(t *testing.T) {
+	tests := []struct {
+		name       string
+		attributes map[string]any
+		drift      map[string]any
+		want       func(model LinkedRepositoryModel) bool
+	}{
+		{
+			name:       "bitbucket server slug",
+			attributes: map[string]any{"project": "PROJ", "slug": "my-app"},
+			drift:      map[string]any{"projectKey": "PROJ", "repositorySlug": "my-app-moved"},
+			want: func(model LinkedRepositoryModel) bool {
+				return model.Slug.ValueString() == "my-app-moved"
+			},
+		},
+		{
+			name: "git url",
+			attributes: map[string]any{"type": linkedRepositoryTypeGit, "git": &LinkedRepositoryGitModel{
+				Url:              types.StringValue("https://git.example.com/my-app.git"),
+				SharedCredential: types.StringValue("git-credential"),
+				SshKey:           types.StringNull(),
+				SshPassphrase:    types.StringNull(),
+			}},
+			drift: map[string]any{"url": "https://git.example.com/moved.git", "authenticationProperties": map[string]any{"name": "git-credential"}},
+			want: func(model LinkedRepositoryModel) bool {
+				return model.Git != nil && model.Git.Url.ValueString() == "https://git.example.com/moved.git"
+			},
+		},
+		{
+			name: "github repository",
+			attributes: map[string]any{"type": linkedRepositoryTypeGitHub, "github": &LinkedRepositoryGitHubModel{
+				Repository:      types.StringValue("example/my-app"),
+				TokenCredential: types.StringValue("github-token"),
+			}},
+			drift: map[string]any{"repository": "example/moved", "sharedCredentials": map[string]any{"name": "github-token"}},
+			want: func(model LinkedRepositoryModel) bool {
+				return model.GitHub != nil && model.GitHub.Repository.ValueString() == "example/moved"
+			},
+		},
+		{
+			name:       "type",
+			attributes: map[string]any{"project": "PROJ", "slug": "my-app"},
+			drift:      map[string]any{"url": "https://git.example.com/my-app.git"},
+			want: func(model LinkedRepositoryModel) bool {
+				return model.Type.ValueString() == linkedRepositoryTypeGit && model.Slug.IsNull() &&
+					model.Git != nil && model.Git.Url.ValueString() == "https://git.example.com/my-app.git"
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			virtualization := test.NewServiceVirtualization()
+			harness := newResourceHarness(t, NewLinkedRepositoryResource(), virtualization, testBambooRss(false))
+
+			tt.attributes["name"] = "my-app"
+			tt.attributes["branch"] = "main"
+			state, diags := harness.create(harness.plan(tt.attributes))
+			harness.require(diags)
+
+			// the source repository was changed outside of Terraform
+			tt.drift["name"] = "my-app"
+			tt.drift["branch"] = "main"
+			virtualization.Repository(1).Spec = tt.drift
+
+			state, diags = harness.read(state)
+			harness.require(diags)
+
+			var refreshed LinkedRepositoryModel
+			harness.require(state.Get(context.Background(), &refreshed))
+			if !tt.want(refreshed) {
+				t.Errorf("Read() = %v %v/%v %v %v, want the drift reported", refreshed.Type, refreshed.Project, refreshed.Slug, refreshed.Git, refreshed.GitHub)
+			}
+		})
+	}
+}
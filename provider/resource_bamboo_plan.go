@@ -20,6 +20,7 @@ var (
 	_ resource.ResourceWithImportState = &PlanResource{}
 	_ ProjectPermissionsReceiver       = &PlanResource{}
 	_ ConfigurableReceiver             = &PlanResource{}
+	_ ExtendedConfigurableReceiver     = &PlanResource{}
 )
 
 func NewPlanResource() resource.Resource {
@@ -27,8 +28,9 @@ func NewPlanResource() resource.Resource {
 }
 
 type PlanResource struct {
-	config BambooProviderConfig
-	client *bamboo.Client
+	config         BambooProviderConfig
+	client         *bamboo.Client
+	extendedClient *ExtendedClient
 }
 
 func (receiver *PlanResource) setConfig(config BambooProviderConfig, client *bamboo.Client) {
@@ -36,10 +38,18 @@ func (receiver *PlanResource) setConfig(config BambooProviderConfig, client *bam
 	receiver.client = client
 }
 
+func (receiver *PlanResource) setExtendedClient(extendedClient *ExtendedClient) {
+	receiver.extendedClient = extendedClient
+}
+
 func (receiver *PlanResource) getClient() *bamboo.Client {
 	return receiver.client
 }
 
+func (receiver *PlanResource) getExtendedClient() *ExtendedClient {
+	return receiver.extendedClient
+}
+
 func (receiver *PlanResource) Metadata(ctx context.Context, request resource.MetadataRequest, response *resource.MetadataResponse) {
 	response.TypeName = request.ProviderTypeName + "_plan"
 }
@@ -17,8 +17,10 @@ var (
 	_ resource.Resource                = &ProjectResource{}
 	_ resource.ResourceWithConfigure   = &ProjectResource{}
 	_ resource.ResourceWithImportState = &ProjectResource{}
+	_ resource.ResourceWithModifyPlan  = &ProjectResource{}
 	_ ProjectPermissionsReceiver       = &ProjectResource{}
 	_ ConfigurableReceiver             = &ProjectResource{}
+	_ ExtendedConfigurableReceiver     = &ProjectResource{}
 )
 
 func NewProjectResource() resource.Resource {
@@ -26,8 +28,9 @@ func NewProjectResource() resource.Resource {
 }
 
 type ProjectResource struct {
-	config BambooProviderConfig
-	client *bamboo.Client
+	config         BambooProviderConfig
+	client         *bamboo.Client
+	extendedClient *ExtendedClient
 }
 
 func (receiver *ProjectResource) setConfig(config BambooProviderConfig, client *bamboo.Client) {
@@ -35,10 +38,18 @@ func (receiver *ProjectResource) setConfig(config BambooProviderConfig, client *
 	receiver.client = client
 }
 
+func (receiver *ProjectResource) setExtendedClient(extendedClient *ExtendedClient) {
+	receiver.extendedClient = extendedClient
+}
+
 func (receiver *ProjectResource) getClient() *bamboo.Client {
 	return receiver.client
 }
 
+func (receiver *ProjectResource) getExtendedClient() *ExtendedClient {
+	return receiver.extendedClient
+}
+
 func (receiver *ProjectResource) Metadata(ctx context.Context, request resource.MetadataRequest, response *resource.MetadataResponse) {
 	response.TypeName = request.ProviderTypeName + "_project"
 }
@@ -72,12 +83,13 @@ The priority block has a priority that defines the final assigned permissions of
 				Default:             stringdefault.StaticString(""),
 				MarkdownDescription: "Project description.",
 			},
-			"assignment_version": schema.StringAttribute{
-				Optional:            true,
-				MarkdownDescription: "Assignment version, used to force update the permission.",
-			},
-			"computed_users":  ComputedAssignmentSchema,
-			"computed_groups": ComputedAssignmentSchema,
+			"assignment_version": AssignmentVersionSchema,
+			"authoritative":      AuthoritativeSchema,
+			"ignore_principals":  IgnorePrincipalsSchema,
+			"merge_strategy":     MergeStrategySchema,
+			"strict_principals":  StrictPrincipalsSchema,
+			"computed_users":     ComputedAssignmentSchema,
+			"computed_groups":    ComputedAssignmentSchema,
 		},
 		Blocks: map[string]schema.Block{
 			"assignments": AssignmentSchema(
@@ -90,6 +102,19 @@ The priority block has a priority that defines the final assigned permissions of
 				"CREATEREPOSITORY",
 				"ADMINISTRATION",
 			),
+			"roles": RolesSchema(
+				[]string{
+					"READ",
+					"VIEWCONFIGURATION",
+					"WRITE",
+					"BUILD",
+					"CLONE",
+					"CREATE",
+					"CREATEREPOSITORY",
+					"ADMINISTRATION",
+				},
+				[]string{"READ"},
+			),
 		},
 	}
 }
@@ -184,7 +209,13 @@ func (receiver *ProjectResource) Update(ctx context.Context, request resource.Up
 		return
 	}
 
-	forceUpdate := !plan.AssignmentVersion.Equal(state.AssignmentVersion)
+	// permissions changed outside of Terraform are pushed again
+	drifted, diags := AssignmentDrift(ctx, request.State)
+	if util.TestDiagnostic(&response.Diagnostics, diags) {
+		return
+	}
+
+	forceUpdate := drifted || !plan.AssignmentVersion.Equal(state.AssignmentVersion)
 	computation, diags := UpdateProjectAssignments(ctx, receiver, plan, state, forceUpdate)
 	if util.TestDiagnostic(&response.Diagnostics, diags) {
 		return
@@ -198,6 +229,18 @@ func (receiver *ProjectResource) Update(ctx context.Context, request resource.Up
 	}
 }
 
+func (receiver *ProjectResource) ModifyPlan(ctx context.Context, request resource.ModifyPlanRequest, response *resource.ModifyPlanResponse) {
+	// nothing to check on create and destroy
+	if request.State.Raw.IsNull() || request.Plan.Raw.IsNull() {
+		return
+	}
+
+	diags := ModifyAssignmentPlan(ctx, request.State, &response.Plan)
+	if util.TestDiagnostic(&response.Diagnostics, diags) {
+		return
+	}
+}
+
 func (receiver *ProjectResource) Delete(ctx context.Context, request resource.DeleteRequest, response *resource.DeleteResponse) {
 	var (
 		diags diag.Diagnostics
@@ -3,6 +3,7 @@ package provider
 import (
 	"context"
 	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"strconv"
 	"strings"
@@ -22,8 +23,10 @@ var (
 	_ resource.Resource                   = &ProjectLinkedRepositoryResource{}
 	_ resource.ResourceWithConfigure      = &ProjectLinkedRepositoryResource{}
 	_ resource.ResourceWithImportState    = &ProjectLinkedRepositoryResource{}
+	_ resource.ResourceWithModifyPlan     = &ProjectLinkedRepositoryResource{}
 	_ LinkedRepositoryPermissionsReceiver = &ProjectLinkedRepositoryResource{}
 	_ ConfigurableReceiver                = &ProjectLinkedRepositoryResource{}
+	_ ExtendedConfigurableReceiver        = &ProjectLinkedRepositoryResource{}
 )
 
 func NewProjectLinkedRepositoryResource() resource.Resource {
@@ -31,8 +34,9 @@ func NewProjectLinkedRepositoryResource() resource.Resource {
 }
 
 type ProjectLinkedRepositoryResource struct {
-	config BambooProviderConfig
-	client *bamboo.Client
+	config         BambooProviderConfig
+	client         *bamboo.Client
+	extendedClient *ExtendedClient
 }
 
 func (receiver *ProjectLinkedRepositoryResource) setConfig(config BambooProviderConfig, client *bamboo.Client) {
@@ -40,6 +44,10 @@ func (receiver *ProjectLinkedRepositoryResource) setConfig(config BambooProvider
 	receiver.client = client
 }
 
+func (receiver *ProjectLinkedRepositoryResource) setExtendedClient(extendedClient *ExtendedClient) {
+	receiver.extendedClient = extendedClient
+}
+
 func (receiver *ProjectLinkedRepositoryResource) getClient() *bamboo.Client {
 	return receiver.client
 }
@@ -55,7 +63,7 @@ func (receiver *ProjectLinkedRepositoryResource) Schema(ctx context.Context, req
 One of the main focus of this resource is the permission management, which usually overlooked when creating linked repository through GUI.
 
 The priority block has a priority that defines the final assigned permissions of the user or group.`,
-		Attributes: map[string]schema.Attribute{
+		Attributes: withSpecsScanAttributes(map[string]schema.Attribute{
 			"id": schema.StringAttribute{
 				Computed:            true,
 				MarkdownDescription: "Numeric id of the linked repository.",
@@ -67,6 +75,24 @@ The priority block has a priority that defines the final assigned permissions of
 				},
 				MarkdownDescription: "Name of the linked repository.",
 			},
+			"retain_on_delete": schema.BoolAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(true),
+				MarkdownDescription: "Default value is `true`, and if the value set to `false` when the resource destroyed, the linked repository will be removed.",
+			},
+			"force_delete": schema.BoolAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+				MarkdownDescription: "Default value is `false`, the linked repository is only removed when no plan or deployment uses it, and the destroy fails with the list of users otherwise. Set to `true` to remove it regardless.",
+			},
+			"adopt_existing": schema.BoolAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+				MarkdownDescription: "Default value is `false`, and if the value set to `true` when a linked repository with the same name already exists in the project, the resource will take over the repository, update it to the planned Bitbucket repository and apply the assignments instead of failing.",
+			},
 			"rss_enabled": schema.BoolAttribute{
 				Optional:            true,
 				MarkdownDescription: "Flag to modify Bamboo Spec flag after creation.",
@@ -92,15 +118,18 @@ The priority block has a priority that defines the final assigned permissions of
 				MarkdownDescription: "Bitbucket repository branch.",
 				Default:             stringdefault.StaticString("master"),
 			},
-			"assignment_version": schema.StringAttribute{
-				Optional:            true,
-				MarkdownDescription: "Assignment version, used to force update the permission.",
-			},
-			"computed_users":  ComputedAssignmentSchema,
-			"computed_groups": ComputedAssignmentSchema,
-		},
+			"assignment_version": AssignmentVersionSchema,
+			"authoritative":      AuthoritativeSchema,
+			"ignore_principals":  IgnorePrincipalsSchema,
+			"merge_strategy":     MergeStrategySchema,
+			"strict_principals":  StrictPrincipalsSchema,
+			"computed_users":     ComputedAssignmentSchema,
+			"computed_groups":    ComputedAssignmentSchema,
+		}),
 		Blocks: map[string]schema.Block{
-			"assignments": AssignmentSchema("READ", "ADMINISTRATION"),
+			"assignments":      AssignmentSchema("READ", "ADMINISTRATION"),
+			"roles":            RolesSchema([]string{"READ", "ADMINISTRATION"}, nil),
+			"change_detection": ChangeDetectionSchema,
 		},
 	}
 }
@@ -154,26 +183,27 @@ func (receiver *ProjectLinkedRepositoryResource) Create(ctx context.Context, req
 	}
 
 	repository, err := receiver.client.RepositoryService().ReadProject(plan.Key.ValueString(), plan.Name.ValueString())
-	if err == nil && repository != nil {
-		response.Diagnostics.AddError("linked repository already exists", "Unable to create as the requested repository already exists, manual deletion of project linked repository may be required")
+	if util.TestError(&response.Diagnostics, err, errorFailedToReadRepository) {
 		return
 	}
 
-	repositoryId, err := receiver.client.RepositoryService().CreateProject(bamboo.CreateProjectRepository{
-		Project:        plan.Key.ValueString(),
-		Name:           plan.Name.ValueString(),
-		ProjectKey:     strings.ToLower(plan.Project.ValueString()),
-		RepositorySlug: strings.ToLower(plan.Slug.ValueString()),
-		ServerId:       receiver.config.BambooRss.Server.ValueString(),
-		ServerName:     receiver.config.BambooRss.Name.ValueString(),
-		CloneUrl: strings.ToLower(fmt.Sprintf(
-			receiver.config.BambooRss.CloneUrl.ValueString(),
-			plan.Project.ValueString(),
-			plan.Slug.ValueString(),
-		)),
-	})
-	if util.TestError(&response.Diagnostics, err, errorFailedToReadRepository) {
-		return
+	var repositoryId int
+	if repository != nil {
+		if !plan.AdoptExisting.ValueBool() {
+			response.Diagnostics.AddError("linked repository already exists", "Unable to create as the requested repository already exists, set adopt_existing to take over the repository, or manual deletion of project linked repository may be required")
+			return
+		}
+
+		// take over the existing repository, and point it to the planned Bitbucket repository
+		repositoryId, err = receiver.importRepository(plan, repository.ID)
+		if util.TestError(&response.Diagnostics, err, errorFailedToUpdateRepository) {
+			return
+		}
+	} else {
+		repositoryId, err = receiver.importRepository(plan, 0)
+		if util.TestError(&response.Diagnostics, err, errorFailedToAddRepository) {
+			return
+		}
 	}
 
 	err = receiver.client.RepositoryService().EnableCI(repositoryId, plan.RssEnabled.ValueBool())
@@ -181,12 +211,8 @@ func (receiver *ProjectLinkedRepositoryResource) Create(ctx context.Context, req
 		return
 	}
 
-	if plan.RssEnabled.ValueBool() {
-		err = receiver.client.RepositoryService().ScanCI(repositoryId)
-		if util.TestError(&response.Diagnostics, err, "Failed to execute scan repository") {
-			return
-		}
-	}
+	scan, scanDiags := scanSpecs(ctx, receiver, receiver.extendedClient, repositoryId, plan.specsScanSettings())
+	plan.LastSpecsScanResult, plan.LastSpecsScanTime = specsScanAttributes(scan)
 
 	repository = &bamboo.Repository{
 		ID:         repositoryId,
@@ -206,6 +232,30 @@ func (receiver *ProjectLinkedRepositoryResource) Create(ctx context.Context, req
 	if util.TestDiagnostic(&response.Diagnostics, diags) {
 		return
 	}
+
+	// a failed scan is reported after the repository is recorded in the state
+	response.Diagnostics.Append(scanDiags...)
+}
+
+// importRepository creates the linked repository when repositoryId is 0, otherwise updates the existing one.
+func (receiver *ProjectLinkedRepositoryResource) importRepository(plan ProjectLinkedRepositoryModel, repositoryId int) (int, error) {
+	var oid string
+	if repositoryId != 0 {
+		var err error
+		oid, err = receiver.extendedClient.RepositoryService().ReadOid(repositoryId)
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	return receiver.extendedClient.RepositoryService().Import(NewBitbucketServerRepositorySpec(receiver.config.BambooRss,
+		plan.Key.ValueString(),
+		plan.Name.ValueString(),
+		plan.Project.ValueString(),
+		plan.Slug.ValueString(),
+		plan.Branch.ValueString(),
+		plan.ChangeDetection.settings(),
+	), oid)
 }
 
 func (receiver *ProjectLinkedRepositoryResource) Read(ctx context.Context, request resource.ReadRequest, response *resource.ReadResponse) {
@@ -231,6 +281,27 @@ func (receiver *ProjectLinkedRepositoryResource) Read(ctx context.Context, reque
 	}
 
 	state.ID = types.StringValue(fmt.Sprintf("%d", repository.ID))
+	state.RssEnabled = refreshBool(state.RssEnabled, repository.RssEnabled)
+
+	detail, err := receiver.extendedClient.RepositoryService().ReadDetail(repository.ID)
+	if util.TestError(&response.Diagnostics, err, errorFailedToReadRepository) {
+		return
+	}
+
+	if detail != nil {
+		ignoreCase := receiver.config.BambooRss.Lowercase.ValueBool()
+		state.Branch = refreshString(state.Branch, detail.Branch, false)
+		state.Project = refreshString(state.Project, detail.ProjectKey, ignoreCase)
+		state.Slug = refreshString(state.Slug, detail.Slug, ignoreCase)
+		state.ChangeDetection = refreshChangeDetection(state.ChangeDetection, detail.ChangeDetection())
+	}
+
+	scan, err := receiver.extendedClient.RepositoryService().ReadSpecsState(repository.ID)
+	if util.TestError(&response.Diagnostics, err, errorFailedToReadSpecsScan) {
+		return
+	}
+
+	state.LastSpecsScanResult, state.LastSpecsScanTime = specsScanAttributes(scan)
 
 	computation, diags := ComputeProjectLinkedRepositoryAssignments(ctx, receiver, state)
 	if util.TestDiagnostic(&response.Diagnostics, diags) {
@@ -272,38 +343,30 @@ func (receiver *ProjectLinkedRepositoryResource) Update(ctx context.Context, req
 		return
 	}
 
+	if !plan.Project.Equal(state.Project) || !plan.Slug.Equal(state.Slug) || !plan.Branch.Equal(state.Branch) ||
+		plan.ChangeDetection.settings() != state.ChangeDetection.settings() {
+		_, err = receiver.importRepository(plan, repository.ID)
+		if util.TestError(&response.Diagnostics, err, errorFailedToUpdateRepository) {
+			return
+		}
+	}
+
 	err = receiver.client.RepositoryService().EnableCI(repository.ID, plan.RssEnabled.ValueBool())
 	if util.TestError(&response.Diagnostics, err, errorFailedToUpdateRepository) {
 		return
 	}
 
-	if plan.RssEnabled.ValueBool() {
-		err = receiver.client.RepositoryService().ScanCI(repository.ID)
-		if util.TestError(&response.Diagnostics, err, "Failed to execute scan repository") {
-			return
-		}
-	}
+	// scan after the repository points to the planned branch
+	scan, scanDiags := scanSpecs(ctx, receiver, receiver.extendedClient, repository.ID, plan.specsScanSettings())
+	plan.LastSpecsScanResult, plan.LastSpecsScanTime = specsScanAttributes(scan)
 
-	if !plan.Project.Equal(state.Project) || !plan.Slug.Equal(state.Slug) {
-		err = receiver.client.RepositoryService().UpdateProject(repository.ID, bamboo.CreateProjectRepository{
-			Project:        plan.Key.ValueString(),
-			Name:           plan.Name.ValueString(),
-			ProjectKey:     strings.ToLower(plan.Project.ValueString()),
-			RepositorySlug: strings.ToLower(plan.Slug.ValueString()),
-			ServerId:       receiver.config.BambooRss.Server.ValueString(),
-			ServerName:     receiver.config.BambooRss.Name.ValueString(),
-			CloneUrl: strings.ToLower(fmt.Sprintf(
-				receiver.config.BambooRss.CloneUrl.ValueString(),
-				plan.Project.ValueString(),
-				plan.Slug.ValueString(),
-			)),
-		})
-		if util.TestError(&response.Diagnostics, err, errorFailedToUpdateRepository) {
-			return
-		}
+	// permissions changed outside of Terraform are pushed again
+	drifted, diags := AssignmentDrift(ctx, request.State)
+	if util.TestDiagnostic(&response.Diagnostics, diags) {
+		return
 	}
 
-	forceUpdate := !plan.AssignmentVersion.Equal(state.AssignmentVersion)
+	forceUpdate := drifted || !plan.AssignmentVersion.Equal(state.AssignmentVersion)
 	computation, diags := UpdateProjectLinkedRepositoryAssignments(ctx, receiver, plan, state, forceUpdate)
 	if util.TestDiagnostic(&response.Diagnostics, diags) {
 		return
@@ -315,6 +378,21 @@ func (receiver *ProjectLinkedRepositoryResource) Update(ctx context.Context, req
 	if util.TestDiagnostic(&response.Diagnostics, diags) {
 		return
 	}
+
+	// a failed scan is reported after the repository is recorded in the state
+	response.Diagnostics.Append(scanDiags...)
+}
+
+func (receiver *ProjectLinkedRepositoryResource) ModifyPlan(ctx context.Context, request resource.ModifyPlanRequest, response *resource.ModifyPlanResponse) {
+	// nothing to check on create and destroy
+	if request.State.Raw.IsNull() || request.Plan.Raw.IsNull() {
+		return
+	}
+
+	diags := ModifyAssignmentPlan(ctx, request.State, &response.Plan)
+	if util.TestDiagnostic(&response.Diagnostics, diags) {
+		return
+	}
 }
 
 func (receiver *ProjectLinkedRepositoryResource) Delete(ctx context.Context, request resource.DeleteRequest, response *resource.DeleteResponse) {
@@ -333,9 +411,25 @@ func (receiver *ProjectLinkedRepositoryResource) Delete(ctx context.Context, req
 		return
 	}
 
-	err = receiver.client.RepositoryService().DeleteProject(state.Project.ValueString(), repositoryId)
-	if util.TestError(&response.Diagnostics, err, errorFailedToUpdateRepository) {
-		return
+	if !state.RetainOnDelete.ValueBool() {
+		if !state.ForceDelete.ValueBool() {
+			usage, err := receiver.extendedClient.RepositoryService().ReadUsage(repositoryId)
+			if util.TestError(&response.Diagnostics, err, errorFailedToReadRepositoryUsage) {
+				return
+			}
+
+			if usage.inUse() {
+				response.Diagnostics.AddError(errorLinkedRepositoryInUse, fmt.Sprintf(
+					"Linked repository %s is still used by\n%s\nRemove the usages, or set force_delete to remove it regardless.",
+					state.Name.ValueString(), describeRepositoryUsage(*usage)))
+				return
+			}
+		}
+
+		err = receiver.client.RepositoryService().DeleteProject(state.Key.ValueString(), repositoryId)
+		if util.TestError(&response.Diagnostics, err, errorFailedToRemoveRepository) {
+			return
+		}
 	}
 
 	response.State.RemoveResource(ctx)
@@ -344,15 +438,28 @@ func (receiver *ProjectLinkedRepositoryResource) Delete(ctx context.Context, req
 func (receiver *ProjectLinkedRepositoryResource) ImportState(ctx context.Context, request resource.ImportStateRequest, response *resource.ImportStateResponse) {
 	slug := strings.Split(request.ID, "/")
 	diags := response.State.Set(ctx, &ProjectLinkedRepositoryModel{
-		Key:               types.StringValue(slug[0]),
-		Name:              types.StringValue(slug[1]),
-		RssEnabled:        types.BoolNull(),
-		Project:           types.StringNull(),
-		Slug:              types.StringNull(),
-		AssignmentVersion: types.StringNull(),
-		Assignments:       types.ListNull(assignmentType),
-		ComputedUsers:     types.ListNull(computedAssignmentType),
-		ComputedGroups:    types.ListNull(computedAssignmentType),
+		Key:                  types.StringValue(slug[0]),
+		Name:                 types.StringValue(slug[1]),
+		AdoptExisting:        types.BoolValue(false),
+		RetainOnDelete:       types.BoolValue(true),
+		ForceDelete:          types.BoolValue(false),
+		WaitForSpecsScan:     types.BoolValue(false),
+		SpecsScanTimeout:     types.Int64Value(defaultSpecsScanTimeout),
+		FailOnSpecsScanError: types.BoolValue(true),
+		LastSpecsScanResult:  types.StringNull(),
+		LastSpecsScanTime:    types.StringNull(),
+		RssEnabled:           types.BoolNull(),
+		Project:              types.StringNull(),
+		Slug:                 types.StringNull(),
+		AssignmentVersion:    types.StringNull(),
+		Authoritative:        types.BoolValue(false),
+		IgnorePrincipals:     types.ListNull(types.StringType),
+		MergeStrategy:        types.StringValue(mergeStrategyOverride),
+		StrictPrincipals:     types.BoolValue(false),
+		Assignments:          types.ListNull(assignmentType),
+		Roles:                nil,
+		ComputedUsers:        types.ListNull(computedAssignmentType),
+		ComputedGroups:       types.ListNull(computedAssignmentType),
 	})
 	if util.TestDiagnostic(&response.Diagnostics, diags) {
 		return
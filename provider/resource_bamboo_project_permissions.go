@@ -16,8 +16,10 @@ var (
 	_ resource.Resource                = &ProjectPermissionsResource{}
 	_ resource.ResourceWithConfigure   = &ProjectPermissionsResource{}
 	_ resource.ResourceWithImportState = &ProjectPermissionsResource{}
+	_ resource.ResourceWithModifyPlan  = &ProjectPermissionsResource{}
 	_ ProjectPermissionsReceiver       = &ProjectPermissionsResource{}
 	_ ConfigurableReceiver             = &ProjectPermissionsResource{}
+	_ ExtendedConfigurableReceiver     = &ProjectPermissionsResource{}
 )
 
 func NewProjectPermissionsResource() resource.Resource {
@@ -25,8 +27,9 @@ func NewProjectPermissionsResource() resource.Resource {
 }
 
 type ProjectPermissionsResource struct {
-	config BambooProviderConfig
-	client *bamboo.Client
+	config         BambooProviderConfig
+	client         *bamboo.Client
+	extendedClient *ExtendedClient
 }
 
 func (receiver *ProjectPermissionsResource) setConfig(config BambooProviderConfig, client *bamboo.Client) {
@@ -34,10 +37,18 @@ func (receiver *ProjectPermissionsResource) setConfig(config BambooProviderConfi
 	receiver.client = client
 }
 
+func (receiver *ProjectPermissionsResource) setExtendedClient(extendedClient *ExtendedClient) {
+	receiver.extendedClient = extendedClient
+}
+
 func (receiver *ProjectPermissionsResource) getClient() *bamboo.Client {
 	return receiver.client
 }
 
+func (receiver *ProjectPermissionsResource) getExtendedClient() *ExtendedClient {
+	return receiver.extendedClient
+}
+
 func (receiver *ProjectPermissionsResource) Metadata(ctx context.Context, request resource.MetadataRequest, response *resource.MetadataResponse) {
 	response.TypeName = request.ProviderTypeName + "_project_permissions"
 }
@@ -61,12 +72,13 @@ The priority block has a priority that defines the final assigned permissions of
 				},
 				MarkdownDescription: "Project key where the permissions will be added.",
 			},
-			"assignment_version": schema.StringAttribute{
-				Optional:            true,
-				MarkdownDescription: "Assignment version, used to force update the permission.",
-			},
-			"computed_users":  ComputedAssignmentSchema,
-			"computed_groups": ComputedAssignmentSchema,
+			"assignment_version": AssignmentVersionSchema,
+			"authoritative":      AuthoritativeSchema,
+			"ignore_principals":  IgnorePrincipalsSchema,
+			"merge_strategy":     MergeStrategySchema,
+			"strict_principals":  StrictPrincipalsSchema,
+			"computed_users":     ComputedAssignmentSchema,
+			"computed_groups":    ComputedAssignmentSchema,
 		},
 		Blocks: map[string]schema.Block{
 			"assignments": AssignmentSchema(
@@ -79,6 +91,19 @@ The priority block has a priority that defines the final assigned permissions of
 				"CREATEREPOSITORY",
 				"ADMINISTRATION",
 			),
+			"roles": RolesSchema(
+				[]string{
+					"READ",
+					"VIEWCONFIGURATION",
+					"WRITE",
+					"BUILD",
+					"CLONE",
+					"CREATE",
+					"CREATEREPOSITORY",
+					"ADMINISTRATION",
+				},
+				[]string{"READ"},
+			),
 		},
 	}
 }
@@ -154,7 +179,13 @@ func (receiver *ProjectPermissionsResource) Update(ctx context.Context, request
 		return
 	}
 
-	forceUpdate := !plan.AssignmentVersion.Equal(state.AssignmentVersion)
+	// permissions changed outside of Terraform are pushed again
+	drifted, diags := AssignmentDrift(ctx, request.State)
+	if util.TestDiagnostic(&response.Diagnostics, diags) {
+		return
+	}
+
+	forceUpdate := drifted || !plan.AssignmentVersion.Equal(state.AssignmentVersion)
 	computation, diags := UpdateProjectAssignments(ctx, receiver, plan, state, forceUpdate)
 	if util.TestDiagnostic(&response.Diagnostics, diags) {
 		return
@@ -168,6 +199,18 @@ func (receiver *ProjectPermissionsResource) Update(ctx context.Context, request
 	}
 }
 
+func (receiver *ProjectPermissionsResource) ModifyPlan(ctx context.Context, request resource.ModifyPlanRequest, response *resource.ModifyPlanResponse) {
+	// nothing to check on create and destroy
+	if request.State.Raw.IsNull() || request.Plan.Raw.IsNull() {
+		return
+	}
+
+	diags := ModifyAssignmentPlan(ctx, request.State, &response.Plan)
+	if util.TestDiagnostic(&response.Diagnostics, diags) {
+		return
+	}
+}
+
 func (receiver *ProjectPermissionsResource) Delete(ctx context.Context, request resource.DeleteRequest, response *resource.DeleteResponse) {
 	var (
 		diags diag.Diagnostics
@@ -0,0 +1,328 @@
+package provider
+
+import (
+	"context"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/yunarta/terraform-provider-bamboo/provider/test"
+)
+
+// computedNames lists the names in a computed_users or computed_groups attribute.
+func computedNames(t *testing.T, state tfsdk.State, name string) []string {
+	t.Helper()
+
+	var computed []ComputedAssignment
+	if diags := state.GetAttribute(context.Background(), path.Root(name), &computed); diags.HasError() {
+		t.Fatalf("GetAttribute() diagnostics = %v", diags)
+	}
+
+	names := make([]string, 0)
+	for _, assignment := range computed {
+		names = append(names, assignment.Name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+func newPermissionVirtualization() *test.ServiceVirtualization {
+	virtualization := test.NewServiceVirtualization()
+	virtualization.AddProject("PROJ", "Project")
+	for _, user := range []string{"alice", "bob", "mallory", "svc-bamboo"} {
+		virtualization.AddUser(user)
+	}
+	virtualization.AddGroup("developers")
+	virtualization.AddGroup("contractors")
+
+	// granted outside of Terraform
+	permissions := virtualization.Permissions("project/PROJ")
+	permissions.Users["mallory"] = []string{"READ", "ADMINISTRATION"}
+	permissions.Users["svc-bamboo"] = []string{"READ", "CREATE"}
+	permissions.Groups["contractors"] = []string{"READ"}
+	return virtualization
+}
+
+func TestProjectPermissionsResource_Authoritative(t *testing.T) {
+	tests := []struct {
+		name          string
+		authoritative bool
+		wantUsers     []string
+		wantGroups    []string
+		wantRevoked   []string
+	}{
+		{
+			name:          "additive",
+			authoritative: false,
+			wantUsers:     []string{"alice"},
+			wantGroups:    []string{"developers"},
+		},
+		{
+			name:          "authoritative",
+			authoritative: true,
+			wantUsers:     []string{"alice"},
+			wantGroups:    []string{"developers"},
+			wantRevoked:   []string{"mallory", "contractors"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			virtualization := newPermissionVirtualization()
+			permissions := virtualization.Permissions("project/PROJ")
+
+			harness := newResourceHarness(t, NewProjectPermissionsResource(), virtualization, testBambooRss(false))
+			state, diags := harness.create(harness.plan(map[string]any{
+				"key":               "PROJ",
+				"retain_on_delete":  true,
+				"authoritative":     tt.authoritative,
+				"ignore_principals": []string{"SVC-BAMBOO"},
+				"assignments": []Assignment{
+					{Users: []string{"alice"}, Groups: []string{"developers"}, Permissions: []string{"READ"}, Priority: 1},
+				},
+			}))
+			harness.require(diags)
+
+			if got := computedNames(t, state, "computed_users"); !reflect.DeepEqual(got, tt.wantUsers) {
+				t.Errorf("Create() computed_users = %v, want %v", got, tt.wantUsers)
+			}
+
+			if got := computedNames(t, state, "computed_groups"); !reflect.DeepEqual(got, tt.wantGroups) {
+				t.Errorf("Create() computed_groups = %v, want %v", got, tt.wantGroups)
+			}
+
+			revoked := map[string]bool{}
+			for _, principal := range tt.wantRevoked {
+				revoked[principal] = true
+			}
+
+			if got := len(permissions.Users["mallory"]) == 0; got != revoked["mallory"] {
+				t.Errorf("Create() mallory revoked = %v, want %v", got, revoked["mallory"])
+			}
+
+			if got := len(permissions.Groups["contractors"]) == 0; got != revoked["contractors"] {
+				t.Errorf("Create() contractors revoked = %v, want %v", got, revoked["contractors"])
+			}
+
+			if got := permissions.Users["svc-bamboo"]; len(got) == 0 {
+				t.Errorf("Create() revoked the ignored principal svc-bamboo")
+			}
+
+			// granted outside of Terraform after create, only reported in authoritative mode
+			permissions.Users["bob"] = []string{"READ"}
+			state, diags = harness.read(state)
+			harness.require(diags)
+
+			wantUsers := tt.wantUsers
+			if tt.authoritative {
+				wantUsers = []string{"alice", "bob"}
+			}
+
+			if got := computedNames(t, state, "computed_users"); !reflect.DeepEqual(got, wantUsers) {
+				t.Errorf("Read() computed_users = %v, want %v", got, wantUsers)
+			}
+
+			state, diags = harness.update(harness.planFrom(state, map[string]any{
+				"assignment_version": "2",
+			}), state)
+			harness.require(diags)
+
+			if got := len(permissions.Users["bob"]) == 0; got != tt.authoritative {
+				t.Errorf("Update() bob revoked = %v, want %v", got, tt.authoritative)
+			}
+
+			if got := computedNames(t, state, "computed_users"); !reflect.DeepEqual(got, tt.wantUsers) {
+				t.Errorf("Update() computed_users = %v, want %v", got, tt.wantUsers)
+			}
+		})
+	}
+}
+
+func TestProjectPermissionsResource_Drift(t *testing.T) {
+	virtualization := newPermissionVirtualization()
+	permissions := virtualization.Permissions("project/PROJ")
+
+	harness := newResourceHarness(t, NewProjectPermissionsResource(), virtualization, testBambooRss(false))
+	state, diags := harness.create(harness.plan(map[string]any{
+		"key":              "PROJ",
+		"retain_on_delete": true,
+		"authoritative":    false,
+		"assignments": []Assignment{
+			// ghost does not exist in Bamboo, it must not show up as drift
+			{Users: []string{"alice", "ghost"}, Groups: []string{"developers"}, Permissions: []string{"READ"}, Priority: 1},
+		},
+	}))
+	harness.require(diags)
+
+	planned := func(step string, wantUpdate bool) tfsdk.Plan {
+		t.Helper()
+
+		state, diags = harness.read(state)
+		harness.require(diags)
+
+		plan, diags := harness.modifyPlan(harness.planFrom(state, nil), state)
+		harness.require(diags)
+
+		var computedUsers types.List
+		harness.require(plan.GetAttribute(context.Background(), path.Root("computed_users"), &computedUsers))
+		if got := computedUsers.IsUnknown(); got != wantUpdate {
+			t.Errorf("%s ModifyPlan() planned update = %v, want %v", step, got, wantUpdate)
+		}
+
+		return plan
+	}
+
+	planned("Create", false)
+
+	// changed in the UI, project permissions are spread over the project and its plans
+	permissions.Users["alice"] = []string{"READ", "ADMINISTRATION"}
+	permissions.Groups["developers"] = []string{}
+	virtualization.Permissions("projectplan/PROJ").Groups["developers"] = []string{}
+
+	plan := planned("Drift", true)
+	state, diags = harness.update(plan, state)
+	harness.require(diags)
+
+	if got := permissions.Users["alice"]; !reflect.DeepEqual(got, []string{"READ"}) {
+		t.Errorf("Update() alice permissions = %v, want [READ]", got)
+	}
+
+	if got := permissions.Groups["developers"]; !reflect.DeepEqual(got, []string{"READ"}) {
+		t.Errorf("Update() developers permissions = %v, want [READ]", got)
+	}
+
+	planned("Update", false)
+}
+
+func TestProjectPermissionsResource_UnresolvedPrincipals(t *testing.T) {
+	tests := []struct {
+		name         string
+		strict       bool
+		wantErrors   int
+		wantWarnings int
+		wantGranted  bool
+	}{
+		{name: "warning", strict: false, wantWarnings: 2, wantGranted: true},
+		{name: "strict", strict: true, wantErrors: 2, wantGranted: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			virtualization := newPermissionVirtualization()
+			permissions := virtualization.Permissions("project/PROJ")
+
+			harness := newResourceHarness(t, NewProjectPermissionsResource(), virtualization, testBambooRss(false))
+			_, diags := harness.create(harness.plan(map[string]any{
+				"key":               "PROJ",
+				"retain_on_delete":  true,
+				"authoritative":     false,
+				"strict_principals": tt.strict,
+				"assignments": []Assignment{
+					{Users: []string{"alice", "ghost"}, Groups: []string{"developer"}, Permissions: []string{"READ"}, Priority: 1},
+				},
+			}))
+
+			if got := diags.ErrorsCount(); got != tt.wantErrors {
+				t.Errorf("Create() errors = %d, want %d: %v", got, tt.wantErrors, diags)
+			}
+
+			if got := diags.WarningsCount(); got != tt.wantWarnings {
+				t.Errorf("Create() warnings = %d, want %d: %v", got, tt.wantWarnings, diags)
+			}
+
+			for _, diagnostic := range diags {
+				if !strings.Contains(diagnostic.Detail(), `project "PROJ"`) {
+					t.Errorf("Create() diagnostic %q does not name the project", diagnostic.Detail())
+				}
+			}
+
+			if got := len(permissions.Users["alice"]) > 0; got != tt.wantGranted {
+				t.Errorf("Create() alice granted = %v, want %v", got, tt.wantGranted)
+			}
+		})
+	}
+}
+
+func TestProjectPermissionsResource_Roles(t *testing.T) {
+	virtualization := newPermissionVirtualization()
+	projectPermissions := virtualization.Permissions("project/PROJ")
+	planPermissions := virtualization.Permissions("projectplan/PROJ")
+
+	// granted outside of Terraform, revoked as the roles block does not declare it
+	projectPermissions.Roles["ANONYMOUS"] = []string{"READ"}
+	planPermissions.Roles["ANONYMOUS"] = []string{"READ"}
+
+	roles := &RolesModel{
+		LoggedIn:  types.ListValueMust(types.StringType, []attr.Value{types.StringValue("READ")}),
+		Anonymous: types.ListNull(types.StringType),
+	}
+
+	harness := newResourceHarness(t, NewProjectPermissionsResource(), virtualization, testBambooRss(false))
+	config := harness.plan(map[string]any{
+		"key":              "PROJ",
+		"retain_on_delete": true,
+		"authoritative":    false,
+		"roles":            roles,
+		"assignments": []Assignment{
+			{Users: []string{"alice"}, Permissions: []string{"READ"}, Priority: 1},
+		},
+	})
+
+	state, diags := harness.create(config)
+	harness.require(diags)
+
+	if got := projectPermissions.Roles["LOGGED_IN"]; !reflect.DeepEqual(got, []string{"READ"}) {
+		t.Errorf("Create() logged in permissions = %v, want [READ]", got)
+	}
+
+	if got := projectPermissions.Roles["ANONYMOUS"]; len(got) > 0 {
+		t.Errorf("Create() anonymous permissions = %v, want none", got)
+	}
+
+	rolePermissions := func(step string) []string {
+		t.Helper()
+
+		var known *RolesModel
+		harness.require(state.GetAttribute(context.Background(), path.Root("roles"), &known))
+		if known == nil {
+			t.Fatalf("%s roles = nil", step)
+		}
+
+		permissions := make([]string, 0)
+		harness.require(known.LoggedIn.ElementsAs(context.Background(), &permissions, true))
+		return permissions
+	}
+
+	state, diags = harness.read(state)
+	harness.require(diags)
+
+	if got := rolePermissions("Read"); !reflect.DeepEqual(got, []string{"READ"}) {
+		t.Errorf("Read() logged_in = %v, want [READ]", got)
+	}
+
+	// changed in the UI, reported by read so the plan shows the difference
+	projectPermissions.Roles["LOGGED_IN"] = []string{"READ", "ADMINISTRATION"}
+	state, diags = harness.read(state)
+	harness.require(diags)
+
+	if got := rolePermissions("Drift"); !reflect.DeepEqual(got, []string{"ADMINISTRATION", "READ"}) {
+		t.Errorf("Read() logged_in = %v, want [ADMINISTRATION READ]", got)
+	}
+
+	state, diags = harness.update(config, state)
+	harness.require(diags)
+
+	if got := projectPermissions.Roles["LOGGED_IN"]; !reflect.DeepEqual(got, []string{"READ"}) {
+		t.Errorf("Update() logged in permissions = %v, want [READ]", got)
+	}
+
+	if got := rolePermissions("Update"); !reflect.DeepEqual(got, []string{"READ"}) {
+		t.Errorf("Update() logged_in = %v, want [READ]", got)
+	}
+}
@@ -3,7 +3,6 @@ package provider
 import (
 	"context"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
-	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
@@ -12,7 +11,6 @@ import (
 	"github.com/yunarta/golang-quality-of-life-pack/collections"
 	"github.com/yunarta/terraform-atlassian-api-client/bamboo"
 	"github.com/yunarta/terraform-provider-commons/util"
-	"sort"
 	"strconv"
 )
 
@@ -20,6 +18,9 @@ type ProjectRepositoriesModel struct {
 	RetainOnDelete types.Bool   `tfsdk:"retain_on_delete"`
 	Key            types.String `tfsdk:"key"`
 	Repositories   types.List   `tfsdk:"repositories"`
+
+	Repository           []RepositoryReferenceModel `tfsdk:"repository"`
+	ResolvedRepositories types.List                 `tfsdk:"resolved_repositories"`
 }
 
 var (
@@ -27,6 +28,7 @@ var (
 	_ resource.ResourceWithConfigure   = &ProjectRepositoriesResource{}
 	_ resource.ResourceWithImportState = &ProjectRepositoriesResource{}
 	_ ConfigurableReceiver             = &ProjectRepositoriesResource{}
+	_ ExtendedConfigurableReceiver     = &ProjectRepositoriesResource{}
 )
 
 func NewProjectRepositoriesResource() resource.Resource {
@@ -34,8 +36,9 @@ func NewProjectRepositoriesResource() resource.Resource {
 }
 
 type ProjectRepositoriesResource struct {
-	config BambooProviderConfig
-	client *bamboo.Client
+	config         BambooProviderConfig
+	client         *bamboo.Client
+	extendedClient *ExtendedClient
 }
 
 func (receiver *ProjectRepositoriesResource) setConfig(config BambooProviderConfig, client *bamboo.Client) {
@@ -43,6 +46,10 @@ func (receiver *ProjectRepositoriesResource) setConfig(config BambooProviderConf
 	receiver.client = client
 }
 
+func (receiver *ProjectRepositoriesResource) setExtendedClient(extendedClient *ExtendedClient) {
+	receiver.extendedClient = extendedClient
+}
+
 func (receiver *ProjectRepositoriesResource) Metadata(ctx context.Context, request resource.MetadataRequest, response *resource.MetadataResponse) {
 	response.TypeName = request.ProviderTypeName + "_project_repositories"
 }
@@ -51,7 +58,9 @@ func (receiver *ProjectRepositoriesResource) Schema(ctx context.Context, request
 	response.Schema = schema.Schema{
 		MarkdownDescription: `This resource define project repository spec permissions.
 
-In order for the execution to be successful, the user must have user access to all the specified repositories.`,
+In order for the execution to be successful, the user must have user access to all the specified repositories.
+
+Import with the project key, the repositories currently granted access are listed by id in ` + "`repositories`" + `.`,
 		Attributes: map[string]schema.Attribute{
 			"retain_on_delete": schema.BoolAttribute{
 				Optional:            true,
@@ -67,10 +76,14 @@ In order for the execution to be successful, the user must have user access to a
 				MarkdownDescription: "Project key where the variable will be added",
 			},
 			"repositories": schema.ListAttribute{
-				Required:            true,
+				Optional:            true,
 				ElementType:         types.StringType,
-				MarkdownDescription: "This project will add this list of linked repositories into its permission.",
+				MarkdownDescription: "This project will add this list of linked repositories, by numeric id or name, into its permission.",
 			},
+			"resolved_repositories": ResolvedRepositoriesSchema("repositories", "repository"),
+		},
+		Blocks: map[string]schema.Block{
+			"repository": RepositoryReferenceBlock("Linked repository to add into the permission, referenced by `id`, `name`, or `project_key` and `name`."),
 		},
 	}
 }
@@ -81,9 +94,8 @@ func (receiver *ProjectRepositoriesResource) Configure(ctx context.Context, requ
 
 func (receiver *ProjectRepositoriesResource) Create(ctx context.Context, request resource.CreateRequest, response *resource.CreateResponse) {
 	var (
-		plan                 ProjectRepositoriesModel
-		diags                diag.Diagnostics
-		projectRepositoryIDs = make([]string, 0)
+		plan  ProjectRepositoriesModel
+		diags diag.Diagnostics
 	)
 
 	diags = request.Plan.Get(ctx, &plan)
@@ -91,27 +103,35 @@ func (receiver *ProjectRepositoriesResource) Create(ctx context.Context, request
 		return
 	}
 
-	diags = plan.Repositories.ElementsAs(ctx, &projectRepositoryIDs, true)
+	references, diags := NewRepositoryReferences(ctx, plan.Repositories, plan.Repository)
 	if util.TestDiagnostic(&response.Diagnostics, diags) {
 		return
 	}
 
-	for _, repository := range projectRepositoryIDs {
-		repositoryId, err := strconv.Atoi(repository)
-		if util.TestError(&response.Diagnostics, err, errorProvidedRepositoryMustBeNumber) {
-			return
-		}
+	resolved, diags := NewRepositoryResolver(receiver.client, receiver.extendedClient).ResolveAll(references)
+	if util.TestDiagnostic(&response.Diagnostics, diags) {
+		return
+	}
 
-		_, err = receiver.client.ProjectService().AddSpecRepositories(plan.Key.ValueString(), repositoryId)
+	for _, repository := range resolvedIds(resolved) {
+		repositoryId, _ := strconv.Atoi(repository)
+		_, err := receiver.client.ProjectService().AddSpecRepositories(plan.Key.ValueString(), repositoryId)
 		if util.TestError(&response.Diagnostics, err, errorFailedToAddProjectRepositories) {
 			return
 		}
 	}
 
+	resolvedRepositories, diags := resolvedList(ctx, resolved)
+	if util.TestDiagnostic(&response.Diagnostics, diags) {
+		return
+	}
+
 	diags = response.State.Set(ctx, &ProjectRepositoriesModel{
-		RetainOnDelete: plan.RetainOnDelete,
-		Key:            types.StringValue(plan.Key.ValueString()),
-		Repositories:   plan.Repositories,
+		RetainOnDelete:       plan.RetainOnDelete,
+		Key:                  types.StringValue(plan.Key.ValueString()),
+		Repositories:         plan.Repositories,
+		Repository:           references.Blocks,
+		ResolvedRepositories: resolvedRepositories,
 	})
 	if util.TestDiagnostic(&response.Diagnostics, diags) {
 		return
@@ -120,12 +140,9 @@ func (receiver *ProjectRepositoriesResource) Create(ctx context.Context, request
 
 func (receiver *ProjectRepositoriesResource) Read(ctx context.Context, request resource.ReadRequest, response *resource.ReadResponse) {
 	var (
-		state                 ProjectRepositoriesModel
-		diags                 diag.Diagnostics
-		err                   error
-		projectRepositoryIDs  = make([]string, 0)
-		plannedRepositoryIDs  = make([]string, 0)
-		existingRepositoryIDs = make([]string, 0)
+		state ProjectRepositoriesModel
+		diags diag.Diagnostics
+		err   error
 	)
 
 	diags = request.State.Get(ctx, &state)
@@ -139,31 +156,32 @@ func (receiver *ProjectRepositoriesResource) Read(ctx context.Context, request r
 		return
 	}
 
-	for _, repository := range repositories {
-		projectRepositoryIDs = append(projectRepositoryIDs, strconv.Itoa(repository.ID))
+	references, diags := NewRepositoryReferences(ctx, state.Repositories, state.Repository)
+	if util.TestDiagnostic(&response.Diagnostics, diags) {
+		return
 	}
 
-	diags = state.Repositories.ElementsAs(ctx, &plannedRepositoryIDs, true)
+	refreshed, resolved, diags := NewRepositoryResolver(receiver.client, receiver.extendedClient).Refresh(references, repositories, false)
 	if util.TestDiagnostic(&response.Diagnostics, diags) {
 		return
 	}
 
-	for _, project := range plannedRepositoryIDs {
-		if collections.Contains(projectRepositoryIDs, project) {
-			existingRepositoryIDs = append(existingRepositoryIDs, project)
-		}
+	listValue, diags := refreshed.List(ctx)
+	if util.TestDiagnostic(&response.Diagnostics, diags) {
+		return
 	}
 
-	sort.Strings(existingRepositoryIDs)
-	listValue, diags := types.ListValueFrom(ctx, types.StringType, existingRepositoryIDs)
+	resolvedRepositories, diags := resolvedList(ctx, resolved)
 	if util.TestDiagnostic(&response.Diagnostics, diags) {
 		return
 	}
 
 	diags = response.State.Set(ctx, &ProjectRepositoriesModel{
-		RetainOnDelete: state.RetainOnDelete,
-		Key:            types.StringValue(state.Key.ValueString()),
-		Repositories:   listValue,
+		RetainOnDelete:       state.RetainOnDelete,
+		Key:                  types.StringValue(state.Key.ValueString()),
+		Repositories:         listValue,
+		Repository:           refreshed.Blocks,
+		ResolvedRepositories: resolvedRepositories,
 	})
 	if util.TestDiagnostic(&response.Diagnostics, diags) {
 		return
@@ -172,10 +190,8 @@ func (receiver *ProjectRepositoriesResource) Read(ctx context.Context, request r
 
 func (receiver *ProjectRepositoriesResource) Update(ctx context.Context, request resource.UpdateRequest, response *resource.UpdateResponse) {
 	var (
-		plan, state           ProjectRepositoriesModel
-		incomingRepositoryIDs = make([]string, 0)
-		existingRepositoryIDs = make([]string, 0)
-		diags                 diag.Diagnostics
+		plan, state ProjectRepositoriesModel
+		diags       diag.Diagnostics
 	)
 
 	diags = request.Plan.Get(ctx, &plan)
@@ -187,45 +203,56 @@ func (receiver *ProjectRepositoriesResource) Update(ctx context.Context, request
 	if util.TestDiagnostic(&response.Diagnostics, diags) {
 		return
 	}
-	diags = plan.Repositories.ElementsAs(ctx, &incomingRepositoryIDs, true)
+
+	plannedReferences, diags := NewRepositoryReferences(ctx, plan.Repositories, plan.Repository)
 	if util.TestDiagnostic(&response.Diagnostics, diags) {
 		return
 	}
 
-	diags = state.Repositories.ElementsAs(ctx, &existingRepositoryIDs, true)
+	inStateReferences, diags := NewRepositoryReferences(ctx, state.Repositories, state.Repository)
 	if util.TestDiagnostic(&response.Diagnostics, diags) {
 		return
 	}
 
-	adding, removing := collections.Delta(existingRepositoryIDs, incomingRepositoryIDs)
-	for _, repository := range adding {
-		repositoryId, err := strconv.Atoi(repository)
-		if util.TestError(&response.Diagnostics, err, errorProvidedRepositoryMustBeNumber) {
-			return
-		}
+	resolver := NewRepositoryResolver(receiver.client, receiver.extendedClient)
+	resolved, diags := resolver.ResolveAll(plannedReferences)
+	if util.TestDiagnostic(&response.Diagnostics, diags) {
+		return
+	}
 
-		_, err = receiver.client.ProjectService().AddSpecRepositories(plan.Key.ValueString(), repositoryId)
+	existingRepositoryIDs, diags := resolver.Owned(ctx, state.ResolvedRepositories, inStateReferences)
+	if util.TestDiagnostic(&response.Diagnostics, diags) {
+		return
+	}
+
+	adding, removing := collections.Delta(existingRepositoryIDs, resolvedIds(resolved))
+	for _, repository := range adding {
+		repositoryId, _ := strconv.Atoi(repository)
+		_, err := receiver.client.ProjectService().AddSpecRepositories(plan.Key.ValueString(), repositoryId)
 		if util.TestError(&response.Diagnostics, err, errorFailedToAddProjectRepositories) {
 			return
 		}
 	}
 
 	for _, repository := range removing {
-		repositoryId, err := strconv.Atoi(repository)
-		if util.TestError(&response.Diagnostics, err, errorProvidedRepositoryMustBeNumber) {
-			return
-		}
-
-		err = receiver.client.ProjectService().RemoveSpecRepositories(plan.Key.ValueString(), repositoryId)
+		repositoryId, _ := strconv.Atoi(repository)
+		err := receiver.client.ProjectService().RemoveSpecRepositories(plan.Key.ValueString(), repositoryId)
 		if util.TestError(&response.Diagnostics, err, errorFailedToRemoveProjectRepositories) {
 			return
 		}
 	}
 
+	resolvedRepositories, diags := resolvedList(ctx, resolved)
+	if util.TestDiagnostic(&response.Diagnostics, diags) {
+		return
+	}
+
 	diags = response.State.Set(ctx, &ProjectRepositoriesModel{
-		RetainOnDelete: plan.RetainOnDelete,
-		Key:            types.StringValue(plan.Key.ValueString()),
-		Repositories:   plan.Repositories,
+		RetainOnDelete:       plan.RetainOnDelete,
+		Key:                  types.StringValue(plan.Key.ValueString()),
+		Repositories:         plan.Repositories,
+		Repository:           plannedReferences.Blocks,
+		ResolvedRepositories: resolvedRepositories,
 	})
 	if util.TestDiagnostic(&response.Diagnostics, diags) {
 		return
@@ -234,9 +261,8 @@ func (receiver *ProjectRepositoriesResource) Update(ctx context.Context, request
 
 func (receiver *ProjectRepositoriesResource) Delete(ctx context.Context, request resource.DeleteRequest, response *resource.DeleteResponse) {
 	var (
-		state                 ProjectRepositoriesModel
-		existingRepositoryIDs = make([]string, 0)
-		diags                 diag.Diagnostics
+		state ProjectRepositoriesModel
+		diags diag.Diagnostics
 	)
 
 	diags = request.State.Get(ctx, &state)
@@ -245,18 +271,19 @@ func (receiver *ProjectRepositoriesResource) Delete(ctx context.Context, request
 	}
 
 	if !state.RetainOnDelete.ValueBool() {
-		diags = state.Repositories.ElementsAs(ctx, &existingRepositoryIDs, true)
+		references, diags := NewRepositoryReferences(ctx, state.Repositories, state.Repository)
 		if util.TestDiagnostic(&response.Diagnostics, diags) {
 			return
 		}
 
-		for _, repository := range existingRepositoryIDs {
-			repositoryId, err := strconv.Atoi(repository)
-			if util.TestError(&response.Diagnostics, err, errorProvidedRepositoryMustBeNumber) {
-				return
-			}
+		existingRepositoryIDs, diags := NewRepositoryResolver(receiver.client, receiver.extendedClient).Owned(ctx, state.ResolvedRepositories, references)
+		if util.TestDiagnostic(&response.Diagnostics, diags) {
+			return
+		}
 
-			err = receiver.client.ProjectService().RemoveSpecRepositories(state.Key.ValueString(), repositoryId)
+		for _, repository := range existingRepositoryIDs {
+			repositoryId, _ := strconv.Atoi(repository)
+			err := receiver.client.ProjectService().RemoveSpecRepositories(state.Key.ValueString(), repositoryId)
 			if util.TestError(&response.Diagnostics, err, errorFailedToRemoveProjectRepositories) {
 				return
 			}
@@ -267,5 +294,22 @@ func (receiver *ProjectRepositoriesResource) Delete(ctx context.Context, request
 }
 
 func (receiver *ProjectRepositoriesResource) ImportState(ctx context.Context, request resource.ImportStateRequest, response *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("key"), request, response)
+	granted, err := receiver.client.ProjectService().GetSpecRepositories(request.ID)
+	if util.TestError(&response.Diagnostics, err, errorFailedToReadProjectRepositories) {
+		return
+	}
+
+	repositories, resolvedRepositories, diags := importedReferences(ctx, granted)
+	if util.TestDiagnostic(&response.Diagnostics, diags) {
+		return
+	}
+
+	diags = response.State.Set(ctx, &ProjectRepositoriesModel{
+		RetainOnDelete:       types.BoolValue(true),
+		Key:                  types.StringValue(request.ID),
+		Repositories:         repositories,
+		Repository:           make([]RepositoryReferenceModel, 0),
+		ResolvedRepositories: resolvedRepositories,
+	})
+	util.TestDiagnostic(&response.Diagnostics, diags)
 }
@@ -0,0 +1,20 @@
+package provider
+
+import (
+	"testing"
+)
+
+func TestProjectRepositoriesResource_ImportState(t *testing.T) {
+	virtualization := newAccessVirtualization()
+	virtualization.AddProject("PROJ", "Project")
+	virtualization.GrantAccess("project/PROJ", 2)
+
+	harness := newResourceHarness(t, NewProjectRepositoriesResource(), virtualization, testBambooRss(false))
+	state, diags := harness.importState("PROJ")
+	harness.require(diags)
+
+	requireImportedReferences(t, harness, state,
+		[]RepositoryReferenceData{{Id: "2", Name: "lib"}},
+		map[string]any{"key": "PROJ", "repositories": []string{"2"}},
+	)
+}
@@ -19,6 +19,7 @@ var (
 	_ resource.ResourceWithImportState = &ProjectVariableResource{}
 	_ ProjectPermissionsReceiver       = &ProjectVariableResource{}
 	_ ConfigurableReceiver             = &ProjectVariableResource{}
+	_ ExtendedConfigurableReceiver     = &ProjectVariableResource{}
 )
 
 func NewProjectVariableResource() resource.Resource {
@@ -26,8 +27,9 @@ func NewProjectVariableResource() resource.Resource {
 }
 
 type ProjectVariableResource struct {
-	config BambooProviderConfig
-	client *bamboo.Client
+	config         BambooProviderConfig
+	client         *bamboo.Client
+	extendedClient *ExtendedClient
 }
 
 func (receiver *ProjectVariableResource) setConfig(config BambooProviderConfig, client *bamboo.Client) {
@@ -35,10 +37,18 @@ func (receiver *ProjectVariableResource) setConfig(config BambooProviderConfig,
 	receiver.client = client
 }
 
+func (receiver *ProjectVariableResource) setExtendedClient(extendedClient *ExtendedClient) {
+	receiver.extendedClient = extendedClient
+}
+
 func (receiver *ProjectVariableResource) getClient() *bamboo.Client {
 	return receiver.client
 }
 
+func (receiver *ProjectVariableResource) getExtendedClient() *ExtendedClient {
+	return receiver.extendedClient
+}
+
 func (receiver *ProjectVariableResource) Metadata(ctx context.Context, request resource.MetadataRequest, response *resource.MetadataResponse) {
 	response.TypeName = request.ProviderTypeName + "_project_variable"
 }
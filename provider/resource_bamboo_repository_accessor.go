@@ -3,27 +3,24 @@ package provider
 import (
 	"context"
 	"fmt"
-	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
-	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
-	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
-	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/yunarta/golang-quality-of-life-pack/collections"
 	"github.com/yunarta/terraform-atlassian-api-client/bamboo"
 	"github.com/yunarta/terraform-provider-commons/util"
-	"regexp"
-	"sort"
 	"strconv"
 )
 
 type LinkedRepositoryAccessorModel struct {
-	RetainOnDelete types.Bool   `tfsdk:"retain_on_delete"`
-	ID             types.String `tfsdk:"id"`
-	Repositories   types.List   `tfsdk:"repositories"`
+	RetainOnDelete       types.Bool                 `tfsdk:"retain_on_delete"`
+	ID                   types.String               `tfsdk:"id"`
+	Mode                 types.String               `tfsdk:"mode"`
+	Repositories         types.List                 `tfsdk:"repositories"`
+	Repository           []RepositoryReferenceModel `tfsdk:"repository"`
+	ResolvedRepositories types.List                 `tfsdk:"resolved_repositories"`
 }
 
 var (
@@ -31,6 +28,7 @@ var (
 	_ resource.ResourceWithConfigure   = &LinkedRepositoryAccessorResource{}
 	_ resource.ResourceWithImportState = &LinkedRepositoryAccessorResource{}
 	_ ConfigurableReceiver             = &LinkedRepositoryAccessorResource{}
+	_ ExtendedConfigurableReceiver     = &LinkedRepositoryAccessorResource{}
 )
 
 func NewLinkedRepositoryAccessorResource() resource.Resource {
@@ -38,8 +36,9 @@ func NewLinkedRepositoryAccessorResource() resource.Resource {
 }
 
 type LinkedRepositoryAccessorResource struct {
-	config BambooProviderConfig
-	client *bamboo.Client
+	config         BambooProviderConfig
+	client         *bamboo.Client
+	extendedClient *ExtendedClient
 }
 
 func (receiver *LinkedRepositoryAccessorResource) setConfig(config BambooProviderConfig, client *bamboo.Client) {
@@ -47,6 +46,10 @@ func (receiver *LinkedRepositoryAccessorResource) setConfig(config BambooProvide
 	receiver.client = client
 }
 
+func (receiver *LinkedRepositoryAccessorResource) setExtendedClient(extendedClient *ExtendedClient) {
+	receiver.extendedClient = extendedClient
+}
+
 func (receiver *LinkedRepositoryAccessorResource) Metadata(ctx context.Context, request resource.MetadataRequest, response *resource.MetadataResponse) {
 	response.TypeName = request.ProviderTypeName + "_linked_repository_accessor"
 }
@@ -55,7 +58,12 @@ func (receiver *LinkedRepositoryAccessorResource) Schema(ctx context.Context, re
 	response.Schema = schema.Schema{
 		MarkdownDescription: `This resource define relationship that allow other repositories to use this repository.
 
-In order for the execution to be successful, the user must have user access to all the specified repositories.`,
+In order for the execution to be successful, the user must have user access to all the specified repositories.
+
+With ` + "`mode = \"additive\"`" + `, the default, the accessors granted outside of this resource are left untouched, while ` + "`mode = \"authoritative\"`" + ` makes the accessors exactly match ` + "`repositories`" + `.
+
+Import with the numeric id of the linked repository, the current accessors are listed by id in ` + "`repositories`" + `.
+The imported ` + "`mode`" + ` is additive, append ` + "`:authoritative`" + ` to the id to import in authoritative mode, for example ` + "`42:authoritative`" + `.`,
 		Attributes: map[string]schema.Attribute{
 			"retain_on_delete": schema.BoolAttribute{
 				Optional:            true,
@@ -67,17 +75,16 @@ In order for the execution to be successful, the user must have user access to a
 				Required:            true,
 				MarkdownDescription: "Numeric id of the linked repository.",
 			},
+			"mode": AccessModeSchema,
 			"repositories": schema.ListAttribute{
-				Required:    true,
-				ElementType: types.StringType,
-				Validators: []validator.List{
-					listvalidator.ValueStringsAre(stringvalidator.RegexMatches(
-						regexp.MustCompile(`^\d+$`),
-						"value must be a numeric",
-					)),
-				},
-				MarkdownDescription: "This repository will add this list of linked repositories into its permission.",
+				Optional:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "This repository will add this list of linked repositories, by numeric id or name, into its permission.",
 			},
+			"resolved_repositories": ResolvedRepositoriesSchema("repositories", "repository"),
+		},
+		Blocks: map[string]schema.Block{
+			"repository": RepositoryReferenceBlock("Linked repository to add into the permission, referenced by `id`, `name`, or `project_key` and `name`."),
 		},
 	}
 }
@@ -106,46 +113,44 @@ func (receiver *LinkedRepositoryAccessorResource) Create(ctx context.Context, re
 		return
 	}
 
-	var repositories []bamboo.Repository
-	var existingRepositories = make([]string, 0)
-	var incomingRepositories = make([]string, 0)
-
-	repositories, err = receiver.client.RepositoryService().ReadAccessor(repositoryId)
-	if err != nil {
-		response.Diagnostics.AddError(errorFailedToReadDeployment, err.Error())
+	references, diags := NewRepositoryReferences(ctx, plan.Repositories, plan.Repository)
+	if util.TestDiagnostic(&response.Diagnostics, diags) {
 		return
 	}
 
-	for _, repository := range repositories {
-		existingRepositories = append(existingRepositories, strconv.Itoa(repository.ID))
+	resolved, diags := NewRepositoryResolver(receiver.client, receiver.extendedClient).ResolveAll(references)
+	if util.TestDiagnostic(&response.Diagnostics, diags) {
+		return
 	}
 
-	diags = plan.Repositories.ElementsAs(ctx, &incomingRepositories, true)
-	response.Diagnostics.Append(diags...)
-	if response.Diagnostics.HasError() {
+	incomingRepositories := resolvedIds(resolved)
+	if collections.Contains(incomingRepositories, plan.ID.ValueString()) {
+		response.Diagnostics.AddError("Cannot add self as accessor", fmt.Sprintf("Repository %s", plan.ID.ValueString()))
 		return
 	}
 
-	adding, _ := collections.Delta(existingRepositories, incomingRepositories)
+	existingRepositories, err := receiver.readAccessors(repositoryId)
+	if util.TestError(&response.Diagnostics, err, errorFailedToReadRepositoryAccessor) {
+		return
+	}
 
-	if collections.Contains(adding, plan.ID.ValueString()) {
-		response.Diagnostics.AddError("Cannot add self as accessor", fmt.Sprintf("Repository %s", plan.ID.ValueString()))
+	adding, removing := accessDelta(plan.Mode, existingRepositories, nil, incomingRepositories)
+	if util.TestError(&response.Diagnostics, receiver.updateAccessors(repositoryId, adding, removing), errorFailedToUpdateRepositoryAccessor) {
 		return
 	}
 
-	for _, repository := range adding {
-		accessorId, _ := strconv.Atoi(repository)
-		_, err = receiver.client.RepositoryService().AddAccessor(repositoryId, accessorId)
-		if err != nil {
-			response.Diagnostics.AddError(errorFailedToAddRepositoryAccessor, err.Error())
-			return
-		}
+	resolvedRepositories, diags := resolvedList(ctx, resolved)
+	if util.TestDiagnostic(&response.Diagnostics, diags) {
+		return
 	}
 
 	diags = response.State.Set(ctx, &LinkedRepositoryAccessorModel{
-		RetainOnDelete: plan.RetainOnDelete,
-		ID:             types.StringValue(strconv.Itoa(repositoryId)),
-		Repositories:   plan.Repositories,
+		RetainOnDelete:       plan.RetainOnDelete,
+		ID:                   types.StringValue(strconv.Itoa(repositoryId)),
+		Mode:                 plan.Mode,
+		Repositories:         plan.Repositories,
+		Repository:           references.Blocks,
+		ResolvedRepositories: resolvedRepositories,
 	})
 
 	response.Diagnostics.Append(diags...)
@@ -156,14 +161,13 @@ func (receiver *LinkedRepositoryAccessorResource) Create(ctx context.Context, re
 
 func (receiver *LinkedRepositoryAccessorResource) Read(ctx context.Context, request resource.ReadRequest, response *resource.ReadResponse) {
 	var state LinkedRepositoryAccessorModel
-	var existingRepositories = make([]string, 0)
 
 	diags := request.State.Get(ctx, &state)
 	if util.TestDiagnostic(&response.Diagnostics, diags) {
 		return
 	}
 
-	diags = state.Repositories.ElementsAs(ctx, &existingRepositories, true)
+	references, diags := NewRepositoryReferences(ctx, state.Repositories, state.Repository)
 	if util.TestDiagnostic(&response.Diagnostics, diags) {
 		return
 	}
@@ -180,24 +184,30 @@ func (receiver *LinkedRepositoryAccessorResource) Read(ctx context.Context, requ
 		return
 	}
 
-	var repositoryIds []string
-	for _, repository := range repositories {
-		accessorId := fmt.Sprintf("%v", repository.ID)
-		if collections.Contains(existingRepositories, accessorId) {
-			repositoryIds = append(repositoryIds, accessorId)
-		}
+	mode := accessMode(state.Mode)
+
+	refreshed, resolved, diags := NewRepositoryResolver(receiver.client, receiver.extendedClient).Refresh(references, repositories, mode.ValueString() == accessModeAuthoritative)
+	if util.TestDiagnostic(&response.Diagnostics, diags) {
+		return
+	}
+
+	from, diags := refreshed.List(ctx)
+	if util.TestDiagnostic(&response.Diagnostics, diags) {
+		return
 	}
 
-	sort.Strings(repositoryIds)
-	from, diags := types.ListValueFrom(ctx, types.StringType, repositoryIds)
+	resolvedRepositories, diags := resolvedList(ctx, resolved)
 	if util.TestDiagnostic(&response.Diagnostics, diags) {
 		return
 	}
 
 	diags = response.State.Set(ctx, &LinkedRepositoryAccessorModel{
-		RetainOnDelete: state.RetainOnDelete,
-		ID:             types.StringValue(fmt.Sprintf("%v", repositoryId)),
-		Repositories:   from,
+		RetainOnDelete:       state.RetainOnDelete,
+		ID:                   types.StringValue(fmt.Sprintf("%v", repositoryId)),
+		Mode:                 mode,
+		Repositories:         from,
+		Repository:           refreshed.Blocks,
+		ResolvedRepositories: resolvedRepositories,
 	})
 	if util.TestDiagnostic(&response.Diagnostics, diags) {
 		return
@@ -210,14 +220,9 @@ func (receiver *LinkedRepositoryAccessorResource) Update(ctx context.Context, re
 	var diags diag.Diagnostics
 	var err error
 
-	var inStateRepositories = make([]string, 0)
-	var plannedRepository = make([]string, 0)
-
 	if util.TestDiagnostics(&response.Diagnostics,
 		request.Plan.Get(ctx, &plan),
 		request.State.Get(ctx, &state),
-		plan.Repositories.ElementsAs(ctx, &plannedRepository, true),
-		state.Repositories.ElementsAs(ctx, &inStateRepositories, true),
 	) {
 		return
 	}
@@ -230,49 +235,55 @@ func (receiver *LinkedRepositoryAccessorResource) Update(ctx context.Context, re
 		return
 	}
 
-	var repositories []bamboo.Repository
-	var existingRepositories = make([]string, 0)
+	plannedReferences, diags := NewRepositoryReferences(ctx, plan.Repositories, plan.Repository)
+	if util.TestDiagnostic(&response.Diagnostics, diags) {
+		return
+	}
 
-	repositories, err = receiver.client.RepositoryService().ReadAccessor(repositoryId)
-	if err != nil {
-		response.Diagnostics.AddError(errorFailedToReadRepositoryAccessor, err.Error())
+	inStateReferences, diags := NewRepositoryReferences(ctx, state.Repositories, state.Repository)
+	if util.TestDiagnostic(&response.Diagnostics, diags) {
 		return
 	}
 
-	for _, repository := range repositories {
-		existingRepositories = append(existingRepositories, strconv.Itoa(repository.ID))
+	resolver := NewRepositoryResolver(receiver.client, receiver.extendedClient)
+	resolved, diags := resolver.ResolveAll(plannedReferences)
+	if util.TestDiagnostic(&response.Diagnostics, diags) {
+		return
+	}
+
+	inStateRepositories, diags := resolver.Owned(ctx, state.ResolvedRepositories, inStateReferences)
+	if util.TestDiagnostic(&response.Diagnostics, diags) {
+		return
 	}
 
-	adding, removing := collections.Delta(inStateRepositories, plannedRepository)
-	if collections.Contains(adding, plan.ID.ValueString()) {
+	plannedRepository := resolvedIds(resolved)
+	if collections.Contains(plannedRepository, plan.ID.ValueString()) {
 		response.Diagnostics.AddError("Cannot add self as accessor", fmt.Sprintf("Repository %s", plan.ID.ValueString()))
 		return
 	}
 
-	for _, repository := range adding {
-		if !collections.Contains(existingRepositories, repository) {
-			accessorId, _ := strconv.Atoi(repository)
-			_, err = receiver.client.RepositoryService().AddAccessor(repositoryId, accessorId)
-			if err != nil {
-				response.Diagnostics.AddError(errorFailedToAddRepositoryAccessor, err.Error())
-				return
-			}
-		}
+	existingRepositories, err := receiver.readAccessors(repositoryId)
+	if util.TestError(&response.Diagnostics, err, errorFailedToReadRepositoryAccessor) {
+		return
 	}
 
-	for _, repository := range removing {
-		accessorId, _ := strconv.Atoi(repository)
-		err = receiver.client.RepositoryService().RemoveAccessor(repositoryId, accessorId)
-		if err != nil {
-			response.Diagnostics.AddError(errorFailedToRemoveRepositoryAccessor, err.Error())
-			return
-		}
+	adding, removing := accessDelta(plan.Mode, existingRepositories, inStateRepositories, plannedRepository)
+	if util.TestError(&response.Diagnostics, receiver.updateAccessors(repositoryId, adding, removing), errorFailedToUpdateRepositoryAccessor) {
+		return
+	}
+
+	resolvedRepositories, diags := resolvedList(ctx, resolved)
+	if util.TestDiagnostic(&response.Diagnostics, diags) {
+		return
 	}
 
 	diags = response.State.Set(ctx, &LinkedRepositoryAccessorModel{
-		RetainOnDelete: plan.RetainOnDelete,
-		ID:             types.StringValue(strconv.Itoa(repositoryId)),
-		Repositories:   plan.Repositories,
+		RetainOnDelete:       plan.RetainOnDelete,
+		ID:                   types.StringValue(strconv.Itoa(repositoryId)),
+		Mode:                 plan.Mode,
+		Repositories:         plan.Repositories,
+		Repository:           plannedReferences.Blocks,
+		ResolvedRepositories: resolvedRepositories,
 	})
 
 	if util.TestDiagnostic(&response.Diagnostics, diags) {
@@ -285,11 +296,9 @@ func (receiver *LinkedRepositoryAccessorResource) Delete(ctx context.Context, re
 
 	var err error
 	var repositoryId int
-	var inStateRepositories = make([]string, 0)
 
-	if util.TestDiagnostics(&response.Diagnostics,
-		request.State.Get(ctx, &state),
-		state.Repositories.ElementsAs(ctx, &inStateRepositories, true)) {
+	diags := request.State.Get(ctx, &state)
+	if util.TestDiagnostic(&response.Diagnostics, diags) {
 		return
 	}
 
@@ -300,6 +309,16 @@ func (receiver *LinkedRepositoryAccessorResource) Delete(ctx context.Context, re
 			return
 		}
 
+		references, diags := NewRepositoryReferences(ctx, state.Repositories, state.Repository)
+		if util.TestDiagnostic(&response.Diagnostics, diags) {
+			return
+		}
+
+		inStateRepositories, diags := NewRepositoryResolver(receiver.client, receiver.extendedClient).Owned(ctx, state.ResolvedRepositories, references)
+		if util.TestDiagnostic(&response.Diagnostics, diags) {
+			return
+		}
+
 		var existingRepositories []bamboo.Repository
 		existingRepositories, err = receiver.client.RepositoryService().ReadAccessor(repositoryId)
 		if err != nil {
@@ -319,7 +338,64 @@ func (receiver *LinkedRepositoryAccessorResource) Delete(ctx context.Context, re
 }
 
 func (receiver *LinkedRepositoryAccessorResource) ImportState(ctx context.Context, request resource.ImportStateRequest, response *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), request, response)
+	repositoryId, mode, diags := parseAccessImportId(request.ID)
+	if util.TestDiagnostic(&response.Diagnostics, diags) {
+		return
+	}
+
+	accessors, err := receiver.client.RepositoryService().ReadAccessor(repositoryId)
+	if util.TestError(&response.Diagnostics, err, errorFailedToReadRepositoryAccessor) {
+		return
+	}
+
+	repositories, resolvedRepositories, diags := importedReferences(ctx, accessors)
+	if util.TestDiagnostic(&response.Diagnostics, diags) {
+		return
+	}
+
+	diags = response.State.Set(ctx, &LinkedRepositoryAccessorModel{
+		RetainOnDelete:       types.BoolValue(true),
+		ID:                   types.StringValue(strconv.Itoa(repositoryId)),
+		Mode:                 mode,
+		Repositories:         repositories,
+		Repository:           make([]RepositoryReferenceModel, 0),
+		ResolvedRepositories: resolvedRepositories,
+	})
+	util.TestDiagnostic(&response.Diagnostics, diags)
+}
+
+func (receiver *LinkedRepositoryAccessorResource) readAccessors(repositoryId int) ([]string, error) {
+	repositories, err := receiver.client.RepositoryService().ReadAccessor(repositoryId)
+	if err != nil {
+		return nil, err
+	}
+
+	var existingRepositories = make([]string, 0)
+	for _, repository := range repositories {
+		existingRepositories = append(existingRepositories, strconv.Itoa(repository.ID))
+	}
+
+	return existingRepositories, nil
+}
+
+func (receiver *LinkedRepositoryAccessorResource) updateAccessors(repositoryId int, adding []string, removing []string) error {
+	for _, repository := range adding {
+		accessorId, _ := strconv.Atoi(repository)
+		_, err := receiver.client.RepositoryService().AddAccessor(repositoryId, accessorId)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, repository := range removing {
+		accessorId, _ := strconv.Atoi(repository)
+		err := receiver.client.RepositoryService().RemoveAccessor(repositoryId, accessorId)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
 }
 
 // New function to remove Accessors from Repositories
@@ -0,0 +1,135 @@
+package provider
+
+import (
+	"context"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/yunarta/terraform-provider-bamboo/provider/test"
+)
+
+func newAccessVirtualization() *test.ServiceVirtualization {
+	virtualization := test.NewServiceVirtualization()
+	for id, name := range map[int]string{1: "app", 2: "lib", 3: "specs", 4: "other"} {
+		virtualization.AddRepository(test.Repository{ID: id, Name: name})
+	}
+
+	return virtualization
+}
+
+func sortedAccess(virtualization *test.ServiceVirtualization, entity string) []int {
+	access := append([]int{}, virtualization.Access(entity)...)
+	sort.Ints(access)
+	return access
+}
+
+func listAttribute(t *testing.T, state tfsdk.State, name string) []string {
+	t.Helper()
+
+	var values []string
+	if diags := state.GetAttribute(context.Background(), path.Root(name), &values); diags.HasError() {
+		t.Fatalf("GetAttribute() diagnostics = %v", diags)
+	}
+
+	return values
+}
+
+func TestLinkedRepositoryAccessorResource_Mode(t *testing.T) {
+	tests := []struct {
+		name        string
+		mode        string
+		afterCreate []int
+		afterUpdate []int
+		readAfter   []string
+	}{
+		{
+			name:        "additive keeps accessors granted outside",
+			mode:        accessModeAdditive,
+			afterCreate: []int{2, 3, 4},
+			afterUpdate: []int{3, 4},
+			readAfter:   []string{"3"},
+		},
+		{
+			name:        "authoritative removes and reports accessors granted outside",
+			mode:        accessModeAuthoritative,
+			afterCreate: []int{2, 3},
+			afterUpdate: []int{3},
+			readAfter:   []string{"3", "4"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			virtualization := newAccessVirtualization()
+			virtualization.GrantAccess("repository/1", 4)
+
+			harness := newResourceHarness(t, NewLinkedRepositoryAccessorResource(), virtualization, testBambooRss(false))
+			state, diags := harness.create(harness.plan(map[string]any{
+				"id":               "1",
+				"mode":             tt.mode,
+				"retain_on_delete": true,
+				"repositories":     []string{"2", "3"},
+			}))
+			harness.require(diags)
+
+			if got := sortedAccess(virtualization, "repository/1"); !reflect.DeepEqual(got, tt.afterCreate) {
+				t.Errorf("Create() access = %v, want %v", got, tt.afterCreate)
+			}
+
+			state, diags = harness.update(harness.planFrom(state, map[string]any{"repositories": []string{"3"}}), state)
+			harness.require(diags)
+
+			if got := sortedAccess(virtualization, "repository/1"); !reflect.DeepEqual(got, tt.afterUpdate) {
+				t.Errorf("Update() access = %v, want %v", got, tt.afterUpdate)
+			}
+
+			virtualization.GrantAccess("repository/1", 4)
+			state, diags = harness.read(state)
+			harness.require(diags)
+
+			if got := listAttribute(t, state, "repositories"); !reflect.DeepEqual(got, tt.readAfter) {
+				t.Errorf("Read() repositories = %v, want %v", got, tt.readAfter)
+			}
+		})
+	}
+}
+
+func TestLinkedRepositoryAccessorResource_ImportState(t *testing.T) {
+	tests := []struct {
+		name     string
+		id       string
+		wantMode string
+		wantErr  string
+	}{
+		{name: "additive", id: "1", wantMode: accessModeAdditive},
+		{name: "authoritative", id: "1:authoritative", wantMode: accessModeAuthoritative},
+		{name: "name", id: "app", wantErr: errorProvidedRepositoryMustBeNumber},
+		{name: "unknown mode", id: "1:exclusive", wantErr: "Invalid import id"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			virtualization := newAccessVirtualization()
+			virtualization.GrantAccess("repository/1", 3)
+			virtualization.GrantAccess("repository/1", 2)
+
+			harness := newResourceHarness(t, NewLinkedRepositoryAccessorResource(), virtualization, testBambooRss(false))
+			state, diags := harness.importState(tt.id)
+			if tt.wantErr != "" {
+				if !diags.HasError() || diags.Errors()[0].Summary() != tt.wantErr {
+					t.Fatalf("ImportState() diagnostics = %v, want %q", diags, tt.wantErr)
+				}
+				return
+			}
+
+			harness.require(diags)
+			requireImportedReferences(t, harness, state,
+				[]RepositoryReferenceData{{Id: "2", Name: "lib"}, {Id: "3", Name: "specs"}},
+				map[string]any{"id": "1", "mode": tt.wantMode, "repositories": []string{"2", "3"}},
+			)
+		})
+	}
+}
@@ -3,32 +3,32 @@ package provider
 import (
 	"context"
 	"fmt"
-	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
-	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
-	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/yunarta/golang-quality-of-life-pack/collections"
 	"github.com/yunarta/terraform-atlassian-api-client/bamboo"
 	"github.com/yunarta/terraform-provider-commons/util"
-	"regexp"
-	"sort"
 	"strconv"
 )
 
 type LinkedRepositoryDependencyModel struct {
-	RetainOnDelete types.Bool   `tfsdk:"retain_on_delete"`
-	ID             types.String `tfsdk:"id"`
-	Repositories   types.List   `tfsdk:"requires"`
+	RetainOnDelete       types.Bool                 `tfsdk:"retain_on_delete"`
+	ID                   types.String               `tfsdk:"id"`
+	Mode                 types.String               `tfsdk:"mode"`
+	Repositories         types.List                 `tfsdk:"requires"`
+	Repository           []RepositoryReferenceModel `tfsdk:"require"`
+	ResolvedRepositories types.List                 `tfsdk:"resolved_repositories"`
 }
 
 var (
-	_ resource.Resource              = &LinkedRepositoryDependencyResource{}
-	_ resource.ResourceWithConfigure = &LinkedRepositoryDependencyResource{}
-	_ ConfigurableReceiver           = &LinkedRepositoryDependencyResource{}
+	_ resource.Resource                = &LinkedRepositoryDependencyResource{}
+	_ resource.ResourceWithConfigure   = &LinkedRepositoryDependencyResource{}
+	_ resource.ResourceWithImportState = &LinkedRepositoryDependencyResource{}
+	_ ConfigurableReceiver             = &LinkedRepositoryDependencyResource{}
+	_ ExtendedConfigurableReceiver     = &LinkedRepositoryDependencyResource{}
 )
 
 func NewLinkedRepositoryDependencyResource() resource.Resource {
@@ -36,8 +36,9 @@ func NewLinkedRepositoryDependencyResource() resource.Resource {
 }
 
 type LinkedRepositoryDependencyResource struct {
-	config BambooProviderConfig
-	client *bamboo.Client
+	config         BambooProviderConfig
+	client         *bamboo.Client
+	extendedClient *ExtendedClient
 }
 
 func (receiver *LinkedRepositoryDependencyResource) setConfig(config BambooProviderConfig, client *bamboo.Client) {
@@ -45,6 +46,10 @@ func (receiver *LinkedRepositoryDependencyResource) setConfig(config BambooProvi
 	receiver.client = client
 }
 
+func (receiver *LinkedRepositoryDependencyResource) setExtendedClient(extendedClient *ExtendedClient) {
+	receiver.extendedClient = extendedClient
+}
+
 func (receiver *LinkedRepositoryDependencyResource) Metadata(ctx context.Context, request resource.MetadataRequest, response *resource.MetadataResponse) {
 	response.TypeName = request.ProviderTypeName + "_linked_repository_dependency"
 }
@@ -53,7 +58,13 @@ func (receiver *LinkedRepositoryDependencyResource) Schema(ctx context.Context,
 	response.Schema = schema.Schema{
 		MarkdownDescription: `This resource define relationship where repository specified by id will requires access to list of specified required repositories.
 
-In order for the execution to be successful, the user must have admin access to all the required repositories. 
+In order for the execution to be successful, the user must have admin access to all the required repositories.
+
+With ` + "`mode = \"additive\"`" + `, the default, the access granted outside of this resource is left untouched, while ` + "`mode = \"authoritative\"`" + ` makes the repositories this repository has access to exactly match ` + "`requires`" + `.
+The authoritative mode reads the accessors of every linked repository, which may take a while on large instances.
+
+Import with the numeric id of the linked repository, the repositories it currently has access to are listed by id in ` + "`requires`" + `.
+The imported ` + "`mode`" + ` is additive, append ` + "`:authoritative`" + ` to the id to import in authoritative mode, for example ` + "`42:authoritative`" + `.
 `,
 		Attributes: map[string]schema.Attribute{
 			"retain_on_delete": schema.BoolAttribute{
@@ -66,17 +77,16 @@ In order for the execution to be successful, the user must have admin access to
 				Required:            true,
 				MarkdownDescription: "Numeric id of the linked repository.",
 			},
+			"mode": AccessModeSchema,
 			"requires": schema.ListAttribute{
-				Required:    true,
-				ElementType: types.StringType,
-				Validators: []validator.List{
-					listvalidator.ValueStringsAre(stringvalidator.RegexMatches(
-						regexp.MustCompile(`^\d+$`),
-						"value must be a numeric",
-					)),
-				},
-				MarkdownDescription: "This repository will be added into to this list of linked repositories permissions.",
+				Optional:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "This repository will be added into to this list of linked repositories, by numeric id or name, permissions.",
 			},
+			"resolved_repositories": ResolvedRepositoriesSchema("requires", "require"),
+		},
+		Blocks: map[string]schema.Block{
+			"require": RepositoryReferenceBlock("Linked repository whose permissions this repository will be added into, referenced by `id`, `name`, or `project_key` and `name`."),
 		},
 	}
 }
@@ -90,15 +100,13 @@ func (receiver *LinkedRepositoryDependencyResource) Create(ctx context.Context,
 		diags diag.Diagnostics
 		err   error
 
-		plan         LinkedRepositoryDependencyModel
-		dependencies = make([]string, 0)
+		plan LinkedRepositoryDependencyModel
 
 		repositoryId int
 	)
 
 	if util.TestDiagnostics(&response.Diagnostics,
 		request.Plan.Get(ctx, &plan),
-		plan.Repositories.ElementsAs(ctx, &dependencies, true),
 	) {
 		return
 	}
@@ -108,39 +116,44 @@ func (receiver *LinkedRepositoryDependencyResource) Create(ctx context.Context,
 		return
 	}
 
+	references, diags := NewRepositoryReferences(ctx, plan.Repositories, plan.Repository)
+	if util.TestDiagnostic(&response.Diagnostics, diags) {
+		return
+	}
+
+	resolved, diags := NewRepositoryResolver(receiver.client, receiver.extendedClient).ResolveAll(references)
+	if util.TestDiagnostic(&response.Diagnostics, diags) {
+		return
+	}
+
+	dependencies := resolvedIds(resolved)
 	if collections.Contains(dependencies, plan.ID.ValueString()) {
 		response.Diagnostics.AddError("Cannot add self as accessor", fmt.Sprintf("Repository %s", plan.ID.ValueString()))
 		return
 	}
 
-	for _, repository := range dependencies {
-		var (
-			dependency   int
-			repositories []bamboo.Repository
-		)
-
-		dependency, err = strconv.Atoi(repository)
-		if util.TestError(&response.Diagnostics, err, errorProvidedRepositoryMustBeNumber) {
-			return
-		}
+	existingDependencies, diags := receiver.readDependencies(repositoryId, plan.Mode, dependencies)
+	if util.TestDiagnostic(&response.Diagnostics, diags) {
+		return
+	}
 
-		repositories, err = receiver.client.RepositoryService().ReadAccessor(dependency)
-		if util.TestError(&response.Diagnostics, err, errorFailedToReadDeployment) {
-			return
-		}
+	adding, removing := accessDelta(plan.Mode, repositoryIds(existingDependencies), nil, dependencies)
+	if util.TestError(&response.Diagnostics, receiver.updateDependencies(repositoryId, adding, removing), errorFailedToUpdateRepositoryAccessor) {
+		return
+	}
 
-		if !collections.ContainsFunc(repositories, func(e bamboo.Repository) bool { return e.ID == repositoryId }) {
-			_, err = receiver.client.RepositoryService().AddAccessor(dependency, repositoryId)
-			if util.TestError(&response.Diagnostics, err, errorFailedToAddRepositoryAccessor) {
-				return
-			}
-		}
+	resolvedRepositories, diags := resolvedList(ctx, resolved)
+	if util.TestDiagnostic(&response.Diagnostics, diags) {
+		return
 	}
 
 	diags = response.State.Set(ctx, &LinkedRepositoryDependencyModel{
-		RetainOnDelete: plan.RetainOnDelete,
-		ID:             types.StringValue(strconv.Itoa(repositoryId)),
-		Repositories:   plan.Repositories,
+		RetainOnDelete:       plan.RetainOnDelete,
+		ID:                   types.StringValue(strconv.Itoa(repositoryId)),
+		Mode:                 plan.Mode,
+		Repositories:         plan.Repositories,
+		Repository:           references.Blocks,
+		ResolvedRepositories: resolvedRepositories,
 	})
 	if util.TestDiagnostic(&response.Diagnostics, diags) {
 		return
@@ -150,12 +163,9 @@ func (receiver *LinkedRepositoryDependencyResource) Create(ctx context.Context,
 func (receiver *LinkedRepositoryDependencyResource) Read(ctx context.Context, request resource.ReadRequest, response *resource.ReadResponse) {
 	var state LinkedRepositoryDependencyModel
 	var err error
-	var dependencies = make([]string, 0)
-	var newDependencies []string
 
 	if util.TestDiagnostics(&response.Diagnostics,
 		request.State.Get(ctx, &state),
-		state.Repositories.ElementsAs(ctx, &dependencies, true),
 	) {
 		return
 	}
@@ -165,41 +175,47 @@ func (receiver *LinkedRepositoryDependencyResource) Read(ctx context.Context, re
 		return
 	}
 
-	for _, repository := range dependencies {
-		var (
-			dependency   int
-			repositories []bamboo.Repository
-		)
+	references, diags := NewRepositoryReferences(ctx, state.Repositories, state.Repository)
+	if util.TestDiagnostic(&response.Diagnostics, diags) {
+		return
+	}
 
-		dependency, err = strconv.Atoi(repository)
-		if util.TestError(&response.Diagnostics, err, errorProvidedRepositoryMustBeNumber) {
-			return
-		}
+	mode := accessMode(state.Mode)
+	resolver := NewRepositoryResolver(receiver.client, receiver.extendedClient)
 
-		repositories, err = receiver.client.RepositoryService().ReadAccessor(dependency)
-		if err != nil {
-			response.Diagnostics.AddError(errorFailedToReadDeployment, err.Error())
-			return
-		}
+	// in additive mode only the repositories still resolving from the references are checked
+	candidates, diags := resolver.Existing(references)
+	if util.TestDiagnostic(&response.Diagnostics, diags) {
+		return
+	}
 
-		for _, accessor := range repositories {
-			if repositoryId == accessor.ID {
-				newDependencies = append(newDependencies, strconv.Itoa(dependency))
-				break
-			}
-		}
+	dependencies, diags := receiver.readDependencies(repositoryId, mode, candidates)
+	if util.TestDiagnostic(&response.Diagnostics, diags) {
+		return
 	}
 
-	sort.Strings(newDependencies)
-	from, diags := types.ListValueFrom(ctx, types.StringType, newDependencies)
+	refreshed, resolved, diags := resolver.Refresh(references, dependencies, mode.ValueString() == accessModeAuthoritative)
+	if util.TestDiagnostic(&response.Diagnostics, diags) {
+		return
+	}
+
+	from, diags := refreshed.List(ctx)
+	if util.TestDiagnostic(&response.Diagnostics, diags) {
+		return
+	}
+
+	resolvedRepositories, diags := resolvedList(ctx, resolved)
 	if util.TestDiagnostic(&response.Diagnostics, diags) {
 		return
 	}
 
 	diags = response.State.Set(ctx, &LinkedRepositoryDependencyModel{
-		RetainOnDelete: state.RetainOnDelete,
-		ID:             types.StringValue(fmt.Sprintf("%v", repositoryId)),
-		Repositories:   from,
+		RetainOnDelete:       state.RetainOnDelete,
+		ID:                   types.StringValue(fmt.Sprintf("%v", repositoryId)),
+		Mode:                 mode,
+		Repositories:         from,
+		Repository:           refreshed.Blocks,
+		ResolvedRepositories: resolvedRepositories,
 	})
 	if util.TestDiagnostic(&response.Diagnostics, diags) {
 		return
@@ -213,14 +229,9 @@ func (receiver *LinkedRepositoryDependencyResource) Update(ctx context.Context,
 	var err error
 	var repositoryId int
 
-	var plannedDependencies = make([]string, 0)
-	var inStateDependencies = make([]string, 0)
-
 	if util.TestDiagnostics(&response.Diagnostics,
 		request.Plan.Get(ctx, &plan),
 		request.State.Get(ctx, &state),
-		plan.Repositories.ElementsAs(ctx, &plannedDependencies, true),
-		state.Repositories.ElementsAs(ctx, &inStateDependencies, true),
 	) {
 		return
 	}
@@ -230,33 +241,55 @@ func (receiver *LinkedRepositoryDependencyResource) Update(ctx context.Context,
 		return
 	}
 
-	adding, removing := collections.Delta(inStateDependencies, plannedDependencies)
+	plannedReferences, diags := NewRepositoryReferences(ctx, plan.Repositories, plan.Repository)
+	if util.TestDiagnostic(&response.Diagnostics, diags) {
+		return
+	}
+
+	inStateReferences, diags := NewRepositoryReferences(ctx, state.Repositories, state.Repository)
+	if util.TestDiagnostic(&response.Diagnostics, diags) {
+		return
+	}
+
+	resolver := NewRepositoryResolver(receiver.client, receiver.extendedClient)
+	resolved, diags := resolver.ResolveAll(plannedReferences)
+	if util.TestDiagnostic(&response.Diagnostics, diags) {
+		return
+	}
 
-	if collections.Contains(adding, plan.ID.ValueString()) {
+	inStateDependencies, diags := resolver.Owned(ctx, state.ResolvedRepositories, inStateReferences)
+	if util.TestDiagnostic(&response.Diagnostics, diags) {
+		return
+	}
+
+	plannedDependencies := resolvedIds(resolved)
+	if collections.Contains(plannedDependencies, plan.ID.ValueString()) {
 		response.Diagnostics.AddError("Cannot add self as accessor", fmt.Sprintf("Repository %s", plan.ID.ValueString()))
 		return
 	}
 
-	for _, repository := range adding {
-		dependency, _ := strconv.Atoi(repository)
-		_, err = receiver.client.RepositoryService().AddAccessor(dependency, repositoryId)
-		if util.TestError(&response.Diagnostics, err, errorFailedToAddRepository) {
-			return
-		}
+	existingDependencies, diags := receiver.readDependencies(repositoryId, plan.Mode, collections.Unique(append(plannedDependencies, inStateDependencies...)))
+	if util.TestDiagnostic(&response.Diagnostics, diags) {
+		return
 	}
 
-	for _, repository := range removing {
-		dependency, _ := strconv.Atoi(repository)
-		err = receiver.client.RepositoryService().RemoveAccessor(dependency, repositoryId)
-		if util.TestError(&response.Diagnostics, err, errorFailedToRemoveRepository) {
-			return
-		}
+	adding, removing := accessDelta(plan.Mode, repositoryIds(existingDependencies), inStateDependencies, plannedDependencies)
+	if util.TestError(&response.Diagnostics, receiver.updateDependencies(repositoryId, adding, removing), errorFailedToUpdateRepositoryAccessor) {
+		return
+	}
+
+	resolvedRepositories, diags := resolvedList(ctx, resolved)
+	if util.TestDiagnostic(&response.Diagnostics, diags) {
+		return
 	}
 
 	diags = response.State.Set(ctx, &LinkedRepositoryDependencyModel{
-		RetainOnDelete: plan.RetainOnDelete,
-		ID:             types.StringValue(strconv.Itoa(repositoryId)),
-		Repositories:   plan.Repositories,
+		RetainOnDelete:       plan.RetainOnDelete,
+		ID:                   types.StringValue(strconv.Itoa(repositoryId)),
+		Mode:                 plan.Mode,
+		Repositories:         plan.Repositories,
+		Repository:           plannedReferences.Blocks,
+		ResolvedRepositories: resolvedRepositories,
 	})
 	if util.TestDiagnostic(&response.Diagnostics, diags) {
 		return
@@ -265,13 +298,11 @@ func (receiver *LinkedRepositoryDependencyResource) Update(ctx context.Context,
 
 func (receiver *LinkedRepositoryDependencyResource) Delete(ctx context.Context, request resource.DeleteRequest, response *resource.DeleteResponse) {
 	var state LinkedRepositoryDependencyModel
-	var existingDependencies = make([]string, 0)
 
 	var err error
 
 	if util.TestDiagnostics(&response.Diagnostics,
 		request.State.Get(ctx, &state),
-		state.Repositories.ElementsAs(ctx, &existingDependencies, true),
 	) {
 		return
 	}
@@ -283,6 +314,16 @@ func (receiver *LinkedRepositoryDependencyResource) Delete(ctx context.Context,
 			return
 		}
 
+		references, diags := NewRepositoryReferences(ctx, state.Repositories, state.Repository)
+		if util.TestDiagnostic(&response.Diagnostics, diags) {
+			return
+		}
+
+		existingDependencies, diags := NewRepositoryResolver(receiver.client, receiver.extendedClient).Owned(ctx, state.ResolvedRepositories, references)
+		if util.TestDiagnostic(&response.Diagnostics, diags) {
+			return
+		}
+
 		for _, repository := range existingDependencies {
 			dependency, _ := strconv.Atoi(repository)
 			err = receiver.client.RepositoryService().RemoveAccessor(dependency, repositoryId)
@@ -294,3 +335,106 @@ func (receiver *LinkedRepositoryDependencyResource) Delete(ctx context.Context,
 
 	response.State.RemoveResource(ctx)
 }
+
+func (receiver *LinkedRepositoryDependencyResource) ImportState(ctx context.Context, request resource.ImportStateRequest, response *resource.ImportStateResponse) {
+	repositoryId, mode, diags := parseAccessImportId(request.ID)
+	if util.TestDiagnostic(&response.Diagnostics, diags) {
+		return
+	}
+
+	// every linked repository is checked, as there are no references yet to narrow the candidates
+	dependencies, diags := receiver.readDependencies(repositoryId, types.StringValue(accessModeAuthoritative), nil)
+	if util.TestDiagnostic(&response.Diagnostics, diags) {
+		return
+	}
+
+	repositories, resolvedRepositories, diags := importedReferences(ctx, dependencies)
+	if util.TestDiagnostic(&response.Diagnostics, diags) {
+		return
+	}
+
+	diags = response.State.Set(ctx, &LinkedRepositoryDependencyModel{
+		RetainOnDelete:       types.BoolValue(true),
+		ID:                   types.StringValue(strconv.Itoa(repositoryId)),
+		Mode:                 mode,
+		Repositories:         repositories,
+		Repository:           make([]RepositoryReferenceModel, 0),
+		ResolvedRepositories: resolvedRepositories,
+	})
+	util.TestDiagnostic(&response.Diagnostics, diags)
+}
+
+// readDependencies returns the repositories granting the repository access. In authoritative mode every linked repository
+// is checked, otherwise only the candidates are.
+func (receiver *LinkedRepositoryDependencyResource) readDependencies(repositoryId int, mode types.String, candidates []string) ([]bamboo.Repository, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	var repositories = make([]bamboo.Repository, 0)
+	if accessMode(mode).ValueString() == accessModeAuthoritative {
+		all, err := receiver.extendedClient.RepositoryService().Search("")
+		if err != nil {
+			diags.AddError(errorFailedToReadRepository, err.Error())
+			return nil, diags
+		}
+
+		for _, repository := range all {
+			if repository.ID != repositoryId {
+				repositories = append(repositories, repository)
+			}
+		}
+	} else {
+		for _, candidate := range candidates {
+			dependency, err := strconv.Atoi(candidate)
+			if err != nil {
+				diags.AddError(errorProvidedRepositoryMustBeNumber, err.Error())
+				return nil, diags
+			}
+
+			repositories = append(repositories, bamboo.Repository{ID: dependency})
+		}
+	}
+
+	var dependencies = make([]bamboo.Repository, 0)
+	for _, repository := range repositories {
+		accessors, err := receiver.client.RepositoryService().ReadAccessor(repository.ID)
+		if err != nil {
+			diags.AddError(errorFailedToReadRepositoryAccessor, err.Error())
+			return nil, diags
+		}
+
+		if containsRepository(accessors, repositoryId) {
+			dependencies = append(dependencies, repository)
+		}
+	}
+
+	return dependencies, diags
+}
+
+func (receiver *LinkedRepositoryDependencyResource) updateDependencies(repositoryId int, adding []string, removing []string) error {
+	for _, repository := range adding {
+		dependency, _ := strconv.Atoi(repository)
+		_, err := receiver.client.RepositoryService().AddAccessor(dependency, repositoryId)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, repository := range removing {
+		dependency, _ := strconv.Atoi(repository)
+		err := receiver.client.RepositoryService().RemoveAccessor(dependency, repositoryId)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func repositoryIds(repositories []bamboo.Repository) []string {
+	var ids = make([]string, 0)
+	for _, repository := range repositories {
+		ids = append(ids, strconv.Itoa(repository.ID))
+	}
+
+	return ids
+}
@@ -0,0 +1,80 @@
+package provider
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestLinkedRepositoryDependencyResource_Mode(t *testing.T) {
+	tests := []struct {
+		name      string
+		mode      string
+		otherHas  bool
+		readAfter []string
+	}{
+		{name: "additive keeps access granted outside", mode: accessModeAdditive, otherHas: true, readAfter: []string{"2", "3"}},
+		{name: "authoritative removes and reports access granted outside", mode: accessModeAuthoritative, otherHas: false, readAfter: []string{"2", "3", "4"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			virtualization := newAccessVirtualization()
+			virtualization.GrantAccess("repository/4", 1)
+
+			harness := newResourceHarness(t, NewLinkedRepositoryDependencyResource(), virtualization, testBambooRss(false))
+			state, diags := harness.create(harness.plan(map[string]any{
+				"id":               "1",
+				"mode":             tt.mode,
+				"retain_on_delete": true,
+				"requires":         []string{"2", "3"},
+			}))
+			harness.require(diags)
+
+			for _, entity := range []string{"repository/2", "repository/3"} {
+				if got := sortedAccess(virtualization, entity); !reflect.DeepEqual(got, []int{1}) {
+					t.Errorf("Create() %s access = %v, want [1]", entity, got)
+				}
+			}
+
+			if got := len(virtualization.Access("repository/4")) == 1; got != tt.otherHas {
+				t.Errorf("Create() repository/4 keeps access = %v, want %v", got, tt.otherHas)
+			}
+
+			virtualization.GrantAccess("repository/4", 1)
+			state, diags = harness.read(state)
+			harness.require(diags)
+
+			if got := listAttribute(t, state, "requires"); !reflect.DeepEqual(got, tt.readAfter) {
+				t.Errorf("Read() requires = %v, want %v", got, tt.readAfter)
+			}
+		})
+	}
+}
+
+func TestLinkedRepositoryDependencyResource_ImportState(t *testing.T) {
+	tests := []struct {
+		name     string
+		id       string
+		wantMode string
+	}{
+		{name: "additive", id: "2", wantMode: accessModeAdditive},
+		{name: "authoritative", id: "2:authoritative", wantMode: accessModeAuthoritative},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			virtualization := newAccessVirtualization()
+			virtualization.GrantAccess("repository/1", 2)
+			virtualization.GrantAccess("repository/3", 2)
+
+			harness := newResourceHarness(t, NewLinkedRepositoryDependencyResource(), virtualization, testBambooRss(false))
+			state, diags := harness.importState(tt.id)
+			harness.require(diags)
+
+			requireImportedReferences(t, harness, state,
+				[]RepositoryReferenceData{{Id: "1", Name: "app"}, {Id: "3", Name: "specs"}},
+				map[string]any{"id": "2", "mode": tt.wantMode, "requires": []string{"1", "3"}},
+			)
+		})
+	}
+}
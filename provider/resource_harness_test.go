@@ -0,0 +1,156 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/yunarta/terraform-api-transport/transport"
+	"github.com/yunarta/terraform-atlassian-api-client/bamboo"
+)
+
+// resourceHarness drives the CRUD methods of a resource directly, so they can be tested against a virtualized Bamboo
+// without a Terraform binary.
+type resourceHarness struct {
+	t        *testing.T
+	ctx      context.Context
+	resource resource.Resource
+	schema   schema.Schema
+}
+
+func newResourceHarness(t *testing.T, target resource.Resource, payloadTransport transport.PayloadTransport, config BambooProviderConfig) *resourceHarness {
+	configureReceiver(target.(ConfigurableReceiver), &BambooProviderData{
+		config:         config,
+		client:         bamboo.NewBambooClient(payloadTransport),
+		extendedClient: NewExtendedClient(payloadTransport),
+	})
+
+	ctx := context.Background()
+	response := &resource.SchemaResponse{}
+	target.Schema(ctx, resource.SchemaRequest{}, response)
+	if response.Diagnostics.HasError() {
+		t.Fatalf("Schema() diagnostics = %v", response.Diagnostics)
+	}
+
+	return &resourceHarness{t: t, ctx: ctx, resource: target, schema: response.Schema}
+}
+
+func (harness *resourceHarness) null() tftypes.Value {
+	return tftypes.NewValue(harness.schema.Type().TerraformType(harness.ctx), nil)
+}
+
+// plan creates a plan with the attributes set, and everything else null.
+func (harness *resourceHarness) plan(attributes map[string]any) tfsdk.Plan {
+	return harness.planFrom(tfsdk.State{Schema: harness.schema, Raw: harness.null()}, attributes)
+}
+
+// planFrom creates a plan from the state with the attributes changed.
+func (harness *resourceHarness) planFrom(state tfsdk.State, attributes map[string]any) tfsdk.Plan {
+	plan := tfsdk.Plan{Schema: harness.schema, Raw: state.Raw.Copy()}
+	for name, value := range attributes {
+		harness.require(plan.SetAttribute(harness.ctx, path.Root(name), value))
+	}
+
+	return plan
+}
+
+func (harness *resourceHarness) create(plan tfsdk.Plan) (tfsdk.State, diag.Diagnostics) {
+	response := &resource.CreateResponse{State: tfsdk.State{Schema: harness.schema, Raw: harness.null()}}
+	harness.resource.Create(harness.ctx, resource.CreateRequest{Plan: plan}, response)
+	return response.State, response.Diagnostics
+}
+
+func (harness *resourceHarness) read(state tfsdk.State) (tfsdk.State, diag.Diagnostics) {
+	response := &resource.ReadResponse{State: state}
+	harness.resource.Read(harness.ctx, resource.ReadRequest{State: state}, response)
+	return response.State, response.Diagnostics
+}
+
+func (harness *resourceHarness) update(plan tfsdk.Plan, state tfsdk.State) (tfsdk.State, diag.Diagnostics) {
+	response := &resource.UpdateResponse{State: tfsdk.State{Schema: harness.schema, Raw: plan.Raw.Copy()}}
+	harness.resource.Update(harness.ctx, resource.UpdateRequest{Plan: plan, State: state}, response)
+	return response.State, response.Diagnostics
+}
+
+// modifyPlan runs the plan modification of the resource, as Terraform does before proposing the plan.
+func (harness *resourceHarness) modifyPlan(plan tfsdk.Plan, state tfsdk.State) (tfsdk.Plan, diag.Diagnostics) {
+	response := &resource.ModifyPlanResponse{Plan: tfsdk.Plan{Schema: harness.schema, Raw: plan.Raw.Copy()}}
+	harness.resource.(resource.ResourceWithModifyPlan).ModifyPlan(harness.ctx, resource.ModifyPlanRequest{Plan: plan, State: state}, response)
+	return response.Plan, response.Diagnostics
+}
+
+func (harness *resourceHarness) delete(state tfsdk.State) diag.Diagnostics {
+	response := &resource.DeleteResponse{State: state}
+	harness.resource.Delete(harness.ctx, resource.DeleteRequest{State: state}, response)
+	return response.Diagnostics
+}
+
+// importState imports the resource and refreshes it, as Terraform does on import.
+func (harness *resourceHarness) importState(id string) (tfsdk.State, diag.Diagnostics) {
+	response := &resource.ImportStateResponse{State: tfsdk.State{Schema: harness.schema, Raw: harness.null()}}
+	harness.resource.(resource.ResourceWithImportState).ImportState(harness.ctx, resource.ImportStateRequest{ID: id}, response)
+	if response.Diagnostics.HasError() {
+		return response.State, response.Diagnostics
+	}
+
+	return harness.read(response.State)
+}
+
+func (harness *resourceHarness) require(diags diag.Diagnostics) {
+	harness.t.Helper()
+	if diags.HasError() {
+		harness.t.Fatalf("unexpected diagnostics = %v", diags)
+	}
+}
+
+// attribute reads a string attribute from the state.
+func (harness *resourceHarness) attribute(state tfsdk.State, name string) string {
+	harness.t.Helper()
+
+	var value *string
+	harness.require(state.GetAttribute(harness.ctx, path.Root(name), &value))
+	if value == nil {
+		return ""
+	}
+
+	return *value
+}
+
+// readDataSource configures the data source and reads it with the attributes set in its config, and everything else null.
+func readDataSource(t *testing.T, target datasource.DataSource, payloadTransport transport.PayloadTransport, config BambooProviderConfig, attributes map[string]any) (tfsdk.State, diag.Diagnostics) {
+	t.Helper()
+
+	configureReceiver(target.(ConfigurableReceiver), &BambooProviderData{
+		config:         config,
+		client:         bamboo.NewBambooClient(payloadTransport),
+		extendedClient: NewExtendedClient(payloadTransport),
+	})
+
+	ctx := context.Background()
+	schemaResponse := &datasource.SchemaResponse{}
+	target.Schema(ctx, datasource.SchemaRequest{}, schemaResponse)
+	if schemaResponse.Diagnostics.HasError() {
+		t.Fatalf("Schema() diagnostics = %v", schemaResponse.Diagnostics)
+	}
+
+	dataSchema := schemaResponse.Schema
+	request := datasource.ReadRequest{Config: tfsdk.Config{Schema: dataSchema, Raw: tftypes.NewValue(dataSchema.Type().TerraformType(ctx), nil)}}
+
+	state := tfsdk.State{Schema: dataSchema, Raw: request.Config.Raw}
+	for name, value := range attributes {
+		if diags := state.SetAttribute(ctx, path.Root(name), value); diags.HasError() {
+			t.Fatalf("unexpected diagnostics = %v", diags)
+		}
+	}
+	request.Config.Raw = state.Raw
+
+	response := &datasource.ReadResponse{State: tfsdk.State{Schema: dataSchema, Raw: request.Config.Raw.Copy()}}
+	target.Read(ctx, request, response)
+	return response.State, response.Diagnostics
+}
@@ -0,0 +1,180 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/yunarta/terraform-atlassian-api-client/bamboo"
+	"slices"
+	"strings"
+)
+
+const (
+	roleLoggedIn  = "LOGGED_IN"
+	roleAnonymous = "ANONYMOUS"
+)
+
+var (
+	projectRoles    = []string{roleLoggedIn, roleAnonymous}
+	deploymentRoles = []string{roleLoggedIn, roleAnonymous}
+	// Bamboo does not grant linked repository permissions to anonymous users
+	repositoryRoles = []string{roleLoggedIn}
+)
+
+// RolesModel is the roles block, the permissions Bamboo grants to every logged-in user and to anonymous users.
+type RolesModel struct {
+	LoggedIn  types.List `tfsdk:"logged_in"`
+	Anonymous types.List `tfsdk:"anonymous"`
+}
+
+// RolesSchema creates the roles block, a resource without anonymous permissions only accepts an empty anonymous list.
+func RolesSchema(loggedIn []string, anonymous []string) schema.SingleNestedBlock {
+	return schema.SingleNestedBlock{
+		MarkdownDescription: "Permissions of the Bamboo roles. Without this block, or without one of its attributes, the role has no permission.",
+		Attributes: map[string]schema.Attribute{
+			"logged_in": rolePermissionsSchema("Permissions granted to every logged-in user.", loggedIn),
+			"anonymous": rolePermissionsSchema("Permissions granted to anonymous users.", anonymous),
+		},
+	}
+}
+
+func rolePermissionsSchema(description string, permissions []string) schema.ListAttribute {
+	if len(permissions) == 0 {
+		return schema.ListAttribute{
+			Optional:    true,
+			ElementType: types.StringType,
+			Validators: []validator.List{
+				listvalidator.SizeAtMost(0),
+			},
+			MarkdownDescription: "Not supported by Bamboo on this resource, must be empty.",
+		}
+	}
+
+	return schema.ListAttribute{
+		Optional:    true,
+		ElementType: types.StringType,
+		Validators: []validator.List{
+			listvalidator.ValueStringsAre(stringvalidator.OneOf(permissions...)),
+		},
+		MarkdownDescription: fmt.Sprintf("%s Valid values are `%s`.", description, strings.Join(permissions, "`, `")),
+	}
+}
+
+// rolesEqual reports whether both roles blocks are absent, or hold the same lists.
+func rolesEqual(a *RolesModel, b *RolesModel) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+
+	return a.LoggedIn.Equal(b.LoggedIn) && a.Anonymous.Equal(b.Anonymous)
+}
+
+// requestedRoles returns the permissions requested for each role, a missing block or attribute requests none.
+func requestedRoles(ctx context.Context, roles *RolesModel) (map[string][]string, diag.Diagnostics) {
+	requested := map[string][]string{
+		roleLoggedIn:  make([]string, 0),
+		roleAnonymous: make([]string, 0),
+	}
+
+	if roles == nil {
+		return requested, nil
+	}
+
+	for role, value := range map[string]types.List{roleLoggedIn: roles.LoggedIn, roleAnonymous: roles.Anonymous} {
+		if value.IsNull() || value.IsUnknown() {
+			continue
+		}
+
+		permissions := make([]string, 0)
+		diags := value.ElementsAs(ctx, &permissions, true)
+		if diags.HasError() {
+			return nil, diags
+		}
+
+		requested[role] = permissions
+	}
+
+	return requested, nil
+}
+
+// ApplyRoles grants each supported role the permissions requested in the roles block, revoking the others.
+func ApplyRoles(ctx context.Context, roles *RolesModel, supported []string, target string,
+	updateRolePermissions func(role string, requestedPermissions []string) error) diag.Diagnostics {
+
+	requested, diags := requestedRoles(ctx, roles)
+	if diags != nil {
+		return diags
+	}
+
+	for _, role := range supported {
+		err := updateRolePermissions(role, requested[role])
+		if err != nil {
+			diags.AddError(errorFailedToUpdateRolePermissions,
+				fmt.Sprintf("Failed to update the %s role permissions of %s: %s", role, target, err.Error()))
+		}
+	}
+
+	return diags
+}
+
+// ComputeRoles creates the roles block from the role permissions granted in Bamboo. The block and its lists are kept
+// as known when they hold the same permissions, so an unchanged role does not show as a difference.
+func ComputeRoles(ctx context.Context, known *RolesModel, granted []bamboo.RolePermission, supported []string) (*RolesModel, diag.Diagnostics) {
+	grantedPermissions := make(map[string][]string)
+	for _, role := range granted {
+		name := strings.ToUpper(role.Name)
+		if slices.Contains(supported, name) {
+			grantedPermissions[name] = role.Permissions
+		}
+	}
+
+	if known == nil {
+		if len(grantedPermissions[roleLoggedIn]) == 0 && len(grantedPermissions[roleAnonymous]) == 0 {
+			return nil, nil
+		}
+
+		known = &RolesModel{
+			LoggedIn:  types.ListNull(types.StringType),
+			Anonymous: types.ListNull(types.StringType),
+		}
+	}
+
+	loggedIn, diags := computeRolePermissions(ctx, known.LoggedIn, grantedPermissions[roleLoggedIn])
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	anonymous, diags := computeRolePermissions(ctx, known.Anonymous, grantedPermissions[roleAnonymous])
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	return &RolesModel{
+		LoggedIn:  loggedIn,
+		Anonymous: anonymous,
+	}, nil
+}
+
+func computeRolePermissions(ctx context.Context, known types.List, granted []string) (types.List, diag.Diagnostics) {
+	knownPermissions := make([]string, 0)
+	if !known.IsNull() && !known.IsUnknown() {
+		diags := known.ElementsAs(ctx, &knownPermissions, true)
+		if diags.HasError() {
+			return known, diags
+		}
+	}
+
+	grantedPermissions := slices.Clone(granted)
+	slices.Sort(knownPermissions)
+	slices.Sort(grantedPermissions)
+	if !known.IsUnknown() && slices.Equal(slices.Compact(knownPermissions), slices.Compact(grantedPermissions)) {
+		return known, nil
+	}
+
+	return types.ListValueFrom(ctx, types.StringType, grantedPermissions)
+}
@@ -0,0 +1,163 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"strings"
+	"time"
+)
+
+const defaultSpecsScanTimeout = 300
+const specsScanLogLines = 20
+
+// specsScanPollInterval is the delay between two reads of the scan status while waiting for a scan.
+var specsScanPollInterval = 5 * time.Second
+
+// SpecsScanSettings holds whether a linked repository is scanned for Bamboo Specs after a change, and how to wait for the scan.
+type SpecsScanSettings struct {
+	Scan        bool
+	Wait        bool
+	Timeout     time.Duration
+	FailOnError bool
+}
+
+func NewSpecsScanSettings(rssEnabled types.Bool, wait types.Bool, timeout types.Int64, failOnError types.Bool) SpecsScanSettings {
+	settings := SpecsScanSettings{
+		Scan:        rssEnabled.ValueBool(),
+		Wait:        wait.ValueBool(),
+		Timeout:     defaultSpecsScanTimeout * time.Second,
+		FailOnError: failOnError.IsNull() || failOnError.ValueBool(),
+	}
+
+	if !timeout.IsNull() && !timeout.IsUnknown() {
+		settings.Timeout = time.Duration(timeout.ValueInt64()) * time.Second
+	}
+
+	return settings
+}
+
+// withSpecsScanAttributes adds the attributes controlling and reporting the Bamboo Specs scan to the linked repository attributes.
+func withSpecsScanAttributes(attributes map[string]schema.Attribute) map[string]schema.Attribute {
+	attributes["wait_for_specs_scan"] = schema.BoolAttribute{
+		Optional:            true,
+		Computed:            true,
+		Default:             booldefault.StaticBool(false),
+		MarkdownDescription: "Default value is `false`, and if the value set to `true` when `rss_enabled` is `true`, the resource waits for the Bamboo Specs scan to finish and reports a failed scan.",
+	}
+	attributes["specs_scan_timeout"] = schema.Int64Attribute{
+		Optional: true,
+		Computed: true,
+		Default:  int64default.StaticInt64(defaultSpecsScanTimeout),
+		Validators: []validator.Int64{
+			int64validator.AtLeast(1),
+		},
+		MarkdownDescription: "Seconds to wait for the Bamboo Specs scan. Default value is `300`.",
+	}
+	attributes["fail_on_specs_scan_error"] = schema.BoolAttribute{
+		Optional:            true,
+		Computed:            true,
+		Default:             booldefault.StaticBool(true),
+		MarkdownDescription: "Default value is `true`, a failed Bamboo Specs scan is reported as an error, otherwise as a warning.",
+	}
+	attributes["last_specs_scan_result"] = schema.StringAttribute{
+		Computed:            true,
+		MarkdownDescription: "Result of the latest Bamboo Specs scan (SUCCESS, FAILED, QUEUED, IN_PROGRESS), unset when the repository was never scanned.",
+	}
+	attributes["last_specs_scan_time"] = schema.StringAttribute{
+		Computed:            true,
+		MarkdownDescription: "Finish time of the latest Bamboo Specs scan in RFC 3339 format.",
+	}
+
+	return attributes
+}
+
+// scanSpecs triggers a Bamboo Specs scan of the repository when scanning is enabled, and waits for it when requested.
+// It returns the latest scan state known to Bamboo, and stops waiting when the context is cancelled.
+func scanSpecs(ctx context.Context, receiver LinkedRepositoryPermissionsReceiver, extendedClient *ExtendedClient, repositoryId int, settings SpecsScanSettings) (*RepositorySpecsState, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	previous, err := extendedClient.RepositoryService().ReadSpecsState(repositoryId)
+	if err != nil {
+		diags.AddError(errorFailedToReadSpecsScan, err.Error())
+		return nil, diags
+	}
+
+	if !settings.Scan {
+		return previous, diags
+	}
+
+	err = receiver.getClient().RepositoryService().ScanCI(repositoryId)
+	if err != nil {
+		diags.AddError(errorFailedToScanRepository, err.Error())
+		return previous, diags
+	}
+
+	if !settings.Wait {
+		return previous, diags
+	}
+
+	deadline := time.Now().Add(settings.Timeout)
+	for {
+		state, err := extendedClient.RepositoryService().ReadSpecsState(repositoryId)
+		if err != nil {
+			diags.AddError(errorFailedToReadSpecsScan, err.Error())
+			return nil, diags
+		}
+
+		if state != nil && !state.running() && (previous == nil || state.StartTime > previous.StartTime) {
+			if state.State == specsScanFailed {
+				summary := fmt.Sprintf("Bamboo Specs scan of repository %d failed", repositoryId)
+				if settings.FailOnError {
+					diags.AddError(summary, specsScanLogSummary(state.Log))
+				} else {
+					diags.AddWarning(summary, specsScanLogSummary(state.Log))
+				}
+			}
+
+			return state, diags
+		}
+
+		if time.Now().After(deadline) {
+			diags.AddError(errorSpecsScanTimeout, fmt.Sprintf("Bamboo Specs scan of repository %d did not finish within %v", repositoryId, settings.Timeout))
+			return state, diags
+		}
+
+		select {
+		case <-ctx.Done():
+			diags.AddError(errorSpecsScanCancelled, fmt.Sprintf("Stopped waiting for the Bamboo Specs scan of repository %d: %s", repositoryId, ctx.Err()))
+			return state, diags
+		case <-time.After(specsScanPollInterval):
+		}
+	}
+}
+
+// specsScanLogSummary keeps the end of the scan log, where Bamboo reports the cause of the failure.
+func specsScanLogSummary(log string) string {
+	lines := strings.Split(strings.TrimSpace(log), "\n")
+	if len(lines) > specsScanLogLines {
+		lines = append([]string{"..."}, lines[len(lines)-specsScanLogLines:]...)
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// specsScanAttributes converts the scan state into the last_specs_scan_result and last_specs_scan_time values.
+func specsScanAttributes(state *RepositorySpecsState) (types.String, types.String) {
+	if state == nil {
+		return types.StringNull(), types.StringNull()
+	}
+
+	scanTime := types.StringNull()
+	if state.FinishTime != 0 {
+		scanTime = types.StringValue(formatBambooTime(state.FinishTime))
+	}
+
+	return types.StringValue(state.State), scanTime
+}
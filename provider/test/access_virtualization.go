@@ -0,0 +1,114 @@
+package test
+
+import (
+	"encoding/json"
+	"github.com/gorilla/mux"
+	"github.com/yunarta/golang-quality-of-life-pack/collections"
+	"github.com/yunarta/terraform-atlassian-api-client/bamboo"
+	"net/http"
+	"sort"
+	"strconv"
+)
+
+// AddProject registers a Bamboo project that will be served by the virtualized Bamboo.
+func (service *ServiceVirtualization) AddProject(key string, name string) {
+	service.bamboo.projects[key] = name
+}
+
+// Access returns the ids of the linked repositories granted Bamboo Specs access to an entity such as project/KEY,
+// deployment/1 or repository/2, as if changed through the UI.
+func (service *ServiceVirtualization) Access(entity string) []int {
+	return service.bamboo.access[entity]
+}
+
+// GrantAccess grants a linked repository Bamboo Specs access to an entity such as project/KEY, deployment/1 or repository/2.
+func (service *ServiceVirtualization) GrantAccess(entity string, repositoryId int) {
+	service.bamboo.access[entity] = collections.Unique(append(service.bamboo.access[entity], repositoryId))
+}
+
+func accessEntity(kind string, request *http.Request) string {
+	return kind + "/" + mux.Vars(request)["id"]
+}
+
+func (router *BambooRouter) projectListHandler(writer http.ResponseWriter, request *http.Request) {
+	startIndex, maxResult := router.page(request)
+
+	keys := make([]string, 0)
+	for key := range router.projects {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	type project struct {
+		Key  string `json:"key"`
+		Name string `json:"name"`
+	}
+
+	projects := make([]project, 0)
+	for index := startIndex; index < len(keys) && index < startIndex+maxResult; index++ {
+		projects = append(projects, project{Key: keys[index], Name: router.projects[keys[index]]})
+	}
+
+	writeJson(writer, 200, map[string]any{
+		"projects": map[string]any{
+			"size":        len(keys),
+			"start-index": startIndex,
+			"max-result":  maxResult,
+			"project":     projects,
+		},
+	})
+}
+
+func (router *BambooRouter) deploymentListHandler(writer http.ResponseWriter, request *http.Request) {
+	deployments := make([]bamboo.Deployment, 0)
+	for _, deployment := range router.deployments {
+		deployments = append(deployments, deployment)
+	}
+	sort.Slice(deployments, func(i, j int) bool {
+		return deployments[i].ID < deployments[j].ID
+	})
+
+	writeJson(writer, 200, deployments)
+}
+
+func (router *BambooRouter) accessHandler(kind string) http.HandlerFunc {
+	return func(writer http.ResponseWriter, request *http.Request) {
+		entity := accessEntity(kind, request)
+
+		switch request.Method {
+		case http.MethodGet:
+			repositories := make([]bamboo.Repository, 0)
+			for _, id := range router.access[entity] {
+				if repository, ok := router.repositories[id]; ok {
+					repositories = append(repositories, bamboo.Repository{ID: id, Name: repository.Name, RssEnabled: repository.RssEnabled})
+				}
+			}
+
+			writeJson(writer, 200, repositories)
+		case http.MethodPost:
+			var payload map[string]int
+			_ = json.NewDecoder(request.Body).Decode(&payload)
+
+			repository, ok := router.repositories[payload["id"]]
+			if !ok {
+				writer.WriteHeader(500)
+				return
+			}
+
+			router.access[entity] = collections.Unique(append(router.access[entity], repository.ID))
+			writeJson(writer, 201, bamboo.Repository{ID: repository.ID, Name: repository.Name, RssEnabled: repository.RssEnabled})
+		case http.MethodDelete:
+			repositoryId, _ := strconv.Atoi(mux.Vars(request)["repository"])
+
+			remaining := make([]int, 0)
+			for _, id := range router.access[entity] {
+				if id != repositoryId {
+					remaining = append(remaining, id)
+				}
+			}
+
+			router.access[entity] = remaining
+			writer.WriteHeader(204)
+		}
+	}
+}
@@ -0,0 +1,60 @@
+package test
+
+import (
+	"github.com/yunarta/terraform-atlassian-api-client/bamboo"
+	"net/http"
+	"strconv"
+)
+
+// AddAgentAssignment assigns an executable to an agent, as if assigned through the UI.
+func (service *ServiceVirtualization) AddAgentAssignment(assignment bamboo.AgentAssignment) {
+	service.bamboo.assignments = append(service.bamboo.assignments, assignment)
+}
+
+// AgentAssignments returns the executables assigned to the agents.
+func (service *ServiceVirtualization) AgentAssignments() []bamboo.AgentAssignment {
+	return service.bamboo.assignments
+}
+
+func (router *BambooRouter) agentAssignmentHandler(writer http.ResponseWriter, request *http.Request) {
+	query := request.URL.Query()
+	executorType := query.Get("executorType")
+	executorId, _ := strconv.ParseInt(query.Get("executorId"), 10, 64)
+
+	if request.Method == http.MethodGet {
+		assignments := make([]bamboo.AgentAssignment, 0)
+		for _, assignment := range router.assignments {
+			if assignment.ExecutorType == executorType && assignment.ExecutorId == executorId {
+				assignments = append(assignments, assignment)
+			}
+		}
+
+		writeJson(writer, 200, assignments)
+		return
+	}
+
+	entityId, _ := strconv.ParseInt(query.Get("entityId"), 10, 64)
+	assignment := bamboo.AgentAssignment{
+		ExecutorType:   executorType,
+		ExecutorId:     executorId,
+		ExecutableId:   entityId,
+		ExecutableType: query.Get("assignmentType"),
+	}
+
+	remaining := make([]bamboo.AgentAssignment, 0)
+	for _, existing := range router.assignments {
+		if existing != assignment {
+			remaining = append(remaining, existing)
+		}
+	}
+
+	if request.Method == http.MethodPost {
+		remaining = append(remaining, assignment)
+		router.assignments = remaining
+		writeJson(writer, 200, assignment)
+		return
+	}
+
+	router.assignments = remaining
+	writer.WriteHeader(204)
+}
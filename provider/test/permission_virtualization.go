@@ -0,0 +1,156 @@
+package test
+
+import (
+	"encoding/json"
+	"github.com/gorilla/mux"
+	"github.com/yunarta/golang-quality-of-life-pack/collections"
+	"github.com/yunarta/terraform-atlassian-api-client/bamboo"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// Permissions holds the granted permissions of an entity, keyed by user, group and role name.
+type Permissions struct {
+	Users  map[string][]string
+	Groups map[string][]string
+	Roles  map[string][]string
+}
+
+func newPermissions() *Permissions {
+	return &Permissions{
+		Users:  make(map[string][]string),
+		Groups: make(map[string][]string),
+		Roles:  make(map[string][]string),
+	}
+}
+
+func (permissions *Permissions) kind(kind string) map[string][]string {
+	switch kind {
+	case "users":
+		return permissions.Users
+	case "groups":
+		return permissions.Groups
+	default:
+		return permissions.Roles
+	}
+}
+
+// AddUser registers a user in the directory of the virtualized Bamboo.
+func (service *ServiceVirtualization) AddUser(name string) {
+	service.bamboo.users = append(service.bamboo.users, name)
+}
+
+// AddGroup registers a group in the directory of the virtualized Bamboo.
+func (service *ServiceVirtualization) AddGroup(name string) {
+	service.bamboo.groups = append(service.bamboo.groups, name)
+}
+
+// Permissions returns the permissions of an entity such as repository/1 or deployment/2, as if changed through the UI.
+func (service *ServiceVirtualization) Permissions(entity string) *Permissions {
+	return service.bamboo.permissionsOf(entity)
+}
+
+func (router *BambooRouter) permissionsOf(entity string) *Permissions {
+	permissions, ok := router.permissions[entity]
+	if !ok {
+		permissions = newPermissions()
+		router.permissions[entity] = permissions
+	}
+
+	return permissions
+}
+
+func permissionEntity(request *http.Request) string {
+	vars := mux.Vars(request)
+	return vars["entity"] + "/" + vars["id"]
+}
+
+func (router *BambooRouter) permissionsHandler(writer http.ResponseWriter, request *http.Request) {
+	kind := mux.Vars(request)["kind"]
+	name := request.URL.Query().Get("name")
+	granted := router.permissionsOf(permissionEntity(request)).kind(kind)
+
+	names := make([]string, 0)
+	for key, permissions := range granted {
+		if len(permissions) > 0 && (name == "" || strings.EqualFold(key, name)) {
+			names = append(names, key)
+		}
+	}
+	sort.Strings(names)
+
+	switch kind {
+	case "users":
+		results := make([]bamboo.UserPermission, 0)
+		for _, key := range names {
+			results = append(results, bamboo.UserPermission{Name: key, Permissions: granted[key]})
+		}
+		writeJson(writer, 200, bamboo.UserPermissionResponse{Results: results})
+	case "groups":
+		results := make([]bamboo.GroupPermission, 0)
+		for _, key := range names {
+			results = append(results, bamboo.GroupPermission{Name: key, Editable: true, Permissions: granted[key]})
+		}
+		writeJson(writer, 200, bamboo.GroupPermissionResponse{Results: results})
+	default:
+		results := make([]bamboo.RolePermission, 0)
+		for _, key := range names {
+			results = append(results, bamboo.RolePermission{Name: key, Permissions: granted[key]})
+		}
+		writeJson(writer, 200, bamboo.RolePermissionResponse{Results: results})
+	}
+}
+
+func (router *BambooRouter) permissionUpdateHandler(writer http.ResponseWriter, request *http.Request) {
+	vars := mux.Vars(request)
+	granted := router.permissionsOf(permissionEntity(request)).kind(vars["kind"])
+
+	var requested []string
+	_ = json.NewDecoder(request.Body).Decode(&requested)
+
+	name := vars["name"]
+	switch request.Method {
+	case http.MethodPut:
+		granted[name] = collections.Unique(append(granted[name], requested...))
+	case http.MethodDelete:
+		remaining := make([]string, 0)
+		for _, permission := range granted[name] {
+			if !collections.Contains(requested, permission) {
+				remaining = append(remaining, permission)
+			}
+		}
+		granted[name] = remaining
+	}
+
+	writer.WriteHeader(204)
+}
+
+func (router *BambooRouter) availableHandler(writer http.ResponseWriter, request *http.Request) {
+	vars := mux.Vars(request)
+	name := request.URL.Query().Get("name")
+	permissions := router.permissionsOf(permissionEntity(request))
+
+	available := func(directory []string, granted map[string][]string) []string {
+		names := make([]string, 0)
+		for _, entry := range directory {
+			if len(granted[entry]) == 0 && (name == "" || strings.EqualFold(entry, name)) {
+				names = append(names, entry)
+			}
+		}
+		return names
+	}
+
+	if vars["kind"] == "users" {
+		results := make([]bamboo.UserPermission, 0)
+		for _, user := range available(router.users, permissions.Users) {
+			results = append(results, bamboo.UserPermission{Name: user})
+		}
+		writeJson(writer, 200, bamboo.UserPermissionResponse{Results: results})
+	} else {
+		results := make([]bamboo.GroupPermission, 0)
+		for _, group := range available(router.groups, permissions.Groups) {
+			results = append(results, bamboo.GroupPermission{Name: group, Editable: true})
+		}
+		writeJson(writer, 200, bamboo.GroupPermissionResponse{Results: results})
+	}
+}
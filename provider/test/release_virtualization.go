@@ -0,0 +1,178 @@
+package test
+
+import (
+	"encoding/json"
+	"github.com/gorilla/mux"
+	"net/http"
+	"sort"
+	"strconv"
+)
+
+// Release is a release of a deployment project served by the virtualized Bamboo.
+type Release struct {
+	ID            int
+	DeploymentId  int
+	Name          string
+	CreationDate  int64
+	PlanResultKey string
+}
+
+type releaseItem struct {
+	PlanResultKey struct {
+		Key string `json:"key"`
+	} `json:"planResultKey"`
+}
+
+type releaseOutput struct {
+	ID           int           `json:"id"`
+	Name         string        `json:"name"`
+	CreationDate int64         `json:"creationDate"`
+	Items        []releaseItem `json:"items"`
+}
+
+func (release *Release) output() releaseOutput {
+	item := releaseItem{}
+	item.PlanResultKey.Key = release.PlanResultKey
+
+	return releaseOutput{
+		ID:           release.ID,
+		Name:         release.Name,
+		CreationDate: release.CreationDate,
+		Items:        []releaseItem{item},
+	}
+}
+
+// AddRelease registers a release of a deployment project, as if created through the UI.
+func (service *ServiceVirtualization) AddRelease(release Release) {
+	service.bamboo.releases[release.ID] = &release
+}
+
+// Release returns the release with the id, as it is seen in the UI.
+func (service *ServiceVirtualization) Release(id int) (Release, bool) {
+	release, ok := service.bamboo.releases[id]
+	if !ok {
+		return Release{}, false
+	}
+
+	return *release, true
+}
+
+// RemoveRelease deletes the release, as if removed through the UI.
+func (service *ServiceVirtualization) RemoveRelease(id int) {
+	delete(service.bamboo.releases, id)
+}
+
+// Deploy records the release as the latest deployment of the environment.
+func (service *ServiceVirtualization) Deploy(environmentId int, releaseId int) {
+	service.bamboo.deployed[environmentId] = releaseId
+}
+
+func (router *BambooRouter) deploymentReleases(deploymentId int) []*Release {
+	releases := make([]*Release, 0)
+	for _, release := range router.releases {
+		if release.DeploymentId == deploymentId {
+			releases = append(releases, release)
+		}
+	}
+
+	sort.Slice(releases, func(i, j int) bool {
+		return releases[i].ID < releases[j].ID
+	})
+
+	return releases
+}
+
+func (router *BambooRouter) releaseListHandler(writer http.ResponseWriter, request *http.Request) {
+	deploymentId, _ := strconv.Atoi(mux.Vars(request)["id"])
+	startIndex, maxResult := router.page(request)
+
+	releases := router.deploymentReleases(deploymentId)
+	versions := make([]releaseOutput, 0)
+	for index := startIndex; index < len(releases) && index < startIndex+maxResult; index++ {
+		versions = append(versions, releases[index].output())
+	}
+
+	writeJson(writer, 200, map[string]any{
+		"start-index": startIndex,
+		"max-result":  maxResult,
+		"versions":    versions,
+	})
+}
+
+func (router *BambooRouter) releaseCreateHandler(writer http.ResponseWriter, request *http.Request) {
+	deploymentId, _ := strconv.Atoi(mux.Vars(request)["id"])
+	if _, ok := router.deployments[strconv.Itoa(deploymentId)]; !ok {
+		writer.WriteHeader(404)
+		return
+	}
+
+	var create struct {
+		Name          string `json:"name"`
+		PlanResultKey string `json:"planResultKey"`
+	}
+	_ = json.NewDecoder(request.Body).Decode(&create)
+
+	id := 1
+	for {
+		if _, ok := router.releases[id]; !ok {
+			break
+		}
+		id++
+	}
+
+	release := &Release{
+		ID:            id,
+		DeploymentId:  deploymentId,
+		Name:          create.Name,
+		CreationDate:  int64(id) * 1000,
+		PlanResultKey: create.PlanResultKey,
+	}
+	router.releases[id] = release
+
+	writeJson(writer, 200, release.output())
+}
+
+func (router *BambooRouter) releaseHandler(writer http.ResponseWriter, request *http.Request) {
+	id, _ := strconv.Atoi(mux.Vars(request)["id"])
+	release, ok := router.releases[id]
+	if !ok {
+		writer.WriteHeader(404)
+		return
+	}
+
+	if request.Method == http.MethodDelete {
+		delete(router.releases, id)
+		writer.WriteHeader(204)
+		return
+	}
+
+	writeJson(writer, 200, release.output())
+}
+
+func (router *BambooRouter) dashboardHandler(writer http.ResponseWriter, request *http.Request) {
+	deploymentId, _ := strconv.Atoi(mux.Vars(request)["id"])
+
+	statuses := make([]map[string]any, 0)
+	for _, environment := range router.environments[deploymentId] {
+		status := map[string]any{
+			"environment": map[string]any{
+				"id":   environment.ID,
+				"name": environment.Name,
+			},
+		}
+
+		if release, ok := router.releases[router.deployed[environment.ID]]; ok {
+			status["deploymentResult"] = map[string]any{
+				"deploymentVersion": release.output(),
+				"deploymentState":   "SUCCESS",
+				"lifeCycleState":    "FINISHED",
+			}
+		}
+
+		statuses = append(statuses, status)
+	}
+
+	writeJson(writer, 200, []map[string]any{
+		{"environmentStatuses": statuses},
+	})
+}
@@ -0,0 +1,408 @@
+package test
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"github.com/gorilla/mux"
+	"github.com/yunarta/terraform-atlassian-api-client/bamboo"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Repository is a linked repository served by the virtualized Bamboo, Spec holds the imported Bamboo Specs properties.
+type Repository struct {
+	ID         int
+	Name       string
+	RssEnabled bool
+	Spec       map[string]any
+
+	// Plans and Deployments are the plan keys and deployment ids using the repository, mapped to their names.
+	Plans       map[string]string
+	Deployments map[int]string
+
+	// ScanOutcome is the outcome of the next Bamboo Specs scans, a scan succeeds when it is nil.
+	ScanOutcome *SpecsScan
+
+	scans    int
+	lastScan *specsState
+}
+
+// SpecsScan describes a Bamboo Specs scan, Pending is the number of status reads reporting the scan still in progress.
+type SpecsScan struct {
+	State   string
+	Log     string
+	Pending int
+}
+
+type specsState struct {
+	State      string `json:"state"`
+	StartTime  int64  `json:"startTime"`
+	FinishTime int64  `json:"finishTime,omitempty"`
+	Log        string `json:"log,omitempty"`
+
+	pending int
+	outcome SpecsScan
+}
+
+func (repository *Repository) owner() string {
+	if project, ok := repository.Spec["project"].(map[string]any); ok {
+		key, _ := project["key"].(string)
+		return key
+	}
+
+	return ""
+}
+
+// scanEpoch is the time of the first scan, 2024-01-01T00:00:00Z.
+const scanEpoch int64 = 1704067200000
+
+// AddRepository registers a linked repository that will be served by the virtualized Bamboo.
+func (service *ServiceVirtualization) AddRepository(repository Repository) {
+	if repository.Spec == nil {
+		repository.Spec = make(map[string]any)
+	}
+
+	repository.Spec["name"] = repository.Name
+	service.bamboo.repositories[repository.ID] = &repository
+}
+
+// RemoveRepository deletes a linked repository, as if deleted through the UI.
+func (service *ServiceVirtualization) RemoveRepository(id int) {
+	service.bamboo.removeRepository(id)
+}
+
+// removeRepository deletes the repository along with the Bamboo Specs access granted to it, like Bamboo does.
+func (router *BambooRouter) removeRepository(id int) {
+	delete(router.repositories, id)
+	delete(router.access, fmt.Sprintf("repository/%d", id))
+
+	for entity, repositories := range router.access {
+		remaining := make([]int, 0)
+		for _, repositoryId := range repositories {
+			if repositoryId != id {
+				remaining = append(remaining, repositoryId)
+			}
+		}
+
+		router.access[entity] = remaining
+	}
+}
+
+// Repository returns the linked repository with the id, or nil when there is no such repository.
+func (service *ServiceVirtualization) Repository(id int) *Repository {
+	return service.bamboo.repositories[id]
+}
+
+func (router *BambooRouter) sortedRepositories(filter func(repository *Repository) bool) []bamboo.Repository {
+	results := make([]bamboo.Repository, 0)
+	for _, repository := range router.repositories {
+		if filter(repository) {
+			results = append(results, bamboo.Repository{
+				ID:         repository.ID,
+				Name:       repository.Name,
+				RssEnabled: repository.RssEnabled,
+			})
+		}
+	}
+
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].ID < results[j].ID
+	})
+	return results
+}
+
+func (router *BambooRouter) repositorySearchHandler(writer http.ResponseWriter, request *http.Request) {
+	searchTerm := strings.ToLower(request.URL.Query().Get("searchTerm"))
+	startIndex, maxResult := router.page(request)
+
+	// project linked repositories are not listed with the global linked repositories
+	results := router.sortedRepositories(func(repository *Repository) bool {
+		return repository.owner() == "" && strings.Contains(strings.ToLower(repository.Name), searchTerm)
+	})
+
+	results = results[min(startIndex, len(results)):min(startIndex+maxResult, len(results))]
+	writeJson(writer, 200, bamboo.RepositoryList{
+		Start:     startIndex,
+		MaxResult: maxResult,
+		Results:   results,
+	})
+}
+
+func (router *BambooRouter) projectRepositoriesHandler(writer http.ResponseWriter, request *http.Request) {
+	project := mux.Vars(request)["project"]
+	query := request.URL.Query()
+	filter := strings.ToLower(query.Get("filter"))
+	startIndex, maxResult := router.page(request)
+
+	results := router.sortedRepositories(func(repository *Repository) bool {
+		return repository.owner() == project && strings.Contains(strings.ToLower(repository.Name), filter)
+	})
+
+	writeJson(writer, 200, bamboo.ProjectRepositoryList{
+		Start:     startIndex,
+		MaxResult: maxResult,
+		Results:   results[min(startIndex, len(results)):min(startIndex+maxResult, len(results))],
+	})
+}
+
+func (router *BambooRouter) repositoryHandler(writer http.ResponseWriter, request *http.Request) {
+	repository, ok := router.repository(request)
+	if !ok {
+		writer.WriteHeader(404)
+		return
+	}
+
+	detail := make(map[string]any)
+	for key, value := range repository.Spec {
+		detail[key] = value
+	}
+	detail["id"] = repository.ID
+	detail["rssEnabled"] = repository.RssEnabled
+
+	writeJson(writer, 200, detail)
+}
+
+func (router *BambooRouter) repositoryEnableCiHandler(writer http.ResponseWriter, request *http.Request) {
+	repository, ok := router.repository(request)
+	if !ok {
+		writer.WriteHeader(404)
+		return
+	}
+
+	var payload map[string]bool
+	_ = json.NewDecoder(request.Body).Decode(&payload)
+	repository.RssEnabled = payload["enable"]
+
+	writer.WriteHeader(200)
+}
+
+func (router *BambooRouter) repositoryScanHandler(writer http.ResponseWriter, request *http.Request) {
+	repository, ok := router.repository(request)
+	if !ok {
+		writer.WriteHeader(404)
+		return
+	}
+
+	outcome := SpecsScan{State: "SUCCESS"}
+	if repository.ScanOutcome != nil {
+		outcome = *repository.ScanOutcome
+	}
+
+	repository.scans++
+	repository.lastScan = &specsState{
+		State:     "IN_PROGRESS",
+		StartTime: scanEpoch + int64(repository.scans)*60000,
+		pending:   outcome.Pending,
+		outcome:   outcome,
+	}
+
+	writer.WriteHeader(204)
+}
+
+func (router *BambooRouter) repositorySpecsStateHandler(writer http.ResponseWriter, request *http.Request) {
+	repository, ok := router.repository(request)
+	if !ok || repository.lastScan == nil {
+		writer.WriteHeader(404)
+		return
+	}
+
+	scan := repository.lastScan
+	if scan.pending > 0 {
+		scan.pending--
+	} else if scan.FinishTime == 0 {
+		scan.State = scan.outcome.State
+		scan.Log = scan.outcome.Log
+		scan.FinishTime = scan.StartTime + 1000
+	}
+
+	writeJson(writer, 200, scan)
+}
+
+func (router *BambooRouter) repositoryExportHandler(writer http.ResponseWriter, request *http.Request) {
+	repository, ok := router.repository(request)
+	if !ok {
+		writeJson(writer, 200, []string{})
+		return
+	}
+
+	writeJson(writer, 200, []string{fmt.Sprintf("repositories/oid%d.yaml", repository.ID)})
+}
+
+func (router *BambooRouter) repositoryImportHandler(writer http.ResponseWriter, request *http.Request) {
+	body, _ := io.ReadAll(request.Body)
+	spec := parseSpec(string(body))
+
+	var repository *Repository
+	if oid, ok := spec["oid"].(map[string]any); ok {
+		value, _ := oid["oid"].(string)
+		id, _ := strconv.Atoi(strings.TrimPrefix(value, "oid"))
+		repository = router.repositories[id]
+		delete(spec, "oid")
+	}
+
+	if repository == nil {
+		id := 1
+		for existing := range router.repositories {
+			if existing >= id {
+				id = existing + 1
+			}
+		}
+
+		repository = &Repository{ID: id}
+		router.repositories[id] = repository
+	}
+
+	repository.Name, _ = spec["name"].(string)
+	repository.Spec = spec
+
+	writer.WriteHeader(200)
+	_, _ = writer.Write([]byte(fmt.Sprintf("/admin/configureLinkedRepositories.action?repositoryId=%d", repository.ID)))
+}
+
+func (router *BambooRouter) repository(request *http.Request) (*Repository, bool) {
+	id, _ := strconv.Atoi(mux.Vars(request)["id"])
+	repository, ok := router.repositories[id]
+	return repository, ok
+}
+
+// parseSpec reads the root entity of a Bamboo Specs YAML document, as produced by the provider, into nested maps.
+func parseSpec(document string) map[string]any {
+	type level struct {
+		indent int
+		values map[string]any
+	}
+
+	root := make(map[string]any)
+	var stack []level
+
+	scanner := bufio.NewScanner(strings.NewReader(document))
+	for scanner.Scan() {
+		line := scanner.Text()
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+
+		indent := len(line) - len(strings.TrimLeft(line, " "))
+		if indent == 0 {
+			if strings.HasPrefix(line, "rootEntity:") {
+				stack = []level{{indent: 0, values: root}}
+			} else {
+				stack = nil
+			}
+			continue
+		}
+
+		if stack == nil {
+			continue
+		}
+
+		for len(stack) > 1 && stack[len(stack)-1].indent >= indent {
+			stack = stack[:len(stack)-1]
+		}
+
+		key, value, _ := strings.Cut(strings.TrimSpace(line), ":")
+		value = strings.TrimSpace(value)
+
+		current := stack[len(stack)-1].values
+		if value == "" || strings.HasPrefix(value, "!!") {
+			nested := make(map[string]any)
+			current[key] = nested
+			stack = append(stack, level{indent: indent, values: nested})
+			continue
+		}
+
+		current[key] = parseScalar(value)
+	}
+
+	return root
+}
+
+func parseScalar(value string) any {
+	if unquoted, err := strconv.Unquote(value); err == nil {
+		return unquoted
+	}
+
+	if parsed, err := strconv.ParseBool(value); err == nil {
+		return parsed
+	}
+
+	if parsed, err := strconv.Atoi(value); err == nil {
+		return parsed
+	}
+
+	return value
+}
+
+// pageOf returns the start-index and max-result of a paged request, Bamboo returns 25 results by default.
+func writeJson(writer http.ResponseWriter, status int, value any) {
+	output, _ := json.Marshal(value)
+
+	writer.WriteHeader(status)
+	_, _ = writer.Write(output)
+}
+
+func (router *BambooRouter) repositoryUsageHandler(writer http.ResponseWriter, request *http.Request) {
+	repository, ok := router.repository(request)
+	if !ok {
+		writer.WriteHeader(404)
+		return
+	}
+
+	type plan struct {
+		Key        string `json:"key"`
+		Name       string `json:"name"`
+		ProjectKey string `json:"projectKey"`
+	}
+
+	type deployment struct {
+		ID   int    `json:"id"`
+		Name string `json:"name"`
+	}
+
+	plans := make([]plan, 0)
+	for key, name := range repository.Plans {
+		projectKey, _, _ := strings.Cut(key, "-")
+		plans = append(plans, plan{Key: key, Name: name, ProjectKey: projectKey})
+	}
+	sort.Slice(plans, func(i, j int) bool {
+		return plans[i].Key < plans[j].Key
+	})
+
+	deployments := make([]deployment, 0)
+	for id, name := range repository.Deployments {
+		deployments = append(deployments, deployment{ID: id, Name: name})
+	}
+	sort.Slice(deployments, func(i, j int) bool {
+		return deployments[i].ID < deployments[j].ID
+	})
+
+	writeJson(writer, 200, map[string]any{
+		"plans":       plans,
+		"deployments": deployments,
+	})
+}
+
+func (router *BambooRouter) repositoryDeleteHandler(writer http.ResponseWriter, request *http.Request) {
+	body, _ := io.ReadAll(request.Body)
+	form, _ := url.ParseQuery(string(body))
+
+	id, _ := strconv.Atoi(form.Get("repositoryId"))
+	repository, ok := router.repositories[id]
+	if !ok {
+		writer.WriteHeader(404)
+		return
+	}
+
+	if projectKey := form.Get("projectKey"); projectKey != "" && repository.owner() != projectKey {
+		writer.WriteHeader(404)
+		return
+	}
+
+	router.removeRepository(id)
+	writer.WriteHeader(200)
+}
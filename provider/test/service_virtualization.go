@@ -9,12 +9,20 @@ import (
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"sort"
 	"strconv"
 	"strings"
 )
 
 type ServiceVirtualization struct {
-	router *mux.Router
+	router   *mux.Router
+	bamboo   *BambooRouter
+	requests map[string]int
+}
+
+// Requests returns how many requests were sent with the method to the path.
+func (service *ServiceVirtualization) Requests(method string, path string) int {
+	return service.requests[method+" "+path]
 }
 
 var _ transport.PayloadTransport = &ServiceVirtualization{}
@@ -40,6 +48,8 @@ func (service *ServiceVirtualization) Send(request *transport.PayloadRequest) (*
 		return nil, err
 	}
 
+	service.requests[muxRequest.Method+" "+muxRequest.URL.Path]++
+
 	muxResponse := httptest.NewRecorder()
 	service.router.ServeHTTP(muxResponse, muxRequest)
 	return &transport.PayloadResponse{
@@ -49,45 +59,211 @@ func (service *ServiceVirtualization) Send(request *transport.PayloadRequest) (*
 }
 
 type BambooRouter struct {
-	deployments map[string]bamboo.Deployment
+	deployments  map[string]bamboo.Deployment
+	environments map[int][]Environment
+	releases     map[int]*Release
+	deployed     map[int]int
+	repositories map[int]*Repository
+	permissions  map[string]*Permissions
+	projects     map[string]string
+	access       map[string][]int
+	assignments  []bamboo.AgentAssignment
+	users        []string
+	groups       []string
+
+	// pageLimit caps max-result of the paged endpoints, like a Bamboo server configured with a lower limit.
+	pageLimit int
+}
+
+// LimitPageSize caps max-result of the paged endpoints below the 100 results Bamboo allows by default.
+func (service *ServiceVirtualization) LimitPageSize(limit int) {
+	service.bamboo.pageLimit = limit
+}
+
+// page returns the start-index and max-result of a paged request, as capped by the server.
+func (router *BambooRouter) page(request *http.Request) (int, int) {
+	query := request.URL.Query()
+	startIndex, _ := strconv.Atoi(query.Get("start-index"))
+	maxResult, err := strconv.Atoi(query.Get("max-result"))
+	if err != nil || maxResult > 100 {
+		maxResult = 100
+	}
+
+	if router.pageLimit > 0 && maxResult > router.pageLimit {
+		maxResult = router.pageLimit
+	}
+
+	return startIndex, maxResult
+}
+
+// AddDeployment registers a deployment project that will be served by the virtualized Bamboo.
+func (service *ServiceVirtualization) AddDeployment(deployment bamboo.Deployment) {
+	service.bamboo.deployments[strconv.Itoa(deployment.ID)] = deployment
 }
 
 func (router *BambooRouter) deploymentSearchHandler(writer http.ResponseWriter, request *http.Request) {
-	writer.WriteHeader(200)
+	searchTerm := strings.ToLower(request.URL.Query().Get("searchTerm"))
+	startIndex, maxResult := router.page(request)
 
-	deploymentItems := make([]bamboo.DeploymentItem, 0)
-	searchTerm := request.URL.Query().Get("searchTerm")
+	ids := make([]int, 0)
 	for _, value := range router.deployments {
-		if strings.Contains(value.Name, searchTerm) {
-			deploymentItems = append(deploymentItems, bamboo.DeploymentItem{
-				Id:   strconv.Itoa(value.ID),
-				Type: value.Name,
-				SearchEntity: bamboo.DeploymentEntity{
-					Id:          strconv.Itoa(value.ID),
-					Key:         value.PlanKey.Key,
-					ProjectName: value.Name,
-					Description: value.Description,
-				},
-			})
+		if strings.Contains(strings.ToLower(value.Name), searchTerm) {
+			ids = append(ids, value.ID)
 		}
 	}
+	sort.Ints(ids)
+
+	deploymentItems := make([]bamboo.DeploymentItem, 0)
+	for index := startIndex; index < len(ids) && index < startIndex+maxResult; index++ {
+		value := router.deployments[strconv.Itoa(ids[index])]
+		deploymentItems = append(deploymentItems, bamboo.DeploymentItem{
+			Id:   strconv.Itoa(value.ID),
+			Type: value.Name,
+			SearchEntity: bamboo.DeploymentEntity{
+				Id:          strconv.Itoa(value.ID),
+				Key:         value.PlanKey.Key,
+				ProjectName: value.Name,
+				Description: value.Description,
+			},
+		})
+	}
 
 	output, _ := json.Marshal(bamboo.DeploymentList{
-		Start:     0,
-		MaxResult: 100,
+		Start:     startIndex,
+		MaxResult: maxResult,
 		Results:   deploymentItems,
 	})
+
+	writer.WriteHeader(200)
 	_, _ = writer.Write(output)
 }
 
+// Environment is an environment of a deployment project served by the virtualized Bamboo, Position is its place in
+// the deployment as ordered in the UI.
+type Environment struct {
+	ID       int    `json:"id"`
+	Name     string `json:"name"`
+	Position int    `json:"position"`
+}
+
+// AddEnvironment registers an environment of a deployment project, environments are served in the order they are added.
+func (service *ServiceVirtualization) AddEnvironment(deploymentId int, environment Environment) {
+	service.bamboo.environments[deploymentId] = append(service.bamboo.environments[deploymentId], environment)
+}
+
+// Deployment returns the deployment project with the id, as it is seen in the UI.
+func (service *ServiceVirtualization) Deployment(id int) (bamboo.Deployment, bool) {
+	deployment, ok := service.bamboo.deployments[strconv.Itoa(id)]
+	return deployment, ok
+}
+
+func (router *BambooRouter) deploymentHandler(writer http.ResponseWriter, request *http.Request) {
+	id := mux.Vars(request)["id"]
+	deployment, ok := router.deployments[id]
+	if !ok {
+		writer.WriteHeader(404)
+		return
+	}
+
+	switch request.Method {
+	case http.MethodPost:
+		var update bamboo.UpdateDeployment
+		_ = json.NewDecoder(request.Body).Decode(&update)
+
+		deployment.Name = update.Name
+		deployment.PlanKey = update.PlanKey
+		deployment.Description = update.Description
+		router.deployments[id] = deployment
+	case http.MethodDelete:
+		delete(router.deployments, id)
+		writer.WriteHeader(204)
+		return
+	}
+
+	// the environments are served with their position, which bamboo.Environment does not carry
+	output := map[string]any{}
+	content, _ := json.Marshal(deployment)
+	_ = json.Unmarshal(content, &output)
+	if environments, ok := router.environments[deployment.ID]; ok {
+		output["environments"] = environments
+	}
+
+	writeJson(writer, 200, output)
+}
+
+func (router *BambooRouter) deploymentCreateHandler(writer http.ResponseWriter, request *http.Request) {
+	var create bamboo.CreateDeployment
+	_ = json.NewDecoder(request.Body).Decode(&create)
+
+	id := 1
+	for {
+		if _, ok := router.deployments[strconv.Itoa(id)]; !ok {
+			break
+		}
+		id++
+	}
+
+	deployment := bamboo.Deployment{
+		ID:          id,
+		Name:        create.Name,
+		PlanKey:     create.PlanKey,
+		Description: create.Description,
+	}
+	router.deployments[strconv.Itoa(id)] = deployment
+
+	writeJson(writer, 200, deployment)
+}
+
 func NewServiceVirtualization() *ServiceVirtualization {
 	bambooRouter := &BambooRouter{
-		deployments: make(map[string]bamboo.Deployment),
+		deployments:  make(map[string]bamboo.Deployment),
+		environments: make(map[int][]Environment),
+		releases:     make(map[int]*Release),
+		deployed:     make(map[int]int),
+		repositories: make(map[int]*Repository),
+		permissions:  make(map[string]*Permissions),
+		projects:     make(map[string]string),
+		access:       make(map[string][]int),
 	}
 
 	router := mux.NewRouter()
-	router.HandleFunc("/rest/api/latest/search/deployment", bambooRouter.deploymentSearchHandler)
+	router.HandleFunc("/rest/api/latest/search/deployments", bambooRouter.deploymentSearchHandler)
+	router.HandleFunc("/rest/api/latest/deploy/project/all", bambooRouter.deploymentListHandler).Methods(http.MethodGet)
+	router.HandleFunc("/rest/api/latest/deploy/project", bambooRouter.deploymentCreateHandler).Methods(http.MethodPut)
+	router.HandleFunc("/rest/api/latest/deploy/project/{id:[0-9]+}", bambooRouter.deploymentHandler).Methods(http.MethodGet, http.MethodPost, http.MethodDelete)
+	router.HandleFunc("/rest/api/latest/deploy/project/{id:[0-9]+}/repository", bambooRouter.accessHandler("deployment")).Methods(http.MethodGet, http.MethodPost)
+	router.HandleFunc("/rest/api/latest/deploy/project/{id:[0-9]+}/repository/{repository:[0-9]+}", bambooRouter.accessHandler("deployment")).Methods(http.MethodDelete)
+	router.HandleFunc("/rest/api/latest/deploy/project/{id:[0-9]+}/versions", bambooRouter.releaseListHandler).Methods(http.MethodGet)
+	router.HandleFunc("/rest/api/latest/deploy/project/{id:[0-9]+}/version", bambooRouter.releaseCreateHandler).Methods(http.MethodPost)
+	router.HandleFunc("/rest/api/latest/deploy/version/{id:[0-9]+}", bambooRouter.releaseHandler).Methods(http.MethodGet, http.MethodDelete)
+	router.HandleFunc("/rest/api/latest/deploy/dashboard/{id:[0-9]+}", bambooRouter.dashboardHandler).Methods(http.MethodGet)
+
+	router.HandleFunc("/rest/api/latest/project", bambooRouter.projectListHandler).Methods(http.MethodGet)
+	router.HandleFunc("/rest/api/latest/project/{id}/repository", bambooRouter.accessHandler("project")).Methods(http.MethodGet, http.MethodPost)
+	router.HandleFunc("/rest/api/latest/project/{id}/repository/{repository:[0-9]+}", bambooRouter.accessHandler("project")).Methods(http.MethodDelete)
+
+	router.HandleFunc("/rest/api/latest/repository", bambooRouter.repositorySearchHandler).Methods(http.MethodGet)
+	router.HandleFunc("/rest/api/latest/repository/{id:[0-9]+}", bambooRouter.repositoryHandler).Methods(http.MethodGet)
+	router.HandleFunc("/rest/api/latest/repository/{id:[0-9]+}/enableCi", bambooRouter.repositoryEnableCiHandler).Methods(http.MethodPut)
+	router.HandleFunc("/rest/api/latest/repository/{id:[0-9]+}/scanNow", bambooRouter.repositoryScanHandler).Methods(http.MethodPost)
+	router.HandleFunc("/rest/api/latest/repository/{id:[0-9]+}/specsState", bambooRouter.repositorySpecsStateHandler).Methods(http.MethodGet)
+	router.HandleFunc("/rest/api/latest/repository/{id:[0-9]+}/rssrepository", bambooRouter.accessHandler("repository")).Methods(http.MethodGet, http.MethodPost)
+	router.HandleFunc("/rest/api/latest/repository/{id:[0-9]+}/rssrepository/{repository:[0-9]+}", bambooRouter.accessHandler("repository")).Methods(http.MethodDelete)
+	router.HandleFunc("/rest/api/latest/repository/{id:[0-9]+}/usage", bambooRouter.repositoryUsageHandler).Methods(http.MethodGet)
+	router.HandleFunc("/admin/deleteLinkedRepository.action", bambooRouter.repositoryDeleteHandler).Methods(http.MethodPost)
+	router.HandleFunc("/project/deleteProjectRepository.action", bambooRouter.repositoryDeleteHandler).Methods(http.MethodPost)
+	router.HandleFunc("/rest/api/latest/project/{project}/repositories", bambooRouter.projectRepositoriesHandler).Methods(http.MethodGet)
+	router.HandleFunc("/rest/api/latest/import/repository", bambooRouter.repositoryImportHandler).Methods(http.MethodPost)
+	router.HandleFunc("/rest/api/latest/export/repository/id/{id:[0-9]+}", bambooRouter.repositoryExportHandler).Methods(http.MethodPost)
+
+	router.HandleFunc("/rest/api/latest/agent/assignment", bambooRouter.agentAssignmentHandler).Methods(http.MethodGet, http.MethodPost, http.MethodDelete)
+
+	router.HandleFunc("/rest/api/latest/permissions/{entity}/{id}/{kind:users|groups|roles}", bambooRouter.permissionsHandler).Methods(http.MethodGet)
+	router.HandleFunc("/rest/api/latest/permissions/{entity}/{id}/{kind:users|groups|roles}/{name}", bambooRouter.permissionUpdateHandler).Methods(http.MethodPut, http.MethodDelete)
+	router.HandleFunc("/rest/api/latest/permissions/{entity}/{id}/available-{kind:users|groups}", bambooRouter.availableHandler).Methods(http.MethodGet)
 	return &ServiceVirtualization{
-		router: router,
+		router:   router,
+		bamboo:   bambooRouter,
+		requests: make(map[string]int),
 	}
 }